@@ -14,13 +14,23 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// newClient returns a new zsys client object
+// newClient returns a new zsys client object.
+// Its context is cancelled after the timeout requested by the user, if any, which cancels the request on the daemon.
 func newClient() (*zsys.ZsysLogClient, error) {
 	// TODO: allow change socket address
 	c, err := zsys.NewZsysUnixSocketClient(config.SocketPath(), log.GetLevel())
 	if err != nil {
 		return nil, fmt.Errorf(i18n.G("couldn't connect to zsys daemon: %v"), err)
 	}
+	if flagTimeout > 0 {
+		ctx, cancel := context.WithTimeout(c.Ctx, flagTimeout)
+		// The client lives until the end of the command: release the context once it expired.
+		go func() {
+			<-ctx.Done()
+			cancel()
+		}()
+		c.Ctx = ctx
+	}
 	return c, nil
 }
 
@@ -32,6 +42,8 @@ func checkConn(err error, reset chan<- struct{}) error {
 			return fmt.Errorf(i18n.G("couldn't connect to zsys daemon: %v"), st.Message())
 		case codes.Canceled:
 			return context.Canceled
+		case codes.DeadlineExceeded:
+			return fmt.Errorf(i18n.G("request didn't complete in %s and was cancelled"), flagTimeout)
 		default:
 			return errors.New(st.Message())
 		}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/cmd/zsysd/cmdhandler"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/streamlogger"
+	"github.com/ubuntu/zsys/internal/units"
+)
+
+var (
+	datasetCmd = &cobra.Command{
+		Use:   "dataset COMMAND",
+		Short: i18n.G("Dataset management"),
+		Args:  cmdhandler.SubcommandsRequiredWithSuggestions,
+		Run:   cmdhandler.NoCmd,
+	}
+
+	datasetExcludeCmd = &cobra.Command{
+		Use:   "exclude PATH",
+		Short: i18n.G("Keep a path out of system states, in a persistent dataset."),
+		Long: i18n.G(`Keep a path out of system states, in a persistent dataset.
+If the path is a system dataset, it is moved with its children and snapshots outside of the system hierarchy.
+Otherwise, the path needs to be empty and a new dataset is created for it.`),
+		Args: cobra.ExactArgs(1),
+		Run:  func(cmd *cobra.Command, args []string) { cmdErr = excludePath(args[0]) },
+	}
+
+	datasetListCmd = &cobra.Command{
+		Use:   "list",
+		Short: i18n.G("List persistent datasets, shared between all system states."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = listDatasets() },
+	}
+
+	datasetCreatePersistentCmd = &cobra.Command{
+		Use:   "create-persistent PATH",
+		Short: i18n.G("Create a new persistent dataset for an empty path."),
+		Long: i18n.G(`Create a new persistent dataset for an empty path.
+The dataset is shared between all system states and reverting the system doesn't change its content.`),
+		Args: cobra.ExactArgs(1),
+		Run:  func(cmd *cobra.Command, args []string) { cmdErr = persistPath(args[0], true) },
+	}
+
+	datasetMakePersistentCmd = &cobra.Command{
+		Use:   "make-persistent PATH",
+		Short: i18n.G("Move a system dataset out of system states, in a persistent dataset."),
+		Long: i18n.G(`Move a system dataset out of system states, in a persistent dataset.
+The dataset is moved with its children and snapshots outside of the system hierarchy.
+On an already persistent dataset, only changes if it follows system states.`),
+		Args: cobra.ExactArgs(1),
+		Run:  func(cmd *cobra.Command, args []string) { cmdErr = persistPath(args[0], false) },
+	}
+)
+
+var datasetFollowSystem bool
+
+func init() {
+	rootCmd.AddCommand(datasetCmd)
+	datasetCmd.AddCommand(datasetExcludeCmd)
+	datasetCmd.AddCommand(datasetListCmd)
+	datasetCmd.AddCommand(datasetCreatePersistentCmd)
+	datasetCmd.AddCommand(datasetMakePersistentCmd)
+
+	datasetListCmd.Flags().StringVarP(&outputFormat, "format", "", formatText, i18n.G("Output format: text, json or yaml."))
+	for _, c := range []*cobra.Command{datasetCreatePersistentCmd, datasetMakePersistentCmd} {
+		c.Flags().BoolVarP(&datasetFollowSystem, "follow-system", "", false, i18n.G("Snapshot the dataset with each saved system state."))
+	}
+}
+
+func excludePath(path string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.DatasetExclude(ctx, &zsys.DatasetExcludeRequest{Path: path})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	var dataset string
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		dataset = r.GetDataset()
+	}
+
+	fmt.Printf(i18n.G("%s is now excluded from system states, in %s\n"), path, dataset)
+	return nil
+}
+
+func listDatasets() error {
+	if err := checkFormat(outputFormat); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.DatasetList(ctx, &zsys.Empty{})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	var ds *zsys.Datasets
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		ds = r.GetDatasets()
+	}
+
+	if ds == nil {
+		return nil
+	}
+	if outputFormat != formatText {
+		return printStructured(ds, outputFormat)
+	}
+
+	var out bytes.Buffer
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, i18n.G("Name\tMountpoint\tUsed\tExcluded\tFollow System\n"))
+	fmt.Fprint(w, i18n.G("----\t----------\t----\t--------\t-------------\n"))
+	for _, d := range ds.GetDatasets() {
+		fmt.Fprintf(w, i18n.G("%s\t%s\t%s\t%t\t%t\n"), d.GetName(), d.GetMountpoint(), units.HumanSize(d.GetUsed()), d.GetExcluded(), d.GetFollowSystem())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Print(out.String())
+
+	return nil
+}
+
+// persistPath creates a new persistent dataset for path if create is true, or makes the existing one persistent.
+func persistPath(path string, create bool) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	req := &zsys.DatasetPersistentRequest{Path: path, FollowSystem: datasetFollowSystem}
+	var stream interface {
+		Recv() (*zsys.DatasetPersistentResponse, error)
+	}
+	if create {
+		stream, err = client.DatasetCreatePersistent(ctx, req)
+	} else {
+		stream, err = client.DatasetMakePersistent(ctx, req)
+	}
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	var dataset string
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		dataset = r.GetDataset()
+	}
+
+	fmt.Printf(i18n.G("%s is persistent, in %s\n"), path, dataset)
+	return nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -10,6 +11,9 @@ import (
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/streamlogger"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
 )
 
 var (
@@ -33,24 +37,57 @@ var (
 		Args:  cobra.NoArgs,
 		Run:   func(cmd *cobra.Command, args []string) { cmdErr = list(args) },
 	}
+
+	cloneCmd = &cobra.Command{
+		Use:   "clone [state id]",
+		Short: i18n.G("Create a new machine from a saved system state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = clone(args[0], cloneUserData) },
+	}
+
+	machineremoveCmd = &cobra.Command{
+		Use:   "remove [machine id]",
+		Short: i18n.G("Remove a machine with its history and the user data only attached to it."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = removeMachine(args[0]) },
+	}
 )
 
 var (
-	fullInfo bool
+	fullInfo      bool
+	outputFormat  string
+	cloneUserData bool
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatYAML = "yaml"
 )
 
 func init() {
 	rootCmd.AddCommand(machineCmd)
 	machineCmd.AddCommand(showCmd)
 	machineCmd.AddCommand(listCmd)
+	machineCmd.AddCommand(cloneCmd)
+	machineCmd.AddCommand(machineremoveCmd)
 
 	showCmd.Flags().BoolVarP(&fullInfo, "full", "", false, i18n.G("Give more detail informations on each machine."))
+	showCmd.Flags().StringVarP(&outputFormat, "format", "", formatText, i18n.G("Output format: text, json or yaml."))
+	listCmd.Flags().StringVarP(&outputFormat, "format", "", formatText, i18n.G("Output format: text, json or yaml."))
+	cloneCmd.Flags().BoolVarP(&cloneUserData, "with-userdata", "", false, i18n.G("Clone user data of the state for the new machine too."))
+	machineremoveCmd.Flags().BoolVarP(&force, "force", "f", false, i18n.G("Force removing, even if dependencies are found or some states are pinned"))
+	machineremoveCmd.Flags().BoolVarP(&dryrun, "dry-run", "", false, i18n.G("Dry run, will not remove anything"))
 
 	cmdhandler.RegisterAlias(listCmd, rootCmd)
 	cmdhandler.RegisterAlias(showCmd, rootCmd)
 }
 
 func show(args []string) error {
+	if err := checkFormat(outputFormat); err != nil {
+		return err
+	}
+
 	var machineID string
 	if len(args) > 0 {
 		machineID = args[0]
@@ -70,6 +107,7 @@ func show(args []string) error {
 		return err
 	}
 
+	var m *zsys.Machine
 	for {
 		r, err := stream.Recv()
 		if err == streamlogger.ErrLogMsg {
@@ -82,12 +120,30 @@ func show(args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Printf(r.GetMachineInfo())
+		m = r.GetMachine()
+	}
+
+	if m == nil {
+		return nil
+	}
+	if outputFormat != formatText {
+		return printStructured(m, outputFormat)
+	}
+
+	info, err := m.Text(fullInfo)
+	if err != nil {
+		return err
 	}
+	fmt.Print(info)
 
 	return nil
 }
+
 func list(args []string) error {
+	if err := checkFormat(outputFormat); err != nil {
+		return err
+	}
+
 	client, err := newClient()
 	if err != nil {
 		return err
@@ -103,6 +159,58 @@ func list(args []string) error {
 		return err
 	}
 
+	var ms *zsys.Machines
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		ms = r.GetMachines()
+	}
+
+	if ms == nil {
+		return nil
+	}
+	if outputFormat != formatText {
+		return printStructured(ms, outputFormat)
+	}
+
+	machinesList, err := ms.Text()
+	if err != nil {
+		return err
+	}
+	fmt.Print(machinesList)
+
+	return nil
+}
+
+func clone(stateID string, withUserData bool) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.MachineClone(ctx, &zsys.MachineCloneRequest{
+		StateName:    stateID,
+		WithUserData: withUserData,
+	})
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	var machineID string
 	for {
 		r, err := stream.Recv()
 		if err == streamlogger.ErrLogMsg {
@@ -115,8 +223,106 @@ func list(args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Printf(r.GetMachineList())
+
+		machineID = r.GetMachineId()
+	}
+
+	fmt.Printf(i18n.G("Successfully created machine %q\n"), machineID)
+	return nil
+}
+
+func removeMachine(machineID string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	for {
+		err = removeMachineGRPC(client, force, dryrun, machineID)
+		if err == nil {
+			break
+		}
+
+		recoverableMsg := confirmationMessage(err)
+		if recoverableMsg == "" {
+			return err
+		}
+
+		confirmed, err := askConfirmation(recoverableMsg)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			break
+		}
+		force = true
+	}
+
+	return nil
+}
+
+func removeMachineGRPC(client *zsys.ZsysLogClient, force, dryrun bool, machineID string) error {
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.MachineRemove(ctx, &zsys.MachineRemoveRequest{
+		MachineId: machineID,
+		Force:     force,
+		Dryrun:    dryrun,
+	})
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err = stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// checkFormat returns an error if format isn't a supported output format.
+func checkFormat(format string) error {
+	switch format {
+	case formatText, formatJSON, formatYAML:
+		return nil
+	}
+	return fmt.Errorf(i18n.G("unsupported format %q. Supported formats are: %s, %s and %s"), format, formatText, formatJSON, formatYAML)
+}
+
+// printStructured prints a protobuf message as json or yaml.
+func printStructured(m proto.Message, format string) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't convert to json: %v"), err)
+	}
+	// Go through a generic structure for stable output and shared field names between json and yaml
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf(i18n.G("couldn't convert to json: %v"), err)
+	}
+
+	switch format {
+	case formatJSON:
+		b, err = json.MarshalIndent(v, "", "  ")
+		b = append(b, '\n')
+	case formatYAML:
+		b, err = yaml.Marshal(v)
+	}
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't convert to %s: %v"), format, err)
 	}
+	fmt.Print(string(b))
 
 	return nil
 }
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/user"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/ubuntu/zsys"
@@ -15,6 +19,7 @@ import (
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/streamlogger"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -70,7 +75,25 @@ var (
 		Use:   "gc",
 		Short: i18n.G("Run daemon state saves garbage collection."),
 		Args:  cobra.NoArgs,
-		Run:   func(cmd *cobra.Command, args []string) { cmdErr = gc(gcAll) },
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = gc(gcAll, gcDryrun, gcExplain) },
+	}
+	eventsCmd = &cobra.Command{
+		Use:   "events",
+		Short: i18n.G("Prints daemon events as they happen, until interrupted."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = watchEvents() },
+	}
+	historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: i18n.G("Shows write requests handled by the daemon, from the oldest to the most recent one."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = history() },
+	}
+	metricsCmd = &cobra.Command{
+		Use:   "metrics",
+		Short: i18n.G("Prints daemon metrics in the Prometheus text format."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = printMetrics() },
 	}
 )
 
@@ -79,6 +102,13 @@ var (
 	traceType     string
 	traceDuration int
 	gcAll         bool
+	gcDryrun      bool
+	gcExplain     bool
+
+	historySince     string
+	historyOperation string
+	historyMatch     string
+	historyLimit     uint32
 )
 
 func init() {
@@ -90,6 +120,7 @@ func init() {
 	serviceCmd.AddCommand(traceCmd)
 	serviceCmd.AddCommand(reloadCmd)
 	serviceCmd.AddCommand(gcCmd)
+	serviceCmd.AddCommand(eventsCmd)
 
 	traceCmd.Flags().StringVarP(&traceOutput, "output", "o", "", i18n.G("Dump the trace to a file. Default is ./zsys.<trace-type>.pprof"))
 	traceCmd.Flags().StringVarP(&traceType, "type", "t", "cpu", i18n.G("Type of profiling cpu or mem. Default is cpu."))
@@ -98,6 +129,18 @@ func init() {
 	serviceCmd.AddCommand(statusCmd)
 
 	gcCmd.Flags().BoolVarP(&gcAll, "all", "a", false, i18n.G("Collects all the datasets including manual snapshots and clones."))
+	gcCmd.Flags().BoolVarP(&gcDryrun, "dry-run", "", false, i18n.G("Dry run, will not remove anything"))
+	gcCmd.Flags().BoolVarP(&gcExplain, "explain", "", false, i18n.G("Explain why each state is kept or removed."))
+
+	eventsCmd.Flags().StringVarP(&outputFormat, "format", "", formatText, i18n.G("Output format: text, json or yaml."))
+
+	serviceCmd.AddCommand(historyCmd)
+	serviceCmd.AddCommand(metricsCmd)
+	historyCmd.Flags().StringVarP(&historySince, "since", "", "", i18n.G("Only show requests since this duration ago (e.g. 24h) or this date (e.g. 2020-06-01 or \"2020-06-01 10:00:00\")."))
+	historyCmd.Flags().StringVarP(&historyOperation, "operation", "", "", i18n.G("Only show requests of this operation (e.g. SaveSystemState, GC)."))
+	historyCmd.Flags().StringVarP(&historyMatch, "match", "", "", i18n.G("Only show requests with a parameter or a changed dataset containing this text."))
+	historyCmd.Flags().Uint32VarP(&historyLimit, "limit", "n", 0, i18n.G("Only show this number of most recent requests. No limit if 0."))
+	historyCmd.Flags().StringVarP(&outputFormat, "format", "", formatText, i18n.G("Output format: text, json or yaml."))
 }
 
 func daemonStop() error {
@@ -159,7 +202,10 @@ func dumpService() error {
 		if err != nil {
 			return err
 		}
-		fmt.Println(r.GetStates())
+		// Structured machines are only for programmatic access
+		if states := r.GetStates(); states != "" {
+			fmt.Println(states)
+		}
 	}
 
 	return nil
@@ -375,7 +421,7 @@ func reloadConfig() error {
 	return nil
 }
 
-func gc(gcAll bool) error {
+func gc(gcAll, gcDryrun, gcExplain bool) error {
 	client, err := newClient()
 	if err != nil {
 		return err
@@ -385,7 +431,7 @@ func gc(gcAll bool) error {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
 
-	stream, err := client.GC(ctx, &zsys.GCRequest{All: gcAll})
+	stream, err := client.GC(ctx, &zsys.GCRequest{All: gcAll, Dryrun: gcDryrun, Explain: gcExplain})
 	if err = checkConn(err, reset); err != nil {
 		return err
 	}
@@ -406,3 +452,226 @@ func gc(gcAll bool) error {
 
 	return nil
 }
+
+func watchEvents() error {
+	if err := checkFormat(outputFormat); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// No timeout: events can be spaced out for a long time.
+	stream, err := client.WatchEvents(client.Ctx, &zsys.Empty{})
+	if err = checkConn(err, nil); err != nil {
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		e := r.GetEvent()
+		if e == nil {
+			continue
+		}
+		if outputFormat != formatText {
+			if err := printStructured(e, outputFormat); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Printf("%s %s\n", zsys.FormatTime(e.GetTime()), eventToText(e))
+	}
+
+	return nil
+}
+
+// eventToText returns a human readable description of e.
+func eventToText(e *zsys.Event) string {
+	switch ev := e.GetEvent().(type) {
+	case *zsys.Event_StateSaved:
+		if u := ev.StateSaved.GetUser(); u != "" {
+			return fmt.Sprintf(i18n.G("State %s saved for user %s"), ev.StateSaved.GetStateName(), u)
+		}
+		return fmt.Sprintf(i18n.G("System state %s saved"), ev.StateSaved.GetStateName())
+	case *zsys.Event_StateRemoved:
+		if u := ev.StateRemoved.GetUser(); u != "" {
+			return fmt.Sprintf(i18n.G("State %s removed for user %s"), ev.StateRemoved.GetStateName(), u)
+		}
+		return fmt.Sprintf(i18n.G("System state %s removed"), ev.StateRemoved.GetStateName())
+	case *zsys.Event_GcStarted:
+		return fmt.Sprintf(i18n.G("Garbage collection started on %d system and %d user states"),
+			ev.GcStarted.GetSystemStates(), ev.GcStarted.GetUserStates())
+	case *zsys.Event_GcFinished:
+		return fmt.Sprintf(i18n.G("Garbage collection finished: %d states removed, %d system and %d user states left"),
+			ev.GcFinished.GetRemovedStates(), ev.GcFinished.GetSystemStates(), ev.GcFinished.GetUserStates())
+	case *zsys.Event_BootCommitted:
+		if ev.BootCommitted.GetChanged() {
+			return fmt.Sprintf(i18n.G("Boot committed on %s, with changes"), ev.BootCommitted.GetMachineId())
+		}
+		return fmt.Sprintf(i18n.G("Boot committed on %s"), ev.BootCommitted.GetMachineId())
+	case *zsys.Event_UserDataCreated:
+		return fmt.Sprintf(i18n.G("User data created for %s on %s"), ev.UserDataCreated.GetUser(), ev.UserDataCreated.GetHome())
+	case *zsys.Event_UserDataDissociated:
+		return fmt.Sprintf(i18n.G("User %s dissociated"), ev.UserDataDissociated.GetUser())
+	case *zsys.Event_RefreshCompleted:
+		return i18n.G("Refresh completed")
+	case *zsys.Event_LowSpace:
+		return fmt.Sprintf(i18n.G("Low space on pool %s: %d%% free, %d%% needed"),
+			ev.LowSpace.GetPool(), ev.LowSpace.GetFree(), ev.LowSpace.GetMin())
+	}
+	return i18n.G("Unknown event")
+}
+
+func history() error {
+	if err := checkFormat(outputFormat); err != nil {
+		return err
+	}
+	req := &zsys.HistoryRequest{
+		Operation: historyOperation,
+		Match:     historyMatch,
+		Limit:     historyLimit,
+	}
+	if historySince != "" {
+		since, err := parseSince(historySince, time.Now())
+		if err != nil {
+			return err
+		}
+		req.Since = timestamppb.New(since)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.History(ctx, req)
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		e := r.GetEntry()
+		if e == nil {
+			continue
+		}
+		if outputFormat != formatText {
+			if err := printStructured(e, outputFormat); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Print(journalEntryToText(e))
+	}
+
+	return nil
+}
+
+func printMetrics() error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.Metrics(ctx, &zsys.Empty{})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Print(r.GetMetrics())
+	}
+
+	return nil
+}
+
+// parseSince returns the time referred by since, either a duration before now or a local date with an optional time.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(i18n.G("invalid --since %q: expected a duration like 24h or a date like 2020-06-01 10:00:00"), since)
+}
+
+// journalEntryToText returns a human readable description of e, with its changed datasets indented.
+func journalEntryToText(e *zsys.JournalEntry) string {
+	var out strings.Builder
+
+	caller := strconv.Itoa(int(e.GetUid()))
+	if u, err := user.LookupId(caller); err == nil {
+		caller = fmt.Sprintf("%s(%s)", u.Username, caller)
+	}
+	if e.GetPid() != 0 {
+		caller = fmt.Sprintf("%s pid:%d", caller, e.GetPid())
+	}
+
+	var params []string
+	for k, v := range e.GetParameters() {
+		params = append(params, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(params)
+
+	result := i18n.G("ok")
+	if e.GetError() != "" {
+		result = fmt.Sprintf(i18n.G("failed: %s"), e.GetError())
+	}
+
+	fmt.Fprintf(&out, "%s %s %s", zsys.FormatTime(e.GetTime()), caller, e.GetOperation())
+	if len(params) > 0 {
+		fmt.Fprintf(&out, " %s", strings.Join(params, " "))
+	}
+	fmt.Fprintf(&out, ": %s\n", result)
+	for _, d := range e.GetDatasets() {
+		fmt.Fprintf(&out, "  %s\n", d)
+	}
+
+	return out.String()
+}
@@ -31,7 +31,7 @@ var (
 		Short: i18n.G("Saves the current state of the machine. By default it saves only the user state. state_id is generated if not provided."),
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdErr = saveState(args, system, userName, noUpdateBootMenu, saveAuto)
+			cmdErr = saveState(args, system, userName, noUpdateBootMenu, saveAuto, stateDescription, stateLabel)
 		},
 	}
 	stateremoveCmd = &cobra.Command{
@@ -40,6 +40,70 @@ var (
 		Args:  cobra.MaximumNArgs(1),
 		Run:   func(cmd *cobra.Command, args []string) { cmdErr = removeState(args) },
 	}
+	staterevertCmd = &cobra.Command{
+		Use:   "revert [state id]",
+		Short: i18n.G("Boot once on a given system state on next reboot. By default, it only reverts the system state."),
+		Long: i18n.G(`Boot once on a given system state on next reboot. By default, it only reverts the system state.
+The GRUB menu entry of the state is set as the next one to boot. Following boots use the default entry again.`),
+		Args: cobra.ExactArgs(1),
+		Run:  func(cmd *cobra.Command, args []string) { cmdErr = revertState(args[0], revertUserData) },
+	}
+	stateannotateCmd = &cobra.Command{
+		Use:   "annotate [state id]",
+		Short: i18n.G("Change the description or label of a state. By default it annotates only the user state."),
+		Long: i18n.G(`Change the description or label of a state. By default it annotates only the user state.
+They are shown by machine show and stored in com.ubuntu.zsys:description and com.ubuntu.zsys:label properties.
+System state annotations are also added to the GRUB menu history entries each time zsys updates the boot menu.`),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var description, label *string
+			if cmd.Flags().Changed("description") {
+				description = &stateDescription
+			}
+			if cmd.Flags().Changed("label") {
+				label = &stateLabel
+			}
+			cmdErr = annotateState(args[0], system, userName, description, label)
+		},
+	}
+	statepinCmd = &cobra.Command{
+		Use:   "pin [state id]",
+		Short: i18n.G("Protect a state from garbage collection. By default it pins only the user state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = pinState(args[0], system, userName, true) },
+	}
+	stateunpinCmd = &cobra.Command{
+		Use:   "unpin [state id]",
+		Short: i18n.G("Let garbage collection remove a previously pinned state. By default it unpins only the user state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = pinState(args[0], system, userName, false) },
+	}
+	statediffCmd = &cobra.Command{
+		Use:   "diff [from state id] [to state id]",
+		Short: i18n.G("List changed files between a system state and another one or the current system state."),
+		Args:  cobra.RangeArgs(1, 2),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = diffStates(args, diffUserData) },
+	}
+	stateexportCmd = &cobra.Command{
+		Use:   "export [state id]",
+		Short: i18n.G("Export a saved system state, with all its system and boot datasets, to a replication stream file."),
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdErr = exportState(args[0], exportOutput, exportFrom, exportUserData)
+		},
+	}
+	stateimportCmd = &cobra.Command{
+		Use:   "import [file]",
+		Short: i18n.G("Import a system state from a replication stream file."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = importState(args[0], importPool) },
+	}
+	statereplicateCmd = &cobra.Command{
+		Use:   "replicate",
+		Short: i18n.G("Copy new system and user states of the current machine to the configured replication pool and prune the replicated ones."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = replicateStates() },
+	}
 )
 
 var (
@@ -49,28 +113,67 @@ var (
 	userName         string
 	force            bool
 	dryrun           bool
+	revertUserData   bool
+	diffUserData     bool
+	stateDescription string
+	stateLabel       string
+	exportOutput     string
+	exportFrom       string
+	exportUserData   bool
+	importPool       string
 )
 
 func init() {
 	rootCmd.AddCommand(stateCmd)
 	stateCmd.AddCommand(statesaveCmd)
 	stateCmd.AddCommand(stateremoveCmd)
+	stateCmd.AddCommand(staterevertCmd)
+	stateCmd.AddCommand(stateannotateCmd)
+	stateCmd.AddCommand(statepinCmd)
+	stateCmd.AddCommand(stateunpinCmd)
+	stateCmd.AddCommand(statediffCmd)
+	stateCmd.AddCommand(stateexportCmd)
+	stateCmd.AddCommand(stateimportCmd)
+	stateCmd.AddCommand(statereplicateCmd)
 
 	statesaveCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Save complete system state (users and system)"))
 	statesaveCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Save the state for a given user or current user if empty"))
 	statesaveCmd.Flags().BoolVarP(&noUpdateBootMenu, "no-update-bootmenu", "", false, i18n.G("Do not update bootmenu on system state save"))
 	statesaveCmd.Flags().BoolVarP(&saveAuto, "auto", "", false, i18n.G("Signal this is an automated request triggered by script"))
+	statesaveCmd.Flags().StringVarP(&stateDescription, "description", "", "", i18n.G("Free form text describing the state"))
+	statesaveCmd.Flags().StringVarP(&stateLabel, "label", "", "", i18n.G("Short tag to identify the state"))
 
 	// user name and system or exclusive: TODO
 	stateremoveCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Remove system state (system and users linked to it)"))
 	stateremoveCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Remove the state for a given user or current user if empty"))
-	stateremoveCmd.Flags().BoolVarP(&force, "force", "f", false, i18n.G("Force removing, even if dependencies are found"))
+	stateremoveCmd.Flags().BoolVarP(&force, "force", "f", false, i18n.G("Force removing, even if dependencies are found or the state is pinned"))
 	stateremoveCmd.Flags().BoolVarP(&dryrun, "dry-run", "", false, i18n.G("Dry run, will not remove anything"))
 
+	staterevertCmd.Flags().BoolVarP(&revertUserData, "userdata", "", false, i18n.G("Revert user data alongside system state"))
+
+	stateannotateCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Annotate system state"))
+	stateannotateCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Annotate the state for a given user or current user if empty"))
+	stateannotateCmd.Flags().StringVarP(&stateDescription, "description", "", "", i18n.G("Free form text describing the state. An empty value removes it"))
+	stateannotateCmd.Flags().StringVarP(&stateLabel, "label", "", "", i18n.G("Short tag to identify the state. An empty value removes it"))
+
+	statepinCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Pin system state"))
+	statepinCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Pin the state for a given user or current user if empty"))
+	stateunpinCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Unpin system state"))
+	stateunpinCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Unpin the state for a given user or current user if empty"))
+
+	statediffCmd.Flags().BoolVarP(&diffUserData, "userdata", "", false, i18n.G("Compare user data alongside system state"))
+
+	stateexportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", i18n.G("File to write the exported state to"))
+	stateexportCmd.MarkFlagRequired("output")
+	stateexportCmd.Flags().StringVarP(&exportFrom, "incremental-from", "", "", i18n.G("Only export changes since this earlier state of the same machine"))
+	stateexportCmd.Flags().BoolVarP(&exportUserData, "userdata", "", false, i18n.G("Export user data alongside system state"))
+
+	stateimportCmd.Flags().StringVarP(&importPool, "pool", "", "", i18n.G("Pool to import system and user datasets to. Default is the pool they were exported from"))
+
 	cmdhandler.RegisterAlias(statesaveCmd, rootCmd)
 }
 
-func saveState(args []string, system bool, userName string, noUpdateBootMenu, saveAuto bool) (err error) {
+func saveState(args []string, system bool, userName string, noUpdateBootMenu, saveAuto bool, description, label string) (err error) {
 
 	if system && userName != "" {
 		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
@@ -98,6 +201,8 @@ func saveState(args []string, system bool, userName string, noUpdateBootMenu, sa
 			StateName:      stateName,
 			UpdateBootMenu: !noUpdateBootMenu,
 			Autosave:       saveAuto,
+			Description:    description,
+			Label:          label,
 		})
 
 		if err = checkConn(err, reset); err != nil {
@@ -128,7 +233,12 @@ func saveState(args []string, system bool, userName string, noUpdateBootMenu, sa
 			userName = user.Username
 		}
 
-		stream, err := client.SaveUserState(ctx, &zsys.SaveUserStateRequest{UserName: userName, StateName: stateName})
+		stream, err := client.SaveUserState(ctx, &zsys.SaveUserStateRequest{
+			UserName:    userName,
+			StateName:   stateName,
+			Description: description,
+			Label:       label,
+		})
 
 		if err = checkConn(err, reset); err != nil {
 			return err
@@ -193,31 +303,16 @@ func removeState(args []string) (err error) {
 			break
 		}
 
-		var recoverableMsg string
-		s := status.Convert(err)
-		for _, d := range s.Details() {
-			switch info := d.(type) {
-			case *errdetails.ErrorInfo:
-				// Does the request needs confirmation?
-				if info.Reason == config.UserConfirmationNeeded {
-					recoverableMsg = info.Metadata["msg"]
-				}
-			default:
-			}
-		}
+		recoverableMsg := confirmationMessage(err)
 		if recoverableMsg == "" {
 			return err
 		}
 
-		fmt.Printf(i18n.G("%s\nWould you like to proceed [y/N]? "), recoverableMsg)
-		reader := bufio.NewReader(os.Stdin)
-		answer, err := reader.ReadString('\n')
+		confirmed, err := askConfirmation(recoverableMsg)
 		if err != nil {
 			return err
 		}
-		answer = strings.TrimSpace(strings.ToLower(answer))
-
-		if !(answer == i18n.G("y") || answer == i18n.G("yes")) {
+		if !confirmed {
 			break
 		}
 		force = true
@@ -226,6 +321,36 @@ func removeState(args []string) (err error) {
 	return nil
 }
 
+// confirmationMessage returns the message to present to the user if err is a request for confirmation.
+// It returns an empty string otherwise.
+func confirmationMessage(err error) string {
+	s := status.Convert(err)
+	for _, d := range s.Details() {
+		switch info := d.(type) {
+		case *errdetails.ErrorInfo:
+			// Does the request needs confirmation?
+			if info.Reason == config.UserConfirmationNeeded {
+				return info.Metadata["msg"]
+			}
+		default:
+		}
+	}
+	return ""
+}
+
+// askConfirmation prints msg and returns true if the user agreed to proceed.
+func askConfirmation(msg string) (bool, error) {
+	fmt.Printf(i18n.G("%s\nWould you like to proceed [y/N]? "), msg)
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	answer = strings.TrimSpace(strings.ToLower(answer))
+
+	return answer == i18n.G("y") || answer == i18n.G("yes"), nil
+}
+
 func removeStateGRPC(client *zsys.ZsysLogClient, force, dryrun, system bool, userName, stateName string) error {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
@@ -286,3 +411,425 @@ func removeStateGRPC(client *zsys.ZsysLogClient, force, dryrun, system bool, use
 
 	return err
 }
+
+func revertState(stateName string, revertUserData bool) (err error) {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.RevertSystemState(ctx, &zsys.RevertSystemStateRequest{
+		StateName:      stateName,
+		RevertUserData: revertUserData,
+	})
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf(i18n.G("Next boot will be on %q\n"), stateName)
+
+	return nil
+}
+
+func annotateState(stateName string, system bool, userName string, description, label *string) (err error) {
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+	if description == nil && label == nil {
+		return errors.New(i18n.G("a description or a label should be provided"))
+	}
+
+	// prefill with current user
+	if !system && userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	var stream interface {
+		Recv() (*zsys.LogResponse, error)
+	}
+	if system {
+		stream, err = client.AnnotateSystemState(ctx, &zsys.AnnotateSystemStateRequest{
+			StateName:   stateName,
+			Description: description,
+			Label:       label,
+		})
+	} else {
+		stream, err = client.AnnotateUserState(ctx, &zsys.AnnotateUserStateRequest{
+			UserName:    userName,
+			StateName:   stateName,
+			Description: description,
+			Label:       label,
+		})
+	}
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func pinState(stateName string, system bool, userName string, pin bool) (err error) {
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+
+	// prefill with current user
+	if !system && userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	var stream interface {
+		Recv() (*zsys.LogResponse, error)
+	}
+	if system {
+		stream, err = client.PinSystemState(ctx, &zsys.PinSystemStateRequest{
+			StateName: stateName,
+			Pin:       pin,
+		})
+	} else {
+		stream, err = client.PinUserState(ctx, &zsys.PinUserStateRequest{
+			UserName:  userName,
+			StateName: stateName,
+			Pin:       pin,
+		})
+	}
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func diffStates(args []string, withUserData bool) (err error) {
+	from := args[0]
+	var to string
+	if len(args) > 1 {
+		to = args[1]
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.StateDiff(ctx, &zsys.StateDiffRequest{
+		From:     from,
+		To:       to,
+		UserData: withUserData,
+	})
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	var fromDataset, toDataset string
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		reset <- struct{}{}
+
+		c := r.GetChange()
+		if c == nil {
+			continue
+		}
+		// Print a header each time we change of dataset
+		if c.GetFromDataset() != fromDataset || c.GetToDataset() != toDataset {
+			fromDataset, toDataset = c.GetFromDataset(), c.GetToDataset()
+			fmt.Printf(i18n.G("%s -> %s\n"), datasetOrNone(fromDataset), datasetOrNone(toDataset))
+		}
+		if c.GetNewPath() != "" {
+			fmt.Printf("%s\t%s -> %s\n", c.GetChange(), c.GetPath(), c.GetNewPath())
+			continue
+		}
+		fmt.Printf("%s\t%s\n", c.GetChange(), c.GetPath())
+	}
+
+	return nil
+}
+
+// datasetOrNone returns a placeholder for datasets only existing on one side of a diff.
+func datasetOrNone(name string) string {
+	if name == "" {
+		return i18n.G("(none)")
+	}
+	return name
+}
+
+func exportState(stateName, output, from string, withUserData bool) (err error) {
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't create export file %s: %v"), output, err)
+	}
+	defer func() {
+		f.Close()
+		// Don't leave a partial export behind
+		if err != nil {
+			os.Remove(output)
+		}
+	}()
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.ExportSystemState(ctx, &zsys.ExportSystemStateRequest{
+		StateName:       stateName,
+		IncrementalFrom: from,
+		UserData:        withUserData,
+	})
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		reset <- struct{}{}
+
+		if _, err := f.Write(r.GetStream()); err != nil {
+			return fmt.Errorf(i18n.G("Couldn't write to export file %s: %v"), output, err)
+		}
+	}
+
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf(i18n.G("Couldn't write to export file %s: %v"), output, err)
+	}
+
+	fmt.Printf(i18n.G("State exported to %s\n"), output)
+	return nil
+}
+
+// importChunkSize is the maximum size of each part of the export sent to the service, below grpc message size limit.
+const importChunkSize = 1 << 20
+
+func importState(path, pool string) (err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't open export file %s: %v"), path, err)
+	}
+	defer f.Close()
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.ImportSystemState(ctx)
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	// Send the export while receiving logs, so that none of the client and service blocks the other.
+	sent := make(chan error, 1)
+	go func() {
+		err := sendExport(stream, f, path, pool, reset)
+		if err != nil {
+			// Abort the request, as the service would wait for the rest of the export.
+			cancel()
+		}
+		sent <- err
+	}()
+
+	var stateName string
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Report why we aborted the request, if we did.
+			if ctx.Err() != nil {
+				if errSend := <-sent; errSend != nil {
+					return errSend
+				}
+			}
+			return err
+		}
+
+		stateName = r.GetStateName()
+	}
+	if err := <-sent; err != nil {
+		return err
+	}
+
+	fmt.Printf(i18n.G("Successfully imported as %q\n"), stateName)
+	return nil
+}
+
+// sendExport sends pool then the content of export file f to the service.
+func sendExport(stream zsys.Zsys_ImportSystemStateClient, f io.Reader, path, pool string, reset chan<- struct{}) error {
+	if err := stream.Send(&zsys.ImportSystemStateRequest{Pool: pool}); err != nil {
+		// The service ended the request: its status is returned on receive.
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&zsys.ImportSystemStateRequest{Stream: buf[:n]}); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			reset <- struct{}{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf(i18n.G("Couldn't read export file %s: %v"), path, err)
+		}
+	}
+
+	return stream.CloseSend()
+}
+
+func replicateStates() (err error) {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.ReplicateStates(ctx, &zsys.Empty{})
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,12 @@
 package client
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/ubuntu/zsys"
@@ -40,7 +45,11 @@ var (
 )
 
 var (
-	removeHome bool
+	removeHome      bool
+	passphraseStdin bool
+	keyFile         string
+	container       string
+	properties      []string
 )
 
 func init() {
@@ -48,12 +57,40 @@ func init() {
 	userdataCmd.AddCommand(userdataCreateCmd)
 	userdataCmd.AddCommand(userdataRenameCmd)
 	userdataCmd.AddCommand(userdataDissociateCmd)
+	userdataCreateCmd.Flags().BoolVarP(&passphraseStdin, "passphrase-stdin", "", false, i18n.G("Encrypt the new user dataset with a passphrase read from the first line of standard input"))
+	userdataCreateCmd.Flags().StringVarP(&keyFile, "key-file", "", "", i18n.G("Encrypt the new user dataset with the raw 32 bytes key stored in this absolute path"))
+	userdataCreateCmd.Flags().StringVarP(&container, "container", "", "", i18n.G("Pool or dataset under which to create the new user dataset, instead of the configured one"))
+	userdataCreateCmd.Flags().StringArrayVarP(&properties, "property", "o", nil, i18n.G("Set a zfs property on the new user dataset, as property=value (compression, recordsize, quota...)"))
 	userdataDissociateCmd.Flags().BoolVarP(&removeHome, "remove", "r", false, i18n.G("Empty home directory content if not associated to any machine state"))
 }
 
 // createUserData creates a new userdata for user and set it to homepath on current zsys system.
 // if the user already exists for a dataset attached to the current system, set its mountpoint to homepath.
 func createUserData(user, homepath string) (err error) {
+	if passphraseStdin && keyFile != "" {
+		return errors.New(i18n.G("you can't provide a passphrase and a key file at the same time"))
+	}
+	var passphrase string
+	if passphraseStdin {
+		passphrase, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf(i18n.G("couldn't read passphrase: %v"), err)
+		}
+		passphrase = strings.TrimSuffix(passphrase, "\n")
+		if passphrase == "" {
+			return errors.New(i18n.G("passphrase can't be empty"))
+		}
+	}
+
+	props := make(map[string]string)
+	for _, p := range properties {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf(i18n.G("invalid property %q: expected property=value"), p)
+		}
+		props[kv[0]] = kv[1]
+	}
+
 	client, err := newClient()
 	if err != nil {
 		return err
@@ -63,7 +100,14 @@ func createUserData(user, homepath string) (err error) {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
 
-	stream, err := client.CreateUserData(ctx, &zsys.CreateUserDataRequest{User: user, Homepath: homepath})
+	stream, err := client.CreateUserData(ctx, &zsys.CreateUserDataRequest{
+		User:       user,
+		Homepath:   homepath,
+		Passphrase: passphrase,
+		KeyFile:    keyFile,
+		Container:  container,
+		Properties: props,
+	})
 	if err = checkConn(err, reset); err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 	"github.com/ubuntu/zsys/cmd/zsysd/cmdhandler"
 	"github.com/ubuntu/zsys/internal/config"
@@ -10,6 +12,7 @@ import (
 var (
 	cmdErr        error
 	flagVerbosity int
+	flagTimeout   time.Duration
 	rootCmd       = &cobra.Command{
 		Use:   "zsysctl COMMAND",
 		Short: i18n.G("ZFS SYStem integration control zsys daemon"),
@@ -29,6 +32,7 @@ var (
 
 func init() {
 	rootCmd.PersistentFlags().CountVarP(&flagVerbosity, "verbose", "v", i18n.G("issue INFO (-v) and DEBUG (-vv) output"))
+	rootCmd.PersistentFlags().DurationVarP(&flagTimeout, "timeout", "", 0, i18n.G("Cancel the request if it didn't complete in this time, reverting its in progress changes (e.g. 30s, 5m). No limit if 0."))
 }
 
 // Cmd returns the zsysctl command and options
@@ -15,6 +15,7 @@ import (
 var (
 	cmdErr        error
 	flagVerbosity int
+	flagDBus      bool
 	rootCmd       = &cobra.Command{
 		Use:   "zsysd",
 		Short: i18n.G("ZFS SYStem integration daemon"),
@@ -27,7 +28,7 @@ var (
 			config.SetVerboseMode(flagVerbosity)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			s, err := daemon.New(config.SocketPath())
+			s, err := daemon.New(config.SocketPath(), daemon.WithDBus(flagDBus))
 			if err != nil {
 				cmdErr = fmt.Errorf(i18n.G("Couldn't register grpc server: %v"), err)
 				return
@@ -58,6 +59,7 @@ var (
 
 func init() {
 	rootCmd.PersistentFlags().CountVarP(&flagVerbosity, "verbose", "v", i18n.G("issue INFO (-v) and DEBUG (-vv) output"))
+	rootCmd.Flags().BoolVar(&flagDBus, "dbus", false, fmt.Sprintf(i18n.G("own %s name on the system bus and export the D-Bus API"), daemon.DBusName))
 	rootCmd.AddCommand(bootPrepareCmd)
 }
 
@@ -1,6 +1,6 @@
 // Package authorizer deals client authorization based on a definite set of polkit actions.
 // The client uid and pid are obtained via the unix socket (SO_PEERCRED) information,
-// that are attached to the grpc request by the server.
+// that are attached to the grpc request by the server. D-Bus clients are identified by their bus name.
 package authorizer
 
 import (
@@ -130,39 +130,99 @@ func (a Authorizer) IsAllowedFromContext(ctx context.Context, action Action) (er
 		}
 	}()
 
+	pci, err := peerCredsFromGRPC(ctx)
+	if err != nil {
+		return err
+	}
+
+	actionUID, err := a.actionUID(ctx, action)
+	if err != nil {
+		return err
+	}
+
+	return a.isAllowed(ctx, action, pci.uid, actionUID, func() (authSubject, error) {
+		return a.processSubject(pci.pid, pci.uid)
+	})
+}
+
+// peerCredsFromGRPC returns the peerCredsInfo of the grpc request attached to ctx.
+func peerCredsFromGRPC(ctx context.Context) (peerCredsInfo, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
-		return errors.New(i18n.G("Context request doesn't have grpc peer creds informations."))
+		return peerCredsInfo{}, errors.New(i18n.G("Context request doesn't have grpc peer creds informations."))
 	}
 	pci, ok := p.AuthInfo.(peerCredsInfo)
 	if !ok {
-		return errors.New(i18n.G("Context request grpc peer creeds information is not a peerCredsInfo."))
+		return peerCredsInfo{}, errors.New(i18n.G("Context request grpc peer creeds information is not a peerCredsInfo."))
 	}
+	return pci, nil
+}
 
-	var actionUID uint32
-	if action == ActionUserWrite {
-		userName, ok := ctx.Value(OnUserKey).(string)
-		if !ok {
-			return errors.New(i18n.G("Request to act on user dataset should have a user name attached"))
-		}
-		user, err := a.userLookup(userName)
-		if err != nil {
-			return fmt.Errorf(i18n.G("Couldn't retrieve user for %q: %v"), userName, err)
+type peerCredsKey struct{}
+
+// WithPeerCreds attaches to ctx the pid and uid of a caller which isn't a grpc peer, like a D-Bus client.
+func WithPeerCreds(ctx context.Context, pid int32, uid uint32) context.Context {
+	return context.WithValue(ctx, peerCredsKey{}, peerCredsInfo{uid: uid, pid: pid})
+}
+
+// PeerCredsFromContext returns the pid and uid of the caller, attached with WithPeerCreds or extracted
+// from peerCredsInfo grpc context.
+func PeerCredsFromContext(ctx context.Context) (pid int32, uid uint32, err error) {
+	pci, ok := ctx.Value(peerCredsKey{}).(peerCredsInfo)
+	if !ok {
+		if pci, err = peerCredsFromGRPC(ctx); err != nil {
+			return 0, 0, err
 		}
-		uid, err := strconv.Atoi(user.Uid)
+	}
+	return pci.pid, pci.uid, nil
+}
+
+// IsAllowedFromBusName returns nil if the user is allowed to perform an operation.
+// busName is the unique name of the caller on the system bus and uid its user, as reported by the D-Bus daemon.
+// polkit then identifies the caller by its bus name, which can't be reused by another process.
+func (a Authorizer) IsAllowedFromBusName(ctx context.Context, action Action, busName string, uid uint32) (err error) {
+	log.Debugf(ctx, i18n.G("Check if bus peer %s of user %d is authorized"), busName, uid)
+
+	defer func() {
 		if err != nil {
-			return fmt.Errorf(i18n.G("Couldn't convert %q to a valid uid for %q"), user.Uid, userName)
+			err = fmt.Errorf(i18n.G("Permission denied: %w"), err)
 		}
-		actionUID = uint32(uid)
+	}()
+
+	actionUID, err := a.actionUID(ctx, action)
+	if err != nil {
+		return err
 	}
 
-	return a.isAllowed(ctx, action, pci.pid, pci.uid, actionUID)
+	return a.isAllowed(ctx, action, uid, actionUID, func() (authSubject, error) {
+		return busNameSubject(busName), nil
+	})
+}
+
+// actionUID returns the uid of the user attached to ctx for ActionUserWrite, 0 for any other action.
+func (a Authorizer) actionUID(ctx context.Context, action Action) (uint32, error) {
+	if action != ActionUserWrite {
+		return 0, nil
+	}
+	userName, ok := ctx.Value(OnUserKey).(string)
+	if !ok {
+		return 0, errors.New(i18n.G("Request to act on user dataset should have a user name attached"))
+	}
+	user, err := a.userLookup(userName)
+	if err != nil {
+		return 0, fmt.Errorf(i18n.G("Couldn't retrieve user for %q: %v"), userName, err)
+	}
+	uid, err := strconv.Atoi(user.Uid)
+	if err != nil {
+		return 0, fmt.Errorf(i18n.G("Couldn't convert %q to a valid uid for %q"), user.Uid, userName)
+	}
+	return uint32(uid), nil
 }
 
 // isAllowed returns nil if the user is allowed to perform an operation.
 // ActionUID is only used for ActionUserWrite which will be converted to corresponding polkit action
-// (self or others)
-func (a Authorizer) isAllowed(ctx context.Context, action Action, pid int32, uid uint32, actionUID uint32) error {
+// (self or others). subject returns how polkit identifies the caller, only if polkit needs to be asked.
+func (a Authorizer) isAllowed(ctx context.Context, action Action, uid uint32, actionUID uint32, subject func() (authSubject, error)) error {
 	if uid == 0 {
 		log.Debug(ctx, i18n.G("Authorized as being administrator"))
 		return nil
@@ -176,31 +236,16 @@ func (a Authorizer) isAllowed(ctx context.Context, action Action, pid int32, uid
 		}
 	}
 
-	f, err := os.Open(filepath.Join(a.root, fmt.Sprintf("proc/%d/stat", pid)))
+	sub, err := subject()
 	if err != nil {
-		return fmt.Errorf(i18n.G("Couldn't open stat file for process: %v"), err)
-	}
-	defer f.Close()
-
-	startTime, err := getStartTimeFromReader(f)
-	if err != nil {
-		return fmt.Errorf(i18n.G("Couldn't determine start time of client process: %v"), err)
-	}
-
-	subject := authSubject{
-		Kind: "unix-process",
-		Details: map[string]dbus.Variant{
-			"pid":        dbus.MakeVariant(uint32(pid)), // polkit requests an uint32 on dbus
-			"start-time": dbus.MakeVariant(startTime),
-			"uid":        dbus.MakeVariant(uid),
-		},
+		return err
 	}
 
 	var result authResult
 	var details map[string]string
 	err = a.authority.Call(
 		"org.freedesktop.PolicyKit1.Authority.CheckAuthorization", dbus.FlagAllowInteractiveAuthorization,
-		subject, string(action), details, checkAllowInteration, "").Store(&result)
+		sub, string(action), details, checkAllowInteration, "").Store(&result)
 	if err != nil {
 		return fmt.Errorf(i18n.G("Call to polkit failed: %v"), err)
 	}
@@ -213,6 +258,37 @@ func (a Authorizer) isAllowed(ctx context.Context, action Action, pid int32, uid
 	return nil
 }
 
+// processSubject returns the polkit subject identifying process pid of user uid.
+func (a Authorizer) processSubject(pid int32, uid uint32) (authSubject, error) {
+	f, err := os.Open(filepath.Join(a.root, fmt.Sprintf("proc/%d/stat", pid)))
+	if err != nil {
+		return authSubject{}, fmt.Errorf(i18n.G("Couldn't open stat file for process: %v"), err)
+	}
+	defer f.Close()
+
+	startTime, err := getStartTimeFromReader(f)
+	if err != nil {
+		return authSubject{}, fmt.Errorf(i18n.G("Couldn't determine start time of client process: %v"), err)
+	}
+
+	return authSubject{
+		Kind: "unix-process",
+		Details: map[string]dbus.Variant{
+			"pid":        dbus.MakeVariant(uint32(pid)), // polkit requests an uint32 on dbus
+			"start-time": dbus.MakeVariant(startTime),
+			"uid":        dbus.MakeVariant(uid),
+		},
+	}, nil
+}
+
+// busNameSubject returns the polkit subject identifying the unique name of a system bus peer.
+func busNameSubject(name string) authSubject {
+	return authSubject{
+		Kind:    "system-bus-name",
+		Details: map[string]dbus.Variant{"name": dbus.MakeVariant(name)},
+	}
+}
+
 // getStartTimeFromReader determines the start time from a process stat file content
 //
 // The implementation is intended to be compatible with polkit:
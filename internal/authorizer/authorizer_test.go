@@ -79,6 +79,54 @@ func TestIsAllowedFromContext(t *testing.T) {
 	}
 }
 
+func TestIsAllowedFromBusName(t *testing.T) {
+	t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		action   authorizer.Action
+		uid      uint32
+		withUser bool
+
+		wantAuthorized bool
+	}{
+		"Root is always authorized":          {uid: 0, wantAuthorized: true},
+		"Any user for always allowed":        {action: authorizer.ActionAlwaysAllowed, uid: 1000, wantAuthorized: true},
+		"Valid bus name and ACK":             {uid: 1000, wantAuthorized: true},
+		"Valid bus name and NACK":            {uid: 1000, wantAuthorized: false},
+		"User action with user name and ACK": {action: authorizer.ActionUserWrite, withUser: true, uid: 1000, wantAuthorized: true},
+
+		// Error cases
+		"User action without user name": {action: authorizer.ActionUserWrite, uid: 1000, wantAuthorized: false},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.action == "" {
+				tc.action = authorizer.ActionManageService
+			}
+			ctx := context.Background()
+			if tc.withUser {
+				ctx = context.WithValue(ctx, authorizer.OnUserKey, "foo")
+			}
+			userLookup := func(string) (*user.User, error) {
+				return &user.User{Uid: "1000"}, nil
+			}
+			d := &authorizer.DbusMock{IsAuthorized: tc.wantAuthorized}
+			a, err := authorizer.New(authorizer.WithAuthority(d), authorizer.WithRoot("testdata"), authorizer.WithUserLookup(userLookup))
+			if err != nil {
+				t.Fatalf("Failed to create authorizer: %v", err)
+			}
+
+			errAllowed := a.IsAllowedFromBusName(ctx, tc.action, ":1.42", tc.uid)
+
+			assert.Equal(t, tc.wantAuthorized, errAllowed == nil, "IsAllowedFromBusName returned state match expectations")
+		})
+	}
+}
+
 func TestIsAllowedFromContextWithoutPeer(t *testing.T) {
 	t.Parallel()
 	defer testutils.StartLocalSystemBus(t)()
@@ -110,6 +158,53 @@ func TestIsAllowedFromContextWithInvalidPeerCreds(t *testing.T) {
 	assert.Equal(t, false, errAllowed == nil, "IsAllowedFromContext must deny with an unexpected peer creds info type")
 }
 
+func TestPeerCredsFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		grpcPeer    authorizer.PeerCredsInfo
+		invalidPeer bool
+		attached    bool
+
+		wantPid int32
+		wantUID uint32
+		wantErr bool
+	}{
+		"From grpc peer":                    {grpcPeer: authorizer.NewTestPeerCredsInfo(1000, 10000), wantPid: 10000, wantUID: 1000},
+		"Attached creds":                    {attached: true, wantPid: 42, wantUID: 999},
+		"Attached creds override grpc peer": {grpcPeer: authorizer.NewTestPeerCredsInfo(1000, 10000), attached: true, wantPid: 42, wantUID: 999},
+
+		"Error on no peer":            {wantErr: true},
+		"Error on invalid peer creds": {invalidPeer: true, wantErr: true},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tc.grpcPeer != (authorizer.PeerCredsInfo{}) {
+				ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: tc.grpcPeer})
+			}
+			if tc.invalidPeer {
+				ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: invalidPeerCredsInfo{}})
+			}
+			if tc.attached {
+				ctx = authorizer.WithPeerCreds(ctx, 42, 999)
+			}
+
+			pid, uid, err := authorizer.PeerCredsFromContext(ctx)
+			if tc.wantErr {
+				assert.Error(t, err, "PeerCredsFromContext should fail")
+				return
+			}
+			assert.NoError(t, err, "PeerCredsFromContext should succeed")
+			assert.Equal(t, tc.wantPid, pid, "pid of caller")
+			assert.Equal(t, tc.wantUID, uid, "uid of caller")
+		})
+	}
+}
+
 func TestIsAllowedFromContextWithoutUserKey(t *testing.T) {
 	t.Parallel()
 	defer testutils.StartLocalSystemBus(t)()
@@ -22,12 +22,14 @@ type DbusMock struct {
 	IsAuthorized    bool
 	WantPolkitError bool
 
-	actionRequested Action
+	actionRequested  Action
+	subjectRequested authSubject
 }
 
 func (d *DbusMock) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
 	var errPolkit error
 
+	d.subjectRequested = args[0].(authSubject)
 	d.actionRequested = Action(args[1].(string))
 
 	if d.WantPolkitError {
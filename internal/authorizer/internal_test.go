@@ -22,6 +22,7 @@ func TestIsAllowed(t *testing.T) {
 	tests := map[string]struct {
 		action    Action
 		pid       int32
+		busName   string
 		uid       uint32
 		actionUID uint32
 
@@ -35,6 +36,8 @@ func TestIsAllowed(t *testing.T) {
 		"ActionAlwaysAllowed is always allowed": {action: ActionAlwaysAllowed, uid: 1000, wantAuthorized: true},
 		"Valid process and ACK":                 {pid: 10000, uid: 1000, polkitAuthorize: true, wantAuthorized: true},
 		"Valid process and NACK":                {pid: 10000, uid: 1000, polkitAuthorize: false, wantAuthorized: false},
+		"Bus name and ACK":                      {busName: ":1.42", uid: 1000, polkitAuthorize: true, wantAuthorized: true},
+		"Bus name and NACK":                     {busName: ":1.42", uid: 1000, polkitAuthorize: false, wantAuthorized: false},
 
 		"ActionUserWrite on its own datasets is transformed on actionUserWriteSelf": {action: ActionUserWrite, actionUID: 1000, pid: 10000, uid: 1000, wantActionRequested: actionUserWriteSelf},
 		"ActionUserWrite on other datasets is transformed on actionUserWriteOthers": {action: ActionUserWrite, actionUID: 999, pid: 10000, uid: 1000, wantActionRequested: actionUserWriteOthers},
@@ -64,7 +67,18 @@ func TestIsAllowed(t *testing.T) {
 				t.Fatalf("Failed to create authorizer: %v", err)
 			}
 
-			errAllowed := a.isAllowed(context.Background(), tc.action, tc.pid, tc.uid, tc.actionUID)
+			subject := func() (authSubject, error) { return a.processSubject(tc.pid, tc.uid) }
+			wantKind := "unix-process"
+			if tc.busName != "" {
+				subject = func() (authSubject, error) { return busNameSubject(tc.busName), nil }
+				wantKind = "system-bus-name"
+			}
+
+			errAllowed := a.isAllowed(context.Background(), tc.action, tc.uid, tc.actionUID, subject)
+
+			if d.subjectRequested.Kind != "" {
+				assert.Equal(t, wantKind, d.subjectRequested.Kind, "Unexpected subject received by polkit")
+			}
 
 			if tc.wantActionRequested != "" {
 				assert.Equal(t, string(tc.wantActionRequested), string(d.actionRequested), "Unexpected action received by polkit")
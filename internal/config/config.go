@@ -24,12 +24,48 @@ var ErrorFormat = "%v"
 
 // ZConfig stores the configuration of zsys
 type ZConfig struct {
-	History HistoryRules
-	General struct {
-		Timeout          int
-		MinFreePoolSpace int
+	History     HistoryRules
+	UserHistory UserHistoryRules
+	General     struct {
+		Timeout             int
+		MinFreePoolSpace    int
+		TargetFreePoolSpace int
 	}
-	Path string
+	Replication Replication
+	Bookmarks   Bookmarks
+	UserData    UserData
+	Metrics     Metrics
+	Path        string
+}
+
+// UserData store the settings used when creating new user datasets.
+type UserData struct {
+	// Container is where new user datasets are created: a pool or a dataset, under which a USERDATA container is used.
+	// If empty, the container of existing user datasets, or the current system pool, is used.
+	Container string
+	// Properties are native zfs properties set on new user datasets, like compression, recordsize or quota.
+	Properties map[string]string
+}
+
+// Metrics store the settings to export daemon metrics to a Prometheus node exporter.
+type Metrics struct {
+	// TextfileDirectory is the textfile collector directory of the node exporter, where metrics are written after each
+	// garbage collection and boot commit. Disabled if empty.
+	TextfileDirectory string
+}
+
+// Bookmarks store the settings to keep bookmarks of states removed by garbage collection.
+type Bookmarks struct {
+	// Enabled turns snapshots removed by garbage collection into bookmarks.
+	Enabled bool
+	// KeepDays is the number of days after their creation bookmarks are kept. 0 keeps them forever.
+	KeepDays int
+}
+
+// Replication store the settings to mirror states to another pool.
+type Replication struct {
+	// TargetPool is the pool receiving replicated states. Replication is disabled if empty.
+	TargetPool string
 }
 
 // HistoryRules store the rules for each GC element
@@ -44,6 +80,30 @@ type HistoryRules struct {
 	}
 }
 
+// UserHistoryRules store the rules for user states GC.
+// Users can have their own rules, which replace the default ones entirely.
+type UserHistoryRules struct {
+	HistoryRules `yaml:",inline"`
+	Users        map[string]HistoryRules
+}
+
+// UserHistoryFor returns the GC rules to apply on states of a given user.
+// It fallbacks to user history rules, then to system history rules if no user history rules are set.
+func (c ZConfig) UserHistoryFor(user string) HistoryRules {
+	if r, ok := c.UserHistory.Users[user]; ok {
+		return r
+	}
+	if !c.UserHistory.HistoryRules.isEmpty() {
+		return c.UserHistory.HistoryRules
+	}
+	return c.History
+}
+
+// isEmpty returns if no rule was set.
+func (r HistoryRules) isEmpty() bool {
+	return r.GCStartAfter == 0 && r.KeepLast == 0 && len(r.GCRules) == 0
+}
+
 // SetVerboseMode change ErrorFormat and logs between very, middly and non verbose
 func SetVerboseMode(level int) {
 	if level > 2 {
@@ -23,6 +23,15 @@ const (
 
 	// DefaultPath is the default configuration path
 	DefaultPath = "/etc/zsys.conf"
+	// DefaultHooksDir is the default directory containing hooks run around state operations
+	DefaultHooksDir = "/etc/zsys/hooks.d"
+	// DefaultJournalDir is the default directory storing the journal of write requests and recorded metrics
+	DefaultJournalDir = "/var/lib/zsys"
+
+	// GrubMenuPath is the generated GRUB boot menu
+	GrubMenuPath = "/boot/grub/grub.cfg"
+	// GrubEnvPath is the GRUB environment block, storing the entry to boot on once
+	GrubEnvPath = "/boot/grub/grubenv"
 
 	// UserConfirmationNeeded is a dedicated type for GRPC error which signal that we need more info from user
 	UserConfirmationNeeded = "UserConfirmationNeeded"
@@ -19,14 +19,14 @@ var internalAssets = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2020, 7, 27, 13, 26, 42, 327263544, time.UTC),
+			modTime: time.Date(2023, 6, 5, 9, 21, 57, 0, time.UTC),
 		},
 		"/zsys.conf": &vfsgen۰CompressedFileInfo{
 			name:             "zsys.conf",
-			modTime:          time.Date(2020, 7, 27, 13, 26, 42, 323263518, time.UTC),
-			uncompressedSize: 908,
+			modTime:          time.Date(2026, 10, 16, 13, 9, 59, 670854578, time.UTC),
+			uncompressedSize: 2762,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x4d\xab\xdb\x3c\x10\x85\xf7\xfe\x15\x07\xee\xe6\x7d\x17\x2d\x37\xfd\x04\xef\x0a\x77\xd7\xde\xd2\x45\xa1\xeb\x89\x7d\x1c\x8b\x58\x23\x77\x34\x0e\xf8\xdf\x17\x29\x4e\x6f\x48\x43\xa1\x5e\x59\x33\x3a\xcf\x7c\x6a\x0c\xd9\x93\xad\x6d\x03\x3c\xe0\x33\x39\x43\x1c\x13\x25\x3b\x14\x9b\x13\x54\xb7\x15\x33\x0d\x8b\x06\x47\x1a\xe0\x21\x12\x61\x00\x35\x2d\x87\xb1\x5a\x46\x46\x88\x11\xb3\x31\x53\xbd\x02\xbf\x8f\x44\xb2\x9e\x86\x2e\x69\x1f\x3c\x24\x2d\x17\xb1\x5f\xba\x23\x1d\xd9\xc5\x1c\xa2\x3d\xa8\x3d\x7a\x71\x66\xfc\x37\x58\x8a\x88\x29\x3b\x8c\x1d\xd5\xe1\x09\x69\xea\x99\xfd\xff\x06\x38\x74\x55\x24\x83\xd3\x5a\xec\x1a\xe0\x48\xce\x93\x64\x6f\xf1\xe6\x11\x0f\x78\x0e\x1a\xe2\x12\xa1\x4b\xdc\xd3\x4a\x66\x1b\x26\x7b\xe5\x7b\xaa\x8a\xd7\x35\x3f\x00\xaf\xa0\x12\xd9\xe2\xfa\xfb\xb4\x0f\x6e\x62\x6b\x75\x6d\xc5\x6d\x39\x5f\x64\xd8\xce\xf9\x4a\xf9\xf5\x77\xc8\xcd\x87\x74\xa2\x55\x71\x50\xa7\x9d\x64\xba\x95\x4f\xd4\x83\x8f\x67\xc6\x97\xfa\x5f\xe4\x94\x6e\xbc\xf4\x28\x28\x7a\x59\xf3\x8b\x30\x4b\x9c\x27\xe6\x99\x76\xbe\xd1\x5e\xc5\xed\xc5\x25\x97\xc0\x5b\x95\x45\x7d\x05\xab\xfd\xb3\x65\x62\x2e\xf3\x7e\xa9\xfd\x9b\xf1\x14\xd2\x92\x9f\x64\x6d\x6e\x8a\xdb\x35\xf7\xd2\xbd\x58\xff\xcc\xe5\xed\x5d\xf0\x0f\xf2\x78\x4b\x7e\xff\x8f\xe4\xdd\x5d\xf2\x73\x52\x1f\x6f\xd1\xef\xee\xa2\x3f\xfe\x05\x7d\xa0\xd2\x64\x3a\x3f\x83\xba\x42\x32\x61\x30\x12\x79\x96\x8e\x30\xfe\x5c\x82\xb1\xc7\x9e\x43\x32\xc2\xe5\x18\xf4\x00\x41\x56\x99\xf3\x98\x4a\x6b\x63\xd0\xa2\x98\x53\x9a\xaa\xa8\x2c\x64\xe5\x3d\x09\x63\x59\xfc\x10\x99\x96\x3a\xd1\xcc\xf2\x1e\xca\x50\x37\x63\x8b\x0f\x8f\xcd\xaf\x00\x00\x00\xff\xff\xbf\xda\xe7\x4a\x8c\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x56\x5b\x8f\xdb\x36\x13\x7d\xd7\xaf\x38\x80\x5e\xbe\x0f\x70\xbc\xde\x5c\x9a\x56\x6f\x41\xb7\x2d\x82\x34\x45\x90\x0b\xf2\x4c\x4b\x63\x8b\x30\x45\x2a\x33\x23\x6f\x9c\x5f\x5f\x0c\x25\x79\xb5\x6b\xb7\x40\xfd\x64\x91\x9c\x33\x67\x2e\x67\xc8\xd6\x8b\x26\x3e\x55\x05\x50\xe2\x1d\x51\x0f\xa7\x08\xe4\x44\x11\x31\x6d\x82\xa2\xf2\x09\x3d\x31\x86\xe8\x15\x69\x07\xf5\x1d\xc1\xef\x40\x31\x0d\xfb\x36\xaf\xb4\xd4\xc1\x31\xa1\x67\x12\x8a\x9a\x01\x3f\xb7\x84\xc4\x0d\x31\xea\x14\x1b\xaf\x3e\x45\x68\x4b\xd8\x0e\xf5\x81\x14\xa2\x8e\x15\x2e\x36\xa0\xd8\xa0\x71\x4a\x82\xff\xed\x38\x75\xe8\x92\x28\x98\x6a\x8a\x0a\x4d\x48\xa1\x21\xd1\xff\x17\xc0\xbe\xce\x46\x6e\xa7\xc4\x15\x6e\x0b\xe0\x40\xd4\x07\x27\x5a\xe1\xf9\x06\x25\xde\xfb\xe8\xbb\xa1\x43\x1c\xba\x2d\xb1\x31\x9b\x60\x44\x33\xbe\xa6\x6c\xb1\xce\xfc\x00\x3c\x43\x74\x1d\x55\x58\xfe\xde\x6c\xbd\xb2\xe3\x53\xde\x9a\x82\x9b\x38\xcf\x66\x98\xbe\x65\x61\xf9\xd7\xd9\xe5\xb4\x87\x74\x24\xce\x01\xfb\xa8\xc4\x47\x17\x9e\x9a\x07\x8a\x7b\x6d\x47\x8c\x3f\xf3\x7f\x73\x47\xae\x6e\xa7\x03\xf0\x11\x8d\x3b\xc9\x83\xa1\xb8\xae\x0f\x24\x3d\xf1\x78\xa2\x5a\xf8\x6d\x9c\x3a\x21\x3d\x47\x69\xd6\x0b\xb0\x9c\x3f\x1e\x02\x49\x55\x2c\x63\xff\xc0\x74\xf4\x69\x90\x3b\x77\x2a\x9e\x04\x77\x5b\x5c\xa3\x7b\x5b\xfc\x13\x97\x17\x57\x81\xbf\x12\x1d\x1e\x01\x49\x85\x57\xff\x11\xf9\xf6\x2a\xf2\xfb\x14\xb5\x7d\x84\x24\x15\x5e\x5e\x85\x7e\xfd\x2f\xd0\x25\x3e\x5a\x5a\xb0\x4b\x8c\x41\x88\xad\x35\x95\x64\x8d\x4f\xd6\x01\xbb\xc4\x9d\x53\x38\x99\x15\xb1\xc6\xdb\x1d\x62\x52\x08\xe9\xea\x2c\x93\x9c\xd9\xac\x81\x41\xa8\x59\x17\x25\xbe\x08\xb1\xa0\x76\x11\xad\x3b\x92\x75\x82\x67\xa4\xfb\x38\x1e\x5d\x81\xad\x75\x6b\x1f\xf7\xb6\x85\x86\x76\x6e\x08\x3a\x12\x98\x41\x53\x24\x59\x17\xa5\xad\x4d\x4b\x55\x51\x5e\xea\xa0\x7c\x2c\x84\xa2\x5c\x94\xba\xbc\x96\x38\xab\xf5\xa3\x56\x94\x09\xe6\x32\x71\xe7\xe5\xcb\xcc\xbd\xb0\x2d\xe3\x36\xbb\x91\xd4\x91\x7d\x4f\x9f\x57\x89\x02\x4b\xb2\x2f\x37\x0f\x47\x97\x84\xaf\x91\xce\x7d\x74\xde\x5d\x30\x7f\x7d\xb9\x7a\xc1\xfe\x7a\x04\xcf\x8b\x12\x1f\xa9\x0f\xbe\x76\x4a\x53\xd9\x6d\xe4\xb8\x98\xb4\x25\x46\x9f\x52\x58\x41\x4f\xbd\xaf\x5d\x08\x56\x0f\x38\x6c\x5d\x7d\x18\x7a\x34\x5e\x0e\x2b\xdc\x7b\x6d\xf1\x43\x4e\x52\x6b\x18\x01\x72\x5d\x33\xe0\x7a\x89\xde\xcc\xf0\xd6\x22\x3d\x0f\x91\x9a\xd1\xd8\x8a\x2f\xd6\x68\x73\xd1\x73\x1e\xd6\xb8\xf3\xe2\xb6\x81\x1a\x9b\xb4\x31\x41\x1d\xef\x49\x33\x23\x78\xb1\xde\x5b\x17\xe5\xec\xca\xa7\x98\x73\x3e\x1e\xb2\x33\xd5\x44\xb3\x28\xf1\x79\xe0\x08\x89\xae\x97\x36\xa9\x80\xa9\x4b\x47\x6a\xb0\x3d\x61\xef\x78\xeb\xf6\x84\x3a\x85\x40\x75\x1e\xd0\x3e\x6a\xc2\x36\xa5\x43\xe7\xf8\x20\x2b\x48\x82\xb6\x4e\xe1\x63\xcd\xd4\x51\x54\x17\x40\xdf\xfb\xc4\x2a\x79\x76\x2f\x18\x14\x25\x44\x7d\x08\x63\xbb\x5b\x9e\x84\xd6\x45\x79\x06\xcb\x0c\x29\xe6\xa0\x2a\x9c\x48\xec\xdb\x32\x64\x7c\x1e\x7c\xe6\x79\x6f\x83\xd3\x45\x8c\xe3\x6f\x8d\x4d\xee\x6f\x31\xa1\x74\x26\x53\x3a\x12\xaf\xe7\xb6\xb7\x23\x15\x7e\xd9\x14\x25\xbe\xb6\xc4\x94\x79\xb5\xe9\xde\x0a\x59\x33\x59\x65\x23\xdd\x8f\xc2\x9a\x67\xe4\x1a\xbf\xa6\xa8\xce\x47\x62\xcb\xa6\x1b\xf3\x9a\x18\x6e\x3e\xb2\xc2\x10\xed\xee\xba\x6f\x7d\xdd\xe2\xcb\xa7\xdf\x3e\xde\xbd\xf9\xfc\xc6\x0e\xcf\xfa\x5e\x4e\x81\x0b\x07\x79\x12\x8c\xde\x1b\x44\xfa\x9e\x6f\x32\x2b\x35\x7d\xf7\xa2\x26\x7a\xd3\xf6\x0a\x89\x31\x5d\x8b\xf5\xc0\x6c\x37\x9e\x9c\x44\xa9\xcb\x84\xcc\xcb\x07\x4e\x3d\xb1\xfa\xa9\x73\x52\x0c\x27\x73\x69\x56\x17\x4e\x2b\xb8\x07\xa6\xf5\x39\xc0\x99\x86\xcd\xb7\x9c\xc1\x31\x99\xf3\xc4\xe9\xcf\x1e\xa6\x51\x63\x19\xc8\xc5\x3a\x43\x54\xd9\x85\x51\xb2\xe5\x07\x83\x49\xad\x75\xea\xec\xe2\x17\x6b\x43\x84\x1f\x2f\xc7\x55\xa6\x3a\x71\x23\xfe\x07\x55\xb8\x7d\xfe\xf3\xbb\x71\xf5\xdb\x90\xd4\x55\x78\xb5\xf9\xc3\xea\xc5\x5e\x09\x8d\xa3\x2e\x45\x74\xa4\xec\x6b\xb1\x6b\xcb\xf2\xf1\x81\x53\x47\xda\xd2\x20\x50\xcb\xdf\x34\x87\xa7\x34\xda\xd2\xce\x87\x73\xef\x26\x46\xe3\x39\xff\x39\xcd\x97\x76\x4c\x0d\x4d\xcd\x4a\xbc\x2a\x4a\xe4\x87\xc3\x78\x27\x5e\x69\x7d\x6b\x9b\x6d\x4a\x8a\x3a\x75\x9d\xd7\xa7\x0a\xd4\x49\x73\x13\xcd\x1c\xfa\xcc\xe2\xec\xba\xc2\xcd\xd1\xf1\x4d\xf0\xdb\x9b\xfe\xcc\xff\xc6\x88\x3c\x9b\x89\x14\x7b\x8a\xc4\x2e\xd8\x2d\x3c\xbd\x58\x5c\xc0\x8e\x89\x20\xbd\xab\x09\x4c\xdf\x06\xcf\xa6\x50\xb2\x56\x87\xba\x83\xf5\x8b\x3b\x6b\xb8\x00\x3a\x1f\xcd\xc2\x2a\x92\x8d\xf2\xd8\x37\xbc\xdf\x1f\x70\x34\x81\x2d\xd6\x95\x49\x3d\xab\xde\x60\x4c\x5d\xa2\x70\x83\xa6\xce\xa9\xaf\xa7\xb1\xb4\xc2\x7d\x4b\x71\x16\x82\x17\x6c\x29\xa4\x7b\x58\x1a\x36\x36\xea\x4c\xb5\x62\x9f\x99\xf5\x5b\x45\x24\x6a\xf2\x53\x63\x4b\x70\x5b\x53\xf0\x53\x4e\x26\xd4\xa5\x1f\x77\x1c\x5f\x60\x46\x1c\x43\x3f\x46\x6b\x4f\xb1\x71\x6a\x3d\x89\x67\x93\x1d\xdd\x8d\xad\x61\x2f\xce\x34\xd8\x14\x82\x90\xbd\x26\xed\x49\x34\x2d\x56\xf8\x69\x53\xfc\x3d\x00\xf2\xca\x44\x8b\xca\x0a\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -2,12 +2,14 @@ package daemon
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
 )
 
 // PrepareBoot consolidates canmount states for early boot.
@@ -17,13 +19,22 @@ func (s *Server) PrepareBoot(req *zsys.Empty, stream zsys.Zsys_PrepareBootServer
 		return err
 	}
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	ctx, done := s.audit(stream.Context(), "PrepareBoot", nil)
+	defer func() { done(err) }()
 
-	log.Infof(stream.Context(), i18n.G("Prepare current boot state"))
-
-	changed, err := s.Machines.EnsureBoot(stream.Context())
+	unlock, err := s.lock(ctx, resourceAll)
 	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Infof(ctx, i18n.G("Prepare current boot state"))
+
+	var changed bool
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		changed, err = ms.EnsureBoot(ctx)
+		return err
+	}); err != nil {
 		return fmt.Errorf(i18n.G("couldn't ensure boot: ")+config.ErrorFormat, err)
 	}
 	stream.Send(&zsys.PrepareBootResponse{
@@ -42,15 +53,43 @@ func (s *Server) CommitBoot(req *zsys.Empty, stream zsys.Zsys_CommitBootServer)
 		return err
 	}
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	ctx, done := s.audit(stream.Context(), "CommitBoot", nil)
+	defer func() { done(err) }()
 
-	log.Infof(stream.Context(), i18n.G("Commit current boot state"))
+	unlock, err := s.lock(ctx, resourceAll)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	changed, err := s.Machines.Commit(stream.Context())
+	log.Infof(ctx, i18n.G("Commit current boot state"))
+
+	var changed, scheduled bool
+	err = s.update(ctx, func(ms *machines.Machines) (err error) {
+		if m, err := ms.GetMachine(""); err == nil {
+			scheduled = m.NextBootParameters() != ""
+		}
+		changed, err = ms.Commit(ctx)
+		return err
+	})
+	s.observeBootCommit(ctx, changed, err)
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't commit: ")+config.ErrorFormat, err)
 	}
+	// The scheduled boot was reset by the commit: the bootloader mustn't boot on it again.
+	if scheduled {
+		if err := resetNextBoot(ctx); err != nil {
+			log.Warning(ctx, err)
+		}
+	}
+	var machineID string
+	if m, err := s.snapshot().GetMachine(""); err == nil {
+		machineID = m.ID
+	}
+	s.events.publish(&zsys.Event{Event: &zsys.Event_BootCommitted{BootCommitted: &zsys.BootCommittedEvent{
+		MachineId: machineID,
+		Changed:   changed,
+	}}})
 	stream.Send(&zsys.CommitBootResponse{
 		Reply: &zsys.CommitBootResponse_Changed{Changed: changed},
 	})
@@ -59,7 +98,7 @@ func (s *Server) CommitBoot(req *zsys.Empty, stream zsys.Zsys_CommitBootServer)
 		return nil
 	}
 
-	return updateBootMenu(stream.Context())
+	return s.updateBootMenu(ctx)
 }
 
 // UpdateBootMenu updates machine bootmenu.
@@ -68,17 +107,23 @@ func (s *Server) UpdateBootMenu(req *zsys.UpdateBootMenuRequest, stream zsys.Zsy
 		return err
 	}
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	ctx, done := s.audit(stream.Context(), "UpdateBootMenu", map[string]string{"auto": strconv.FormatBool(req.GetAuto())})
+	defer func() { done(err) }()
+
+	unlock, err := s.lock(ctx, resourceBootMenu)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// update triggered by apt or other system on non zsys system. Do nothing
-	if !s.Machines.CurrentIsZsys() && req.GetAuto() {
+	if !s.snapshot().CurrentIsZsys() && req.GetAuto() {
 		return nil
 	}
 
-	log.Infof(stream.Context(), i18n.G("Updating system boot menu"))
+	log.Infof(ctx, i18n.G("Updating system boot menu"))
 
-	return updateBootMenu(stream.Context())
+	return s.updateBootMenu(ctx)
 }
 
 // UpdateLastUsed updates all active (system and user) datasets with current time
@@ -87,10 +132,18 @@ func (s *Server) UpdateLastUsed(req *zsys.Empty, stream zsys.Zsys_UpdateLastUsed
 		return err
 	}
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	ctx, done := s.audit(stream.Context(), "UpdateLastUsed", nil)
+	defer func() { done(err) }()
 
-	log.Infof(stream.Context(), i18n.G("Updating last used timestamp"))
+	unlock, err := s.lock(ctx, resourceSystem, resourceUsers)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	return s.Machines.UpdateLastUsed(stream.Context())
+	log.Infof(ctx, i18n.G("Updating last used timestamp"))
+
+	return s.update(ctx, func(ms *machines.Machines) error {
+		return ms.UpdateLastUsed(ctx)
+	})
 }
@@ -6,15 +6,18 @@ import (
 	"os/exec"
 
 	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/grub"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
 )
 
 const (
 	updateGrubCmd = "update-grub"
 )
 
-func updateBootMenu(ctx context.Context) error {
+// updateBootMenu regenerates the boot menu, then shows in it the description and label of each system state.
+func (s *Server) updateBootMenu(ctx context.Context) error {
 	log.RemotePrintln(ctx, i18n.G("ZSys is adding automatic system snapshot to GRUB menu"))
 	cmd := exec.Command(updateGrubCmd)
 	logger := &logWriter{ctx: ctx}
@@ -23,6 +26,49 @@ func updateBootMenu(ctx context.Context) error {
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf(i18n.G("%q returned an error: ")+config.ErrorFormat, updateGrubCmd, err)
 	}
+
+	// The boot menu is usable without annotations: only warn on failure.
+	if err := grub.AnnotateHistory(config.GrubMenuPath, bootMenuAnnotations(s.snapshot())); err != nil {
+		log.Warningf(ctx, i18n.G("Couldn't show state descriptions and labels in boot menu: %v"), err)
+	}
+	return nil
+}
+
+// bootMenuAnnotations returns the annotation to show in the boot menu for each annotated system state, by state ID.
+func bootMenuAnnotations(ms *machines.Machines) map[string]string {
+	r := make(map[string]string)
+	for _, m := range ms.List() {
+		for id, s := range m.History {
+			description, label := s.Annotations()
+			switch {
+			case description != "" && label != "":
+				r[id] = fmt.Sprintf("%s [%s]", description, label)
+			case description != "":
+				r[id] = description
+			case label != "":
+				r[id] = fmt.Sprintf("[%s]", label)
+			}
+		}
+	}
+	return r
+}
+
+// scheduleNextBoot asks the bootloader to boot once on the menu entry with the kernel parameters params.
+func scheduleNextBoot(ctx context.Context, params string) error {
+	log.Debugf(ctx, i18n.G("Scheduling boot menu entry with %q"), params)
+	if err := grub.ScheduleNextBoot(config.GrubMenuPath, config.GrubEnvPath, params); err != nil {
+		return fmt.Errorf(i18n.G("couldn't schedule next boot in boot menu: ")+config.ErrorFormat, err)
+	}
+	return nil
+}
+
+// resetNextBoot removes any entry the bootloader boots on once. GRUB can't always reset it
+// itself, like when its environment block is on ZFS.
+func resetNextBoot(ctx context.Context) error {
+	log.Debug(ctx, i18n.G("Resetting next boot menu entry"))
+	if err := grub.ResetNextBoot(config.GrubEnvPath); err != nil {
+		return fmt.Errorf(i18n.G("couldn't reset next boot in boot menu: ")+config.ErrorFormat, err)
+	}
 	return nil
 }
 
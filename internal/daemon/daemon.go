@@ -6,28 +6,49 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/go-systemd/activation"
 	"github.com/coreos/go-systemd/daemon"
+	"github.com/godbus/dbus/v5"
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/journal"
 	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/metrics"
 	"github.com/ubuntu/zsys/internal/zfs/libzfs"
 	"google.golang.org/grpc"
 )
 
 // Server is used to implement zsys.ZsysServer.
 type Server struct {
-	// Machines scanned
-	Machines machines.Machines
-
-	// Requests mutex
-	RWRequest sync.RWMutex
+	// published are the machines after the last change. Requests work on their own fork of them.
+	published atomic.Pointer[machines.Machines]
+	// model protects publishing machines
+	model sync.Mutex
+	// scans counts rescans of machines after a change, to only publish the latest one
+	scans atomic.Uint64
+	// publishedScan is the scan of the published machines
+	publishedScan uint64
+	// locks queues write requests on the resources they change
+	locks *resourceLocks
+
+	// events are dispatched to WatchEvents subscribers
+	events *eventBroker
+	// dbusConn is the system bus connection owning DBusName, if enabled
+	dbusConn *dbus.Conn
+	// journal records write requests
+	journal *journal.Journal
+	// metrics records requests, garbage collections and boot commits
+	metrics *metrics.Recorder
+	// metricsPath persists recorded metrics across daemon instances
+	metricsPath string
 
 	socket     string
 	lis        net.Listener
@@ -64,6 +85,8 @@ func WithLibZFS(libzfs libzfs.Interface) func(o *options) error {
 type options struct {
 	timeout                   time.Duration
 	libzfs                    libzfs.Interface
+	dbus                      bool
+	journalDir                string
 	authorizer                *authorizer.Authorizer
 	systemdActivationListener func() ([]net.Listener, error)
 	systemdSdNotifier         func(unsetEnvironment bool, state string) (bool, error)
@@ -79,6 +102,7 @@ func New(socket string, opts ...option) (*Server, error) {
 		systemdActivationListener: activation.Listeners,
 		systemdSdNotifier:         daemon.SdNotify,
 		libzfs:                    &libzfs.Adapter{},
+		journalDir:                config.DefaultJournalDir,
 	}
 	for _, o := range opts {
 		if err := o(&args); err != nil {
@@ -117,6 +141,17 @@ func New(socket string, opts ...option) (*Server, error) {
 		return nil, fmt.Errorf(i18n.G("couldn't create a new machine: %v"), err)
 	}
 
+	j, err := journal.New(args.journalDir)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't create journal: %v"), err)
+	}
+
+	metricsPath := filepath.Join(args.journalDir, metrics.StateFileName)
+	m, err := metrics.Load(metricsPath)
+	if err != nil {
+		log.Warningf(context.Background(), i18n.G("Couldn't restore previous metrics, starting from scratch: %v"), err)
+	}
+
 	if args.authorizer == nil {
 		args.authorizer, err = authorizer.New()
 		if err != nil {
@@ -125,7 +160,11 @@ func New(socket string, opts ...option) (*Server, error) {
 	}
 
 	s := &Server{
-		Machines: ms,
+		locks:       newResourceLocks(),
+		events:      newEventBroker(),
+		journal:     j,
+		metrics:     m,
+		metricsPath: metricsPath,
 
 		socket: socket,
 		lis:    lis,
@@ -135,9 +174,17 @@ func New(socket string, opts ...option) (*Server, error) {
 
 		idlerTimeout: newIdler(args.timeout),
 	}
-	grpcserver := zsys.RegisterServer(s)
+	s.published.Store(&ms)
+	grpcserver := zsys.RegisterServer(s, grpc.ChainStreamInterceptor(s.observeRequest))
 	s.grpcserver = grpcserver
 
+	if args.dbus {
+		if err := s.exportOnDBus(); err != nil {
+			lis.Close()
+			return nil, fmt.Errorf(i18n.G("couldn't export D-Bus API: %v"), err)
+		}
+	}
+
 	// Handle idle timeout
 	go s.idlerTimeout.start(s)
 
@@ -163,8 +210,14 @@ func (s *Server) Listen() error {
 // Stop gracefully stops the grpc server
 func (s *Server) Stop() {
 	log.Debug(context.Background(), i18n.G("Stopping daemon requested. Wait for active requests to close"))
+	// Events subscribers never end their requests by themselves
+	s.events.close()
+	if s.dbusConn != nil {
+		s.dbusConn.Close()
+	}
 	s.grpcserver.GracefulStop()
 	log.Debug(context.Background(), i18n.G("All connections closed"))
+	s.saveMetrics(context.Background())
 }
 
 // TrackRequest prevents the idling timeout to fire up and return the function to reset it.
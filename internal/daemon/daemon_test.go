@@ -1,14 +1,23 @@
 package daemon_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/godbus/dbus/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/daemon"
+	"github.com/ubuntu/zsys/internal/journal"
+	"github.com/ubuntu/zsys/internal/streamlogger"
 	"github.com/ubuntu/zsys/internal/testutils"
 )
 
@@ -117,6 +126,237 @@ func TestServerDontTimeoutWithMultipleRequests(t *testing.T) {
 	assertServerTimeout(t, s, errs)
 }
 
+func TestServerWatchEvents(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	s, errs := startDaemonAndListen(t, dir, 10*time.Millisecond)
+
+	client, err := zsys.NewZsysUnixSocketClient(filepath.Join(dir, "daemon_test.sock"), logrus.InfoLevel)
+	if err != nil {
+		t.Fatalf("couldn't connect to the daemon: %v", err)
+	}
+	defer client.Close()
+
+	stream, err := client.WatchEvents(client.Ctx, &zsys.Empty{})
+	if err != nil {
+		t.Fatalf("couldn't watch events: %v", err)
+	}
+	events := make(chan *zsys.Event)
+	go func() {
+		defer close(events)
+		for {
+			r, err := stream.Recv()
+			if err == streamlogger.ErrLogMsg {
+				continue
+			}
+			if err != nil {
+				return
+			}
+			events <- r.GetEvent()
+		}
+	}()
+
+	// The subscriber keeps the daemon alive, far after the idle timeout.
+	select {
+	case <-time.After(500 * time.Millisecond):
+	case <-errs:
+		t.Fatalf("server exited prematurely: we had an events subscriber. Exited with %v", errs)
+	}
+
+	gcStream, err := client.GC(client.Ctx, &zsys.GCRequest{})
+	if err != nil {
+		t.Fatalf("couldn't request garbage collection: %v", err)
+	}
+	for {
+		if _, err := gcStream.Recv(); err == io.EOF {
+			break
+		} else if err != nil && err != streamlogger.ErrLogMsg {
+			t.Fatalf("garbage collection failed: %v", err)
+		}
+	}
+
+	for _, want := range []string{"gcStarted", "gcFinished"} {
+		select {
+		case e := <-events:
+			if e == nil {
+				t.Fatalf("expected %s event but the stream was closed", want)
+			}
+			assert.NotNil(t, e.GetTime(), "events are timestamped")
+			switch want {
+			case "gcStarted":
+				assert.NotNil(t, e.GetGcStarted(), "expected a gcStarted event, got %v", e)
+			case "gcFinished":
+				assert.NotNil(t, e.GetGcFinished(), "expected a gcFinished event, got %v", e)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %s event but got none", want)
+		}
+	}
+
+	// The garbage collection is recorded in the journal
+	historyStream, err := client.History(client.Ctx, &zsys.HistoryRequest{Operation: "gc"})
+	if err != nil {
+		t.Fatalf("couldn't request history: %v", err)
+	}
+	var history []*zsys.JournalEntry
+	for {
+		r, err := historyStream.Recv()
+		if err == io.EOF {
+			break
+		} else if err == streamlogger.ErrLogMsg {
+			continue
+		} else if err != nil {
+			t.Fatalf("history failed: %v", err)
+		}
+		history = append(history, r.GetEntry())
+	}
+	if assert.Len(t, history, 1, "one garbage collection in history") {
+		assert.Equal(t, "GC", history[0].GetOperation(), "history entry operation")
+		assert.NotNil(t, history[0].GetTime(), "history entries are timestamped")
+		assert.NotEmpty(t, history[0].GetRequestId(), "history entries have the request id")
+		assert.Empty(t, history[0].GetError(), "garbage collection succeeded")
+	}
+
+	// The garbage collection and requests are counted in metrics
+	metricsStream, err := client.Metrics(client.Ctx, &zsys.Empty{})
+	if err != nil {
+		t.Fatalf("couldn't request metrics: %v", err)
+	}
+	var metrics string
+	for {
+		r, err := metricsStream.Recv()
+		if err == io.EOF {
+			break
+		} else if err == streamlogger.ErrLogMsg {
+			continue
+		} else if err != nil {
+			t.Fatalf("metrics failed: %v", err)
+		}
+		metrics += r.GetMetrics()
+	}
+	assert.Contains(t, metrics, `zsys_gc_runs_total{result="success"} 1`, "garbage collection is counted")
+	assert.Contains(t, metrics, `zsys_requests_total{method="GC",code="OK"} 1`, "GC request is counted")
+	assert.Contains(t, metrics, `zsys_request_duration_seconds_count{method="History"} 1`, "History request duration is recorded")
+
+	// Stopping the daemon ends the subscription.
+	s.Stop()
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatal("events subscription should have ended with the daemon, but it didn't")
+	case e, ok := <-events:
+		if ok {
+			t.Fatalf("expected no more events but got %v", e)
+		}
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerDBus(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	s, err := daemon.New(filepath.Join(dir, "daemon_test.sock"), daemon.WithIdleTimeout(time.Second),
+		daemon.WithLibZFS(testutils.GetMockZFS(t)), daemon.WithDBus(true), daemon.WithJournalDir(filepath.Join(dir, "journal")))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	errs := make(chan error)
+	go func() {
+		if err := s.Listen(); err != nil {
+			errs <- fmt.Errorf("Server exited with error: %v", err)
+		}
+		close(errs)
+	}()
+
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		t.Fatalf("couldn't connect to system bus: %v", err)
+	}
+	defer conn.Close()
+	obj := conn.Object(daemon.DBusName, daemon.DBusPath)
+
+	var owner string
+	if err := conn.BusObject().Call("org.freedesktop.DBus.GetNameOwner", 0, daemon.DBusName).Store(&owner); err != nil {
+		t.Fatalf("daemon should own %s: %v", daemon.DBusName, err)
+	}
+
+	var xml string
+	if err := obj.Call("org.freedesktop.DBus.Introspectable.Introspect", 0).Store(&xml); err != nil {
+		t.Fatalf("couldn't introspect daemon: %v", err)
+	}
+	for _, want := range []string{"ListMachines", "ListStates", "SaveSystemState", "SaveUserState",
+		"RemoveSystemState", "RemoveUserState", "GC", "StateSaved", "StateRemoved"} {
+		assert.Contains(t, xml, fmt.Sprintf("name=%q", want), "introspection exposes %s", want)
+	}
+
+	var machines string
+	if err := obj.Call(daemon.DBusInterface+".ListMachines", 0).Store(&machines); err != nil {
+		t.Fatalf("couldn't list machines: %v", err)
+	}
+	assert.True(t, json.Valid([]byte(machines)), "machines list is valid json: %q", machines)
+
+	if err := obj.Call(daemon.DBusInterface+".GC", 0, false).Err; err != nil {
+		t.Fatalf("couldn't request garbage collection: %v", err)
+	}
+
+	// No current machine on the mock: saving and removing states fails
+	err = obj.Call(daemon.DBusInterface+".SaveSystemState", 0, "foo", "", "").Err
+	var dErr dbus.Error
+	if !errors.As(err, &dErr) {
+		t.Fatalf("expected a D-Bus error when saving state, got: %v", err)
+	}
+	assert.Equal(t, daemon.DBusErrorFailed, dErr.Name, "error name when saving state on a non zsys system")
+
+	err = obj.Call(daemon.DBusInterface+".RemoveSystemState", 0, "", false).Err
+	if !errors.As(err, &dErr) {
+		t.Fatalf("expected a D-Bus error when removing state without name, got: %v", err)
+	}
+	assert.Equal(t, daemon.DBusErrorFailed, dErr.Name, "error name when removing a state without name")
+
+	// Write requests are recorded with their caller and result
+	j, err := journal.New(filepath.Join(dir, "journal"))
+	if err != nil {
+		t.Fatalf("couldn't open journal: %v", err)
+	}
+	entries, err := j.Entries(journal.Filter{})
+	if err != nil {
+		t.Fatalf("couldn't read journal: %v", err)
+	}
+	var ops []string
+	for _, e := range entries {
+		ops = append(ops, e.Operation)
+		assert.Equal(t, uint32(os.Getuid()), e.UID, "caller uid of %s is recorded", e.Operation)
+		assert.NotZero(t, e.PID, "caller pid of %s is recorded", e.Operation)
+	}
+	assert.Equal(t, []string{"GC", "SaveSystemState", "RemoveSystemState"}, ops, "write requests are recorded in order")
+	if len(entries) == 3 {
+		assert.Empty(t, entries[0].Error, "successful garbage collection has no error")
+		assert.Equal(t, map[string]string{"all": "false"}, entries[0].Parameters, "garbage collection parameters")
+		assert.NotEmpty(t, entries[1].Error, "failed save records its error")
+		assert.Equal(t, map[string]string{"state": "foo"}, entries[1].Parameters, "empty parameters aren't recorded")
+	}
+
+	s.Stop()
+	if err := <-errs; err != nil {
+		t.Fatalf("server exited with error: %v", err)
+	}
+
+	var hasOwner bool
+	if err := conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, daemon.DBusName).Store(&hasOwner); err != nil {
+		t.Fatalf("couldn't check name owner: %v", err)
+	}
+	assert.False(t, hasOwner, "name is released once the daemon stops")
+}
+
 func TestServerCannotCreateSocket(t *testing.T) {
 	t.Parallel()
 
@@ -262,7 +502,8 @@ func assertServerTimeout(t *testing.T, s *daemon.Server, errs chan error) {
 func startDaemonAndListen(t *testing.T, dir string, timeout time.Duration) (*daemon.Server, chan error) {
 	t.Helper()
 
-	s, err := daemon.New(filepath.Join(dir, "daemon_test.sock"), daemon.WithIdleTimeout(timeout), daemon.WithLibZFS(testutils.GetMockZFS(t)))
+	s, err := daemon.New(filepath.Join(dir, "daemon_test.sock"), daemon.WithIdleTimeout(timeout), daemon.WithLibZFS(testutils.GetMockZFS(t)),
+		daemon.WithJournalDir(filepath.Join(dir, "journal")))
 	if err != nil {
 		t.Fatalf("expected no error but got: %v", err)
 	}
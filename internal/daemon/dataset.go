@@ -0,0 +1,132 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+// DatasetExclude keeps a path out of the current system states, by moving it to a persistent dataset.
+func (s *Server) DatasetExclude(req *zsys.DatasetExcludeRequest, stream zsys.Zsys_DatasetExcludeServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "DatasetExclude", map[string]string{"path": req.GetPath()})
+	defer func() { done(err) }()
+
+	path := req.GetPath()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourcePersistent)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Infof(ctx, i18n.G("Requesting to exclude %q from system states"), path)
+
+	var name string
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		name, err = ms.ExcludePath(ctx, path)
+		return err
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't exclude %q: ")+config.ErrorFormat, path, err)
+	}
+
+	stream.Send(&zsys.DatasetExcludeResponse{
+		Reply: &zsys.DatasetExcludeResponse_Dataset{Dataset: name},
+	})
+
+	return nil
+}
+
+// DatasetList returns all persistent datasets.
+func (s *Server) DatasetList(req *zsys.Empty, stream zsys.Zsys_DatasetListServer) error {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
+		return err
+	}
+
+	log.Infof(stream.Context(), i18n.G("Retrieving list of persistent datasets."))
+
+	stream.Send(&zsys.DatasetListResponse{
+		Reply: &zsys.DatasetListResponse_Datasets{
+			Datasets: &zsys.Datasets{Datasets: datasetsToProto(s.snapshot().ListPersistentDatasets())},
+		},
+	})
+
+	return nil
+}
+
+// DatasetCreatePersistent creates a new persistent dataset on an empty path.
+func (s *Server) DatasetCreatePersistent(req *zsys.DatasetPersistentRequest, stream zsys.Zsys_DatasetCreatePersistentServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "DatasetCreatePersistent", map[string]string{"path": req.GetPath(), "follow-system": strconv.FormatBool(req.GetFollowSystem())})
+	defer func() { done(err) }()
+
+	path, followSystem := req.GetPath(), req.GetFollowSystem()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourcePersistent)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Infof(ctx, i18n.G("Requesting to create a persistent dataset for %q"), path)
+
+	var name string
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		name, err = ms.CreatePersistent(ctx, path, followSystem)
+		return err
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't create a persistent dataset for %q: ")+config.ErrorFormat, path, err)
+	}
+
+	stream.Send(&zsys.DatasetPersistentResponse{
+		Reply: &zsys.DatasetPersistentResponse_Dataset{Dataset: name},
+	})
+
+	return nil
+}
+
+// DatasetMakePersistent moves a system dataset to a persistent one, or changes if a persistent dataset follows system states.
+func (s *Server) DatasetMakePersistent(req *zsys.DatasetPersistentRequest, stream zsys.Zsys_DatasetMakePersistentServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "DatasetMakePersistent", map[string]string{"path": req.GetPath(), "follow-system": strconv.FormatBool(req.GetFollowSystem())})
+	defer func() { done(err) }()
+
+	path, followSystem := req.GetPath(), req.GetFollowSystem()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourcePersistent)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Infof(ctx, i18n.G("Requesting to make %q persistent"), path)
+
+	var name string
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		name, err = ms.MakePersistent(ctx, path, followSystem)
+		return err
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't make %q persistent: ")+config.ErrorFormat, path, err)
+	}
+
+	stream.Send(&zsys.DatasetPersistentResponse{
+		Reply: &zsys.DatasetPersistentResponse_Dataset{Dataset: name},
+	})
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+//go:generate go run ../generators/copy.go com.ubuntu.Zsys.conf dbus-1/system.d ../../generated
+//go:generate go run ../generators/copy.go com.ubuntu.Zsys.service dbus-1/system-services ../../generated
+const (
+	// DBusName is the well-known name owned by the daemon on the system bus.
+	DBusName = "com.ubuntu.Zsys"
+	// DBusPath is the object path exporting the zsys interface.
+	DBusPath dbus.ObjectPath = "/com/ubuntu/Zsys"
+	// DBusInterface is the interface exporting methods and signals on DBusPath.
+	DBusInterface = "com.ubuntu.Zsys"
+
+	// DBusErrorFailed is the D-Bus error name returned when a request fails.
+	DBusErrorFailed = DBusInterface + ".Error.Failed"
+	// DBusErrorPermissionDenied is the D-Bus error name returned when the caller isn't authorized.
+	DBusErrorPermissionDenied = DBusInterface + ".Error.PermissionDenied"
+	// DBusErrorNeedsConfirmation is the D-Bus error name returned when a removal needs to be forced.
+	// The error message lists the states and datasets which would be removed.
+	DBusErrorNeedsConfirmation = DBusInterface + ".Error.NeedsConfirmation"
+)
+
+// WithDBus makes the daemon own DBusName on the system bus, exporting its D-Bus API, if enabled.
+func WithDBus(enabled bool) func(o *options) error {
+	return func(o *options) error {
+		o.dbus = enabled
+		return nil
+	}
+}
+
+// dbusAPI exports a subset of the grpc service on the system bus, for clients which can't talk grpc.
+// Only its exported methods are part of the D-Bus interface.
+type dbusAPI struct {
+	s    *Server
+	conn *dbus.Conn
+}
+
+// exportOnDBus connects to the system bus, exports the D-Bus API and owns DBusName.
+// State changes are emitted as signals until the connection is closed.
+func (s *Server) exportOnDBus() error {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't connect to system bus: %v"), err)
+	}
+
+	d := dbusAPI{s: s, conn: conn}
+	if err := conn.Export(d, DBusPath, DBusInterface); err != nil {
+		conn.Close()
+		return fmt.Errorf(i18n.G("couldn't export D-Bus interface: %v"), err)
+	}
+	node := &introspect.Node{
+		Name: string(DBusPath),
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			{
+				Name:    DBusInterface,
+				Methods: introspect.Methods(d),
+				Signals: []introspect.Signal{
+					{Name: "StateSaved", Args: []introspect.Arg{{Name: "stateName", Type: "s"}, {Name: "user", Type: "s"}}},
+					{Name: "StateRemoved", Args: []introspect.Arg{{Name: "stateName", Type: "s"}, {Name: "user", Type: "s"}}},
+				},
+			},
+		},
+	}
+	if err := conn.Export(introspect.NewIntrospectable(node), DBusPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		conn.Close()
+		return fmt.Errorf(i18n.G("couldn't export D-Bus introspection: %v"), err)
+	}
+
+	reply, err := conn.RequestName(DBusName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf(i18n.G("couldn't request name %q on system bus: %v"), DBusName, err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		conn.Close()
+		return fmt.Errorf(i18n.G("name %q is already owned on system bus"), DBusName)
+	}
+
+	s.dbusConn = conn
+	events, _ := s.events.subscribe()
+	go d.emitSignals(events)
+
+	return nil
+}
+
+// emitSignals sends state changes as D-Bus signals until events is closed.
+func (d dbusAPI) emitSignals(events <-chan *zsys.Event) {
+	for e := range events {
+		var name string
+		var se *zsys.StateEvent
+		switch {
+		case e.GetStateSaved() != nil:
+			name, se = "StateSaved", e.GetStateSaved()
+		case e.GetStateRemoved() != nil:
+			name, se = "StateRemoved", e.GetStateRemoved()
+		default:
+			continue
+		}
+		if err := d.conn.Emit(DBusPath, DBusInterface+"."+name, se.GetStateName(), se.GetUser()); err != nil {
+			log.Warningf(context.Background(), i18n.G("Couldn't emit D-Bus signal %s: %v"), name, err)
+		}
+	}
+}
+
+// authorize checks that sender is allowed to perform action, optionally on userName datasets.
+// It returns the context to use for the request.
+func (d dbusAPI) authorize(sender dbus.Sender, action authorizer.Action, userName string) (context.Context, *dbus.Error) {
+	ctx := context.Background()
+	if userName != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, userName)
+	}
+
+	var pid, uid uint32
+	if err := d.conn.BusObject().Call("org.freedesktop.DBus.GetConnectionUnixProcessID", 0, string(sender)).Store(&pid); err != nil {
+		return nil, dbus.NewError(DBusErrorPermissionDenied, []interface{}{
+			fmt.Sprintf(i18n.G("Permission denied: couldn't get process of %s: %v"), sender, err)})
+	}
+	if err := d.conn.BusObject().Call("org.freedesktop.DBus.GetConnectionUnixUser", 0, string(sender)).Store(&uid); err != nil {
+		return nil, dbus.NewError(DBusErrorPermissionDenied, []interface{}{
+			fmt.Sprintf(i18n.G("Permission denied: couldn't get user of %s: %v"), sender, err)})
+	}
+
+	if err := d.s.authorizer.IsAllowedFromBusName(ctx, action, string(sender), uid); err != nil {
+		return nil, dbus.NewError(DBusErrorPermissionDenied, []interface{}{err.Error()})
+	}
+	return authorizer.WithPeerCreds(ctx, int32(pid), uid), nil
+}
+
+// toDBusError converts err to a D-Bus error, nil if err is nil.
+func toDBusError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+	var e *machines.ErrStateRemovalNeedsConfirmation
+	if errors.As(err, &e) {
+		return dbus.NewError(DBusErrorNeedsConfirmation, []interface{}{e.Error()})
+	}
+	return dbus.NewError(DBusErrorFailed, []interface{}{err.Error()})
+}
+
+// ListMachines returns all machines and their states, in the json format of the grpc Machines message.
+func (d dbusAPI) ListMachines(sender dbus.Sender) (string, *dbus.Error) {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionAlwaysAllowed, "")
+	if dErr != nil {
+		return "", dErr
+	}
+
+	log.Info(ctx, i18n.G("Retrieving list of machines over D-Bus."))
+
+	b, err := protojson.Marshal(machinesToProto(*d.s.snapshot()))
+	if err != nil {
+		return "", toDBusError(err)
+	}
+	return string(b), nil
+}
+
+// ListStates returns the history of system states of machineID, or the current machine if empty,
+// as a json array of the grpc State message.
+func (d dbusAPI) ListStates(sender dbus.Sender, machineID string) (string, *dbus.Error) {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionAlwaysAllowed, "")
+	if dErr != nil {
+		return "", dErr
+	}
+
+	m, err := d.s.snapshot().GetMachine(machineID)
+	if err != nil {
+		return "", toDBusError(err)
+	}
+
+	log.Infof(ctx, i18n.G("Retrieving states of machine %s over D-Bus."), m.ID)
+
+	states := make([]string, 0, len(m.History))
+	for _, s := range machineToProto(m, false).GetHistory() {
+		b, err := protojson.Marshal(s)
+		if err != nil {
+			return "", toDBusError(err)
+		}
+		states = append(states, string(b))
+	}
+	return "[" + strings.Join(states, ",") + "]", nil
+}
+
+// SaveSystemState saves the current system and users datasets, updating the boot menu.
+// An id is generated if stateName is empty. It returns the name of the new state.
+func (d dbusAPI) SaveSystemState(sender dbus.Sender, stateName, description, label string) (string, *dbus.Error) {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionSystemWrite, "")
+	if dErr != nil {
+		return "", dErr
+	}
+
+	ctx, done := d.s.audit(ctx, "SaveSystemState", map[string]string{
+		"state":       stateName,
+		"description": description,
+		"label":       label,
+	})
+	stateName, err := d.s.saveSystemState(ctx, stateName, description, label, false, true)
+	done(err)
+	return stateName, toDBusError(err)
+}
+
+// SaveUserState saves the current datasets of userName.
+// An id is generated if stateName is empty. It returns the name of the new state.
+func (d dbusAPI) SaveUserState(sender dbus.Sender, userName, stateName, description, label string) (string, *dbus.Error) {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionUserWrite, userName)
+	if dErr != nil {
+		return "", dErr
+	}
+
+	ctx, done := d.s.audit(ctx, "SaveUserState", map[string]string{
+		"user":        userName,
+		"state":       stateName,
+		"description": description,
+		"label":       label,
+	})
+	stateName, err := d.s.saveUserState(ctx, userName, stateName, description, label)
+	done(err)
+	return stateName, toDBusError(err)
+}
+
+// RemoveSystemState removes stateName and all depending states from system.
+// Without force, any removal of other states or of pinned ones fails with DBusErrorNeedsConfirmation.
+func (d dbusAPI) RemoveSystemState(sender dbus.Sender, stateName string, force bool) *dbus.Error {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionSystemWrite, "")
+	if dErr != nil {
+		return dErr
+	}
+
+	ctx, done := d.s.audit(ctx, "RemoveSystemState", map[string]string{"state": stateName, "force": strconv.FormatBool(force)})
+	err := d.s.removeSystemState(ctx, stateName, force, false)
+	done(err)
+	return toDBusError(err)
+}
+
+// RemoveUserState removes stateName of userName.
+// Without force, any removal of other states or of pinned ones fails with DBusErrorNeedsConfirmation.
+func (d dbusAPI) RemoveUserState(sender dbus.Sender, userName, stateName string, force bool) *dbus.Error {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionUserWrite, userName)
+	if dErr != nil {
+		return dErr
+	}
+
+	ctx, done := d.s.audit(ctx, "RemoveUserState", map[string]string{"user": userName, "state": stateName, "force": strconv.FormatBool(force)})
+	err := d.s.removeUserState(ctx, userName, stateName, force, false)
+	done(err)
+	return toDBusError(err)
+}
+
+// GC runs the garbage collection, on all states if all is true.
+func (d dbusAPI) GC(sender dbus.Sender, all bool) *dbus.Error {
+	defer d.s.TrackRequest()()
+	ctx, dErr := d.authorize(sender, authorizer.ActionAlwaysAllowed, "")
+	if dErr != nil {
+		return dErr
+	}
+
+	log.Info(ctx, i18n.G("Requesting zsys daemon to garbage collect over D-Bus"))
+
+	ctx, done := d.s.audit(ctx, "GC", map[string]string{"all": strconv.FormatBool(all)})
+	err := func() error {
+		unlock, err := d.s.lock(ctx, resourceAll)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		return d.s.gc(ctx, all, false, false)
+	}()
+	done(err)
+	return toDBusError(err)
+}
@@ -0,0 +1,179 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+// eventsBufferSize is the number of events kept per subscriber before dropping new ones for a slow reader.
+const eventsBufferSize = 32
+
+// eventBroker dispatches events to all WatchEvents subscribers.
+type eventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan *zsys.Event]struct{}
+	closed      bool
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[chan *zsys.Event]struct{})}
+}
+
+// subscribe returns a channel receiving all new events and the function to unsubscribe.
+// The channel is closed once unsubscribed or when the broker is closed.
+func (b *eventBroker) subscribe() (<-chan *zsys.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := make(chan *zsys.Event, eventsBufferSize)
+	if b.closed {
+		close(c)
+		return c, func() {}
+	}
+	b.subscribers[c] = struct{}{}
+
+	return c, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[c]; !ok {
+			return
+		}
+		delete(b.subscribers, c)
+		close(c)
+	}
+}
+
+// publish sends e to all subscribers, timestamping it. It never blocks: events are dropped for subscribers
+// which are not reading fast enough.
+func (b *eventBroker) publish(e *zsys.Event) {
+	e.Time = timeToProto(time.Now())
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.subscribers {
+		select {
+		case c <- e:
+		default:
+			log.Warning(context.Background(), i18n.G("An events subscriber isn't reading fast enough, dropping event"))
+		}
+	}
+}
+
+// close ends all subscriptions. Any new subscription is closed immediately.
+func (b *eventBroker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for c := range b.subscribers {
+		delete(b.subscribers, c)
+		close(c)
+	}
+}
+
+// WatchEvents streams events as they happen in the daemon until the client disconnects.
+// The connection keeps the daemon from stopping on idle timeout.
+func (s *Server) WatchEvents(req *zsys.Empty, stream zsys.Zsys_WatchEventsServer) error {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
+		return err
+	}
+
+	log.Info(stream.Context(), i18n.G("Watching daemon events"))
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&zsys.WatchEventsResponse{
+				Reply: &zsys.WatchEventsResponse_Event{Event: e},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// stateSavedEvent returns an event for the new state stateName, of user if not empty.
+func stateSavedEvent(stateName, user string) *zsys.Event {
+	return &zsys.Event{Event: &zsys.Event_StateSaved{StateSaved: &zsys.StateEvent{StateName: stateName, User: user}}}
+}
+
+// stateRemovedEvent returns an event for the removed state stateName, of user if not empty.
+func stateRemovedEvent(stateName, user string) *zsys.Event {
+	return &zsys.Event{Event: &zsys.Event_StateRemoved{StateRemoved: &zsys.StateEvent{StateName: stateName, User: user}}}
+}
+
+// lowSpaceEvent returns an event for a pool below the minimum free space if err is about it, nil otherwise.
+func lowSpaceEvent(err error) *zsys.Event {
+	var e *machines.ErrNotEnoughFreeSpace
+	if !errors.As(err, &e) {
+		return nil
+	}
+	return &zsys.Event{Event: &zsys.Event_LowSpace{LowSpace: &zsys.LowSpaceEvent{
+		Pool: e.Pool,
+		Free: uint32(e.Free),
+		Min:  uint32(e.Min),
+	}}}
+}
+
+// countStates returns the number of system and user states on all machines.
+func countStates(ms machines.Machines) (system, user uint32) {
+	for _, m := range ms.List() {
+		system += uint32(len(m.History))
+		for _, states := range m.AllUsersStates {
+			user += uint32(len(states))
+		}
+	}
+	return system, user
+}
+
+// gc runs the garbage collection, sending events when it starts and finishes.
+// The caller is responsible for locking all resources.
+func (s *Server) gc(ctx context.Context, all, dryrun, explain bool) error {
+	runGC := func(ms *machines.Machines) error {
+		return ms.GC(ctx, all, dryrun, explain)
+	}
+	if dryrun {
+		return s.read(ctx, runGC)
+	}
+
+	system, user := countStates(*s.snapshot())
+	s.events.publish(&zsys.Event{Event: &zsys.Event_GcStarted{GcStarted: &zsys.GCEvent{
+		SystemStates: system,
+		UserStates:   user,
+	}}})
+
+	start := time.Now()
+	err := s.update(ctx, runGC)
+	end := time.Now()
+
+	newSystem, newUser := countStates(*s.snapshot())
+	var removed uint32
+	if total, newTotal := system+user, newSystem+newUser; total > newTotal {
+		removed = total - newTotal
+	}
+	s.events.publish(&zsys.Event{Event: &zsys.Event_GcFinished{GcFinished: &zsys.GCEvent{
+		SystemStates:  newSystem,
+		UserStates:    newUser,
+		RemovedStates: removed,
+	}}})
+	s.metrics.ObserveGC(end, end.Sub(start), removed, err)
+	s.saveMetrics(ctx)
+	s.writeMetricsTextfile(ctx)
+
+	return err
+}
@@ -0,0 +1,229 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+func TestResourceConflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b resource
+
+		want bool
+	}{
+		"Same resource":                          {a: resourceSystem, b: resourceSystem, want: true},
+		"Different resources":                    {a: resourceSystem, b: resourcePersistent},
+		"All conflicts with anything":            {a: resourceAll, b: resourceBootMenu, want: true},
+		"Anything conflicts with all":            {a: userResource("foo"), b: resourceAll, want: true},
+		"Same user":                              {a: userResource("foo"), b: userResource("foo"), want: true},
+		"Different users":                        {a: userResource("foo"), b: userResource("bar")},
+		"All users conflicts with any user":      {a: resourceUsers, b: userResource("foo"), want: true},
+		"Any user conflicts with all users":      {a: userResource("foo"), b: resourceUsers, want: true},
+		"All users doesn't conflict with system": {a: resourceUsers, b: resourceSystem},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, tc.a.conflicts(tc.b), "conflict between resources")
+		})
+	}
+}
+
+func TestAcquireResources(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		held    []resource
+		waiting []resource
+		request []resource
+
+		wantWait     bool
+		wantPosition int
+	}{
+		"Free resources are acquired immediately": {request: []resource{resourceSystem}},
+		"Other user resources are acquired immediately": {
+			held: []resource{userResource("foo")}, request: []resource{userResource("bar")}},
+
+		"Wait for a held resource":    {held: []resource{userResource("foo")}, request: []resource{userResource("foo")}, wantWait: true, wantPosition: 1},
+		"Wait for all resources held": {held: []resource{resourceAll}, request: []resource{resourceBootMenu}, wantWait: true, wantPosition: 1},
+		"Wait for any resource held": {held: []resource{resourceBootMenu},
+			request: []resource{resourceSystem, resourceBootMenu}, wantWait: true, wantPosition: 1},
+		"Wait behind a conflicting waiting request": {held: []resource{resourceAll}, waiting: []resource{userResource("foo")},
+			request: []resource{userResource("foo")}, wantWait: true, wantPosition: 2},
+		"Don't count non conflicting waiting requests": {held: []resource{resourceAll}, waiting: []resource{userResource("bar")},
+			request: []resource{userResource("foo")}, wantWait: true, wantPosition: 1},
+		// No starvation: later requests can't overtake a waiting request on the same resources
+		"Wait behind a waiting request even if free": {held: []resource{resourceSystem}, waiting: []resource{resourceSystem, userResource("foo")},
+			request: []resource{userResource("foo")}, wantWait: true, wantPosition: 1},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newResourceLocks()
+			ctx := context.Background()
+			noReport := func(int) {}
+
+			var releaseHeld func()
+			if tc.held != nil {
+				r, err := l.acquire(ctx, noReport, tc.held...)
+				if err != nil {
+					t.Fatalf("Setup: couldn't acquire held resources: %v", err)
+				}
+				releaseHeld = r
+			}
+			waitingAcquired := make(chan func())
+			if tc.waiting != nil {
+				waitingQueued := make(chan struct{}, 1)
+				go func() {
+					release, err := l.acquire(ctx, notifyQueued(waitingQueued), tc.waiting...)
+					if err != nil {
+						t.Errorf("Setup: couldn't acquire waiting resources: %v", err)
+					}
+					waitingAcquired <- release
+				}()
+				<-waitingQueued
+			}
+
+			positions := make(chan int, 10)
+			acquired := make(chan func())
+			go func() {
+				release, err := l.acquire(ctx, func(pos int) { positions <- pos }, tc.request...)
+				if err != nil {
+					t.Errorf("couldn't acquire resources: %v", err)
+				}
+				acquired <- release
+			}()
+
+			if !tc.wantWait {
+				select {
+				case release := <-acquired:
+					release()
+				case <-time.After(5 * time.Second):
+					t.Fatal("expected resources to be acquired immediately")
+				}
+				return
+			}
+
+			assert.Equal(t, tc.wantPosition, <-positions, "position reported while waiting")
+			select {
+			case <-acquired:
+				t.Fatal("expected request to wait for held resources")
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			// Release held resources: waiting requests get them in order
+			releaseHeld()
+			if tc.waiting != nil {
+				release := <-waitingAcquired
+				release()
+			}
+			select {
+			case release := <-acquired:
+				release()
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected resources to be acquired once released")
+			}
+		})
+	}
+}
+
+func TestAcquireResourcesReportsPositionRegularly(t *testing.T) {
+	t.Parallel()
+
+	l := newResourceLocks()
+	l.reportInterval = 10 * time.Millisecond
+
+	release, err := l.acquire(context.Background(), func(int) {}, resourceAll)
+	if err != nil {
+		t.Fatalf("Setup: couldn't acquire held resources: %v", err)
+	}
+	defer release()
+
+	positions := make(chan int, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.acquire(ctx, func(pos int) {
+		select {
+		case positions <- pos:
+		default:
+		}
+	}, resourceSystem)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case pos := <-positions:
+			assert.Equal(t, 1, pos, "position reported while waiting")
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected position to be reported regularly")
+		}
+	}
+}
+
+func TestAcquireResourcesCancelled(t *testing.T) {
+	t.Parallel()
+
+	l := newResourceLocks()
+	release, err := l.acquire(context.Background(), func(int) {}, resourceSystem)
+	if err != nil {
+		t.Fatalf("Setup: couldn't acquire held resources: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan struct{}, 1)
+	errs := make(chan error)
+	go func() {
+		_, err := l.acquire(ctx, notifyQueued(queued), resourceSystem)
+		errs <- err
+	}()
+	<-queued
+	cancel()
+
+	err = <-errs
+	assert.ErrorIs(t, err, context.Canceled, "acquire should fail once cancelled")
+
+	// The cancelled request left the queue
+	release()
+	release, err = l.acquire(context.Background(), func(pos int) {
+		t.Errorf("expected no wait once held resources are released but got position %d", pos)
+	}, resourceSystem)
+	if err != nil {
+		t.Fatalf("couldn't acquire resources once released: %v", err)
+	}
+	release()
+}
+
+// notifyQueued returns a position reporter signaling on queued that the request is waiting.
+func notifyQueued(queued chan<- struct{}) func(int) {
+	return func(int) {
+		select {
+		case queued <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func TestPublishKeepsLatestScan(t *testing.T) {
+	t.Parallel()
+
+	var s Server
+	first, second := &machines.Machines{}, &machines.Machines{}
+
+	s.publish(2, second)
+	s.publish(1, first)
+	assert.Same(t, second, s.snapshot(), "machines from an earlier scan aren't published over later ones")
+
+	s.publish(3, first)
+	assert.Same(t, first, s.snapshot(), "machines from a later scan are published")
+}
@@ -0,0 +1,187 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/journal"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// WithJournalDir changes the directory where write requests are recorded.
+func WithJournalDir(dir string) func(o *options) error {
+	return func(o *options) error {
+		o.journalDir = dir
+		return nil
+	}
+}
+
+type auditKey struct{}
+
+// auditRecord collects datasets changed by a request while it runs.
+type auditRecord struct {
+	mu       sync.Mutex
+	datasets map[string]bool
+}
+
+func (r *auditRecord) add(datasets []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range datasets {
+		r.datasets[d] = true
+	}
+}
+
+// audit starts recording a write request in the journal, with its non empty parameters.
+// It returns the context to use for the request, so that changed datasets are recorded,
+// and the function to call with the request result to write the entry.
+func (s *Server) audit(ctx context.Context, operation string, params map[string]string) (context.Context, func(error)) {
+	e := journal.Entry{
+		Time:      time.Now(),
+		Operation: operation,
+	}
+	for k, v := range params {
+		if v == "" {
+			continue
+		}
+		if e.Parameters == nil {
+			e.Parameters = make(map[string]string)
+		}
+		e.Parameters[k] = v
+	}
+	e.RequestID, _ = log.IDFromContext(ctx)
+	if pid, uid, err := authorizer.PeerCredsFromContext(ctx); err == nil {
+		e.PID, e.UID = pid, uid
+	}
+
+	r := &auditRecord{datasets: make(map[string]bool)}
+	ctx = context.WithValue(ctx, auditKey{}, r)
+
+	return ctx, func(err error) {
+		if err != nil {
+			e.Error = err.Error()
+		}
+		r.mu.Lock()
+		for d := range r.datasets {
+			e.Datasets = append(e.Datasets, d)
+		}
+		r.mu.Unlock()
+		sort.Strings(e.Datasets)
+
+		if err := s.journal.Append(e); err != nil {
+			log.Warningf(ctx, i18n.G("Couldn't record %s request in journal: %v"), operation, err)
+		}
+	}
+}
+
+// recordChanges adds datasets which differ between prev and next to the audit record of ctx, if any.
+func recordChanges(ctx context.Context, prev, next *machines.Machines) {
+	r, ok := ctx.Value(auditKey{}).(*auditRecord)
+	if !ok {
+		return
+	}
+
+	before, after := datasetsProps(prev), datasetsProps(next)
+	var changed []string
+	for name, p := range before {
+		if n, ok := after[name]; !ok || n != p {
+			changed = append(changed, name)
+		}
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	r.add(changed)
+}
+
+// datasetsProps returns the properties of every dataset of ms by name, without the ones
+// changing on their own like space usage or mount state.
+func datasetsProps(ms *machines.Machines) map[string]zfs.DatasetProp {
+	r := make(map[string]zfs.DatasetProp)
+	add := func(ds []*zfs.Dataset) {
+		for _, d := range ds {
+			p := d.DatasetProp
+			p.Used, p.Referenced, p.Written, p.Mounted = 0, 0, 0, false
+			r[d.Name] = p
+		}
+	}
+	var addState func(s *machines.State)
+	addState = func(s *machines.State) {
+		for _, ds := range s.Datasets {
+			add(ds)
+		}
+		for _, us := range s.Users {
+			addState(us)
+		}
+	}
+
+	for _, m := range ms.List() {
+		addState(&m.State)
+		for _, h := range m.History {
+			addState(h)
+		}
+		for _, states := range m.AllUsersStates {
+			for _, us := range states {
+				addState(us)
+			}
+		}
+		add(m.PersistentDatasets)
+		add(m.Bookmarks)
+	}
+	add(ms.ListPersistentDatasets())
+
+	return r
+}
+
+// History returns the write requests recorded in the journal, from the oldest to the most recent one.
+func (s *Server) History(req *zsys.HistoryRequest, stream zsys.Zsys_HistoryServer) error {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemList); err != nil {
+		return err
+	}
+
+	log.Info(stream.Context(), i18n.G("Retrieving history of write requests"))
+
+	f := journal.Filter{
+		Operation: req.GetOperation(),
+		Match:     req.GetMatch(),
+		Limit:     int(req.GetLimit()),
+	}
+	if req.GetSince() != nil {
+		f.Since = req.GetSince().AsTime()
+	}
+	entries, err := s.journal.Entries(f)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't read journal: ")+config.ErrorFormat, err)
+	}
+
+	for _, e := range entries {
+		if err := stream.Send(&zsys.HistoryResponse{
+			Reply: &zsys.HistoryResponse_Entry{Entry: &zsys.JournalEntry{
+				Time:       timeToProto(e.Time),
+				RequestId:  e.RequestID,
+				Uid:        e.UID,
+				Pid:        e.PID,
+				Operation:  e.Operation,
+				Parameters: e.Parameters,
+				Error:      e.Error,
+				Datasets:   e.Datasets,
+			}},
+		}); err != nil {
+			return fmt.Errorf(i18n.G("couldn't send history to client: %v"), err)
+		}
+	}
+
+	return nil
+}
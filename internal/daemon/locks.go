@@ -0,0 +1,217 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+// resource is a part of the system changed by a request. Requests on conflicting resources are queued.
+type resource string
+
+const (
+	// resourceAll conflicts with every other resource.
+	resourceAll resource = "*"
+	// resourceSystem covers system datasets and states of all machines.
+	resourceSystem resource = "system"
+	// resourceUsers covers datasets and states of all users, conflicting with every userResource.
+	resourceUsers resource = "users"
+	// resourcePersistent covers persistent datasets.
+	resourcePersistent resource = "persistent"
+	// resourceBootMenu covers the boot menu generation.
+	resourceBootMenu resource = "bootmenu"
+
+	userResourcePrefix = "user:"
+)
+
+// userResource covers datasets and states of userName.
+func userResource(userName string) resource {
+	return resource(userResourcePrefix + userName)
+}
+
+// conflicts returns if requests on r and o can't run concurrently.
+func (r resource) conflicts(o resource) bool {
+	switch {
+	case r == resourceAll || o == resourceAll:
+		return true
+	case r == resourceUsers:
+		return o == resourceUsers || strings.HasPrefix(string(o), userResourcePrefix)
+	case o == resourceUsers:
+		return strings.HasPrefix(string(r), userResourcePrefix)
+	}
+	return r == o
+}
+
+// queueReportInterval is the interval at which waiting requests are reminded of their position,
+// below the client inactivity timeout.
+const queueReportInterval = 10 * time.Second
+
+// lockRequest is a request waiting for or holding resources.
+type lockRequest struct {
+	resources []resource
+}
+
+func (r *lockRequest) conflicts(o *lockRequest) bool {
+	for _, a := range r.resources {
+		for _, b := range o.resources {
+			if a.conflicts(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourceLocks queues requests in arrival order. A request runs once no request before it
+// in the queue is on conflicting resources, so that no request can be starved.
+type resourceLocks struct {
+	mu    sync.Mutex
+	queue []*lockRequest
+	// changed is closed and replaced each time a request leaves the queue
+	changed chan struct{}
+
+	reportInterval time.Duration
+}
+
+func newResourceLocks() *resourceLocks {
+	return &resourceLocks{
+		changed:        make(chan struct{}),
+		reportInterval: queueReportInterval,
+	}
+}
+
+// acquire waits for resources to be available and returns the function releasing them.
+// While waiting, report is called with the number of conflicting requests before this one each time it changes
+// and at regular interval. It fails if ctx is cancelled before resources are acquired.
+func (l *resourceLocks) acquire(ctx context.Context, report func(position int), resources ...resource) (release func(), err error) {
+	r := &lockRequest{resources: resources}
+
+	l.mu.Lock()
+	l.queue = append(l.queue, r)
+	l.mu.Unlock()
+
+	var lastPos int
+	for {
+		l.mu.Lock()
+		pos := l.position(r)
+		changed := l.changed
+		l.mu.Unlock()
+
+		if pos == 0 {
+			var once sync.Once
+			return func() { once.Do(func() { l.remove(r) }) }, nil
+		}
+
+		if pos != lastPos {
+			report(pos)
+			lastPos = pos
+		}
+
+		select {
+		case <-changed:
+		case <-time.After(l.reportInterval):
+			report(pos)
+		case <-ctx.Done():
+			l.remove(r)
+			return nil, fmt.Errorf(i18n.G("request cancelled while waiting for other requests: %w"), ctx.Err())
+		}
+	}
+}
+
+// position returns the number of requests on conflicting resources queued before r.
+// The caller needs to hold l.mu.
+func (l *resourceLocks) position(r *lockRequest) (pos int) {
+	for _, o := range l.queue {
+		if o == r {
+			break
+		}
+		if o.conflicts(r) {
+			pos++
+		}
+	}
+	return pos
+}
+
+// remove takes r out of the queue and wakes up waiting requests.
+func (l *resourceLocks) remove(r *lockRequest) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, o := range l.queue {
+		if o == r {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			break
+		}
+	}
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// lock queues the request until resources it changes are available, telling the client its position in the queue
+// while waiting. It returns the function to call once the request is done with those resources.
+// Changes to the machines still need to go through update.
+func (s *Server) lock(ctx context.Context, resources ...resource) (func(), error) {
+	return s.locks.acquire(ctx, func(pos int) {
+		log.RemotePrintf(ctx, i18n.NG("Waiting for %d other request to complete\n",
+			"Waiting for %d other requests to complete\n", uint32(pos)), pos)
+	}, resources...)
+}
+
+// update runs f on a fork of the machines, so that requests on other resources can change them concurrently,
+// then publishes them for read requests. Datasets changed by f are recorded for the request journal entry.
+// It should be called only with the resources changed by f locked.
+func (s *Server) update(ctx context.Context, f func(ms *machines.Machines) error) error {
+	ms, err := s.snapshot().Fork(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't scan machines: %v"), err)
+	}
+	prev := ms.Copy()
+
+	defer func() {
+		// Rescan to publish changes made by f and by any request which completed in the meantime.
+		scan := s.scans.Add(1)
+		if err := ms.Refresh(ctx); err != nil {
+			log.Warningf(ctx, i18n.G("Couldn't refresh machines after request: %v"), err)
+			return
+		}
+		recordChanges(ctx, prev, ms)
+		s.publish(scan, ms)
+	}()
+
+	return f(ms)
+}
+
+// publish makes ms the machines for read requests, unless machines from a later scan were already published.
+func (s *Server) publish(scan uint64, ms *machines.Machines) {
+	s.model.Lock()
+	defer s.model.Unlock()
+
+	if scan < s.publishedScan {
+		return
+	}
+	s.publishedScan = scan
+	s.published.Store(ms)
+}
+
+// read runs f on a fork of the machines for read requests which need to access zfs.
+// Any change f makes on its fork isn't published.
+func (s *Server) read(ctx context.Context, f func(ms *machines.Machines) error) error {
+	ms, err := s.snapshot().Fork(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't scan machines: %v"), err)
+	}
+
+	return f(ms)
+}
+
+// snapshot returns the machines as of the last change, for read requests.
+// It is never changed and so can be used without locking, but only for reading its content: use read to access zfs.
+func (s *Server) snapshot() *machines.Machines {
+	return s.published.Load()
+}
@@ -1,12 +1,21 @@
 package daemon
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MachineShow returns information about the machine id passed in argument
@@ -15,24 +24,26 @@ func (s *Server) MachineShow(req *zsys.MachineShowRequest, stream zsys.Zsys_Mach
 		return err
 	}
 
-	fullInfo := req.GetFull()
-
-	m, err := s.Machines.GetMachine(req.GetMachineId())
+	ms := s.snapshot()
+	m, err := ms.GetMachine(req.GetMachineId())
 	if err != nil {
 		return err
 	}
 
 	log.Infof(stream.Context(), i18n.G("Retrieving information for machine %s"), m.ID)
 
-	machineInfo, err := m.Info(fullInfo)
+	current, _ := ms.GetMachine("")
+	pm := machineToProto(m, m == current)
+	// Older clients only read the preformatted text.
+	machineInfo, err := pm.Text(req.GetFull())
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't fetch matching information: %v"), err)
 	}
-
 	stream.Send(&zsys.MachineShowResponse{
-		Reply: &zsys.MachineShowResponse_MachineInfo{
-			MachineInfo: machineInfo,
+		Reply: &zsys.MachineShowResponse_Machine{
+			Machine: pm,
 		},
+		MachineInfo: machineInfo,
 	})
 
 	return nil
@@ -47,17 +58,253 @@ func (s *Server) MachineList(req *zsys.Empty, stream zsys.Zsys_MachineListServer
 
 	log.Infof(stream.Context(), i18n.G("Retrieving list of machines."))
 
-	machinesList, err := s.Machines.List()
+	pms := machinesToProto(*s.snapshot())
+	// Older clients only read the preformatted text.
+	machinesList, err := pms.Text()
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't fetch list of machines: %v"), err)
 	}
-
 	stream.Send(&zsys.MachineListResponse{
-		Reply: &zsys.MachineListResponse_MachineList{
-			MachineList: machinesList,
+		Reply: &zsys.MachineListResponse_Machines{
+			Machines: pms,
 		},
+		MachineList: machinesList,
+	})
+
+	return nil
+
+}
+
+// MachineClone creates a new machine from a system state, optionally with its user data.
+func (s *Server) MachineClone(req *zsys.MachineCloneRequest, stream zsys.Zsys_MachineCloneServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "MachineClone", map[string]string{"state": req.GetStateName(), "with-user-data": strconv.FormatBool(req.GetWithUserData())})
+	defer func() { done(err) }()
+
+	stateName, withUserData := req.GetStateName(), req.GetWithUserData()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourceUsers)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Infof(ctx, i18n.G("Requesting to clone state %q to a new machine"), stateName)
+
+	var id string
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		id, err = ms.CloneMachine(ctx, stateName, withUserData)
+		return err
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't clone state %q: ")+config.ErrorFormat, stateName, err)
+	}
+
+	stream.Send(&zsys.MachineCloneResponse{
+		Reply: &zsys.MachineCloneResponse_MachineId{MachineId: id},
 	})
 
 	return nil
+}
+
+// MachineRemove removes a non current machine with its history and the user datasets only attached to it.
+func (s *Server) MachineRemove(req *zsys.MachineRemoveRequest, stream zsys.Zsys_MachineRemoveServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "MachineRemove", map[string]string{"machine": req.GetMachineId(), "force": strconv.FormatBool(req.GetForce()), "dryrun": strconv.FormatBool(req.GetDryrun())})
+	defer func() { done(err) }()
+
+	machineID := req.GetMachineId()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourceUsers, resourceBootMenu)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if machineID == "" {
+		return fmt.Errorf(i18n.G("Machine ID is required"))
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to remove machine %q"), machineID)
+
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.RemoveMachine(ctx, machineID, req.GetForce(), req.GetDryrun())
+	}); err != nil {
+		var e *machines.ErrStateRemovalNeedsConfirmation
+		if errors.As(err, &e) {
+			return confirmationNeededErr(e)
+		}
+		return fmt.Errorf(i18n.G("couldn't remove machine %s: ")+config.ErrorFormat, machineID, err)
+	}
+
+	if req.GetDryrun() {
+		return nil
+	}
+	return s.updateBootMenu(ctx)
+}
+
+// machinesToProto converts all machines to their protobuf representation, current machine first.
+func machinesToProto(ms machines.Machines) *zsys.Machines {
+	current, _ := ms.GetMachine("")
+
+	var r zsys.Machines
+	for _, m := range ms.List() {
+		r.Machines = append(r.Machines, machineToProto(m, m == current))
+	}
+	return &r
+}
+
+// machineToProto converts a machine to its protobuf representation.
+// History and user history are sorted from the most recent state to the oldest one.
+func machineToProto(m *machines.Machine, isCurrent bool) *zsys.Machine {
+	r := zsys.Machine{
+		Id:                 m.ID,
+		IsZsys:             m.IsZsys,
+		IsCurrent:          isCurrent,
+		State:              stateToProto(&m.State),
+		PersistentDatasets: datasetsToProto(m.PersistentDatasets),
+		Bookmarks:          datasetsToProto(m.Bookmarks),
+	}
+	r.NextBoot, r.NextBootRevertUserData = m.ScheduledRevert()
+
+	for _, s := range m.History {
+		r.History = append(r.History, stateToProto(s))
+	}
+	sort.Slice(r.History, func(i, j int) bool {
+		return newerThan(r.History[i].GetLastUsed(), r.History[i].GetId(), r.History[j].GetLastUsed(), r.History[j].GetId())
+	})
+
+	for user, states := range m.AllUsersStates {
+	nextUserState:
+		for id, s := range states {
+			// exclude current user states from history
+			for _, us := range m.State.Users {
+				if us == s {
+					continue nextUserState
+				}
+			}
+			// We can’t use s.ID here because some user states can be duplicated (user state attached to 2 system states)
+			// and we want to expose the unique generated id, as it’s what should be used in RemoveState()
+			r.UserHistory = append(r.UserHistory, userStateToProto(user, id, s))
+		}
+	}
+	sort.Slice(r.UserHistory, func(i, j int) bool {
+		a, b := r.UserHistory[i], r.UserHistory[j]
+		if a.GetUser() != b.GetUser() {
+			return a.GetUser() < b.GetUser()
+		}
+		return newerThan(a.GetLastUsed(), a.GetId(), b.GetLastUsed(), b.GetId())
+	})
+
+	return &r
+}
+
+// stateToProto converts a system state and its attached user states to their protobuf representation.
+func stateToProto(s *machines.State) *zsys.State {
+	r := zsys.State{
+		Id:             s.ID,
+		LastUsed:       timeToProto(s.LastUsed),
+		SystemDatasets: datasetsToProto(sortedStateDatasets(s)),
+	}
+	if len(s.Datasets[s.ID]) > 0 {
+		r.LastBootedKernel = s.Datasets[s.ID][0].LastBootedKernel
+	}
+	r.Description, r.Label = s.Annotations()
+	r.Pinned = s.Pinned()
+	r.Used, r.Referenced, r.Written = s.Space()
+	r.Encrypted, r.Locked = s.Encryption()
 
+	var users []string
+	for u := range s.Users {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	for _, u := range users {
+		r.Users = append(r.Users, userStateToProto(u, s.Users[u].ID, s.Users[u]))
+	}
+
+	return &r
+}
+
+// userStateToProto converts a user state to its protobuf representation.
+func userStateToProto(user, id string, s *machines.State) *zsys.UserState {
+	r := zsys.UserState{
+		User:     user,
+		Id:       id,
+		LastUsed: timeToProto(s.LastUsed),
+		Datasets: datasetsToProto(sortedStateDatasets(s)),
+	}
+	r.Description, r.Label = s.Annotations()
+	r.Pinned = s.Pinned()
+	r.Used, r.Referenced, r.Written = s.Space()
+	r.Encrypted, r.Locked = s.Encryption()
+	return &r
+}
+
+// sortedStateDatasets returns all datasets of a state, sorted by route.
+func sortedStateDatasets(s *machines.State) (ds []*zfs.Dataset) {
+	var routes []string
+	for k := range s.Datasets {
+		routes = append(routes, k)
+	}
+	sort.Strings(routes)
+	for _, k := range routes {
+		ds = append(ds, s.Datasets[k]...)
+	}
+	return ds
+}
+
+// datasetsToProto converts datasets to their protobuf representation.
+func datasetsToProto(ds []*zfs.Dataset) (r []*zsys.Dataset) {
+	for _, d := range ds {
+		pd := zsys.Dataset{
+			Name:             d.Name,
+			IsSnapshot:       d.IsSnapshot,
+			IsBookmark:       d.IsBookmark,
+			Mountpoint:       d.Mountpoint,
+			CanMount:         d.CanMount,
+			Mounted:          d.Mounted,
+			BootFS:           d.BootFS,
+			LastBootedKernel: d.LastBootedKernel,
+			Origin:           d.Origin,
+			Used:             d.Used,
+			Referenced:       d.Referenced,
+			Written:          d.Written,
+			EncryptionRoot:   d.EncryptionRoot,
+			KeyStatus:        d.KeyStatus,
+			Excluded:         d.Excluded,
+			FollowSystem:     d.FollowSystem,
+		}
+		if d.LastUsed != 0 {
+			pd.LastUsed = timeToProto(time.Unix(int64(d.LastUsed), 0))
+		}
+		for _, b := range strings.Split(d.BootfsDatasets, ",") {
+			if b = strings.TrimSpace(b); b != "" {
+				pd.BootfsDatasets = append(pd.BootfsDatasets, b)
+			}
+		}
+		r = append(r, &pd)
+	}
+	return r
+}
+
+// timeToProto converts t to a protobuf timestamp, nil if t isn’t set.
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// newerThan returns if state a is more recent than state b. States with same timestamp are sorted by reverse id.
+func newerThan(aTime *timestamppb.Timestamp, aID string, bTime *timestamppb.Timestamp, bID string) bool {
+	if aTime.GetSeconds() != bTime.GetSeconds() {
+		return aTime.GetSeconds() > bTime.GetSeconds()
+	}
+	return aID > bID
 }
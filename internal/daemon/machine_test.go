@@ -0,0 +1,192 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/testutils"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMachinesToProto(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		def     string
+		cmdline string
+	}{
+		"Current machine first, with history and scheduled revert": {def: "machines.yaml", cmdline: "BOOT_IMAGE=vmlinuz-5.2.0-8-generic root=ZFS=rpool/ROOT/ubuntu_1234 ro quiet splash"},
+		"Other machine is current":                                 {def: "machines.yaml", cmdline: "BOOT_IMAGE=vmlinuz-5.2.0-8-generic root=ZFS=rpool/ROOT/ubuntu_5678 ro quiet splash"},
+		"No current machine":                                       {def: "machines.yaml", cmdline: "BOOT_IMAGE=vmlinuz-5.2.0-8-generic root=/dev/sda1 ro quiet splash"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatal("expected success but got an error scanning for machines", err)
+			}
+
+			got := machinesToProto(ms)
+
+			var want zsys.Machines
+			testutils.LoadFromGoldenFile(t, got, &want)
+			assert.True(t, proto.Equal(&want, got), "machines should match golden file.\nWant: %v\nGot:  %v", &want, got)
+		})
+	}
+}
+
+func TestMachineResponsesTextForOlderClients(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "machines.yaml"), testutils.WithLibZFS(libzfs))
+	defer fPools.Create(dir)()
+
+	ms, err := machines.New(context.Background(), "BOOT_IMAGE=vmlinuz-5.2.0-8-generic root=ZFS=rpool/ROOT/ubuntu_1234 ro quiet splash", machines.WithLibZFS(libzfs))
+	if err != nil {
+		t.Fatal("expected success but got an error scanning for machines", err)
+	}
+	pms := machinesToProto(ms)
+
+	list, err := pms.Text()
+	if err != nil {
+		t.Fatal("expected success but got an error formatting machine list", err)
+	}
+	assert.Contains(t, list, "rpool/ROOT/ubuntu_1234", "machine list should contain current machine")
+	info, err := pms.GetMachines()[0].Text(true)
+	if err != nil {
+		t.Fatal("expected success but got an error formatting machine information", err)
+	}
+	assert.Contains(t, info, "rpool/ROOT/ubuntu_1234", "machine information should contain machine id")
+
+	// Older clients decode field 2 as their text reply and ignore the structured one.
+	for want, resp := range map[string]proto.Message{
+		list: &zsys.MachineListResponse{Reply: &zsys.MachineListResponse_Machines{Machines: pms}, MachineList: list},
+		info: &zsys.MachineShowResponse{Reply: &zsys.MachineShowResponse_Machine{Machine: pms.GetMachines()[0]}, MachineInfo: info},
+	} {
+		b, err := proto.Marshal(resp)
+		if err != nil {
+			t.Fatal("couldn't marshal response:", err)
+		}
+		var got string
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			if n < 0 {
+				t.Fatal("couldn't parse response tag:", protowire.ParseError(n))
+			}
+			b = b[n:]
+			if num == 2 && typ == protowire.BytesType {
+				v, n := protowire.ConsumeBytes(b)
+				if n < 0 {
+					t.Fatal("couldn't parse text field:", protowire.ParseError(n))
+				}
+				got = string(v)
+			}
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				t.Fatal("couldn't parse response field:", protowire.ParseError(n))
+			}
+			b = b[n:]
+		}
+		assert.Equal(t, want, got, "text field should be sent in field 2")
+	}
+}
+
+func TestDatasetsToProto(t *testing.T) {
+	t.Parallel()
+
+	lastUsed := time.Date(2020, 1, 1, 11, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		datasets []*zfs.Dataset
+
+		want []*zsys.Dataset
+	}{
+		"Dataset properties": {
+			datasets: []*zfs.Dataset{{Name: "rpool/ROOT/ubuntu_1234", DatasetProp: zfs.DatasetProp{
+				Mountpoint: "/", CanMount: "on", Mounted: true, BootFS: true, LastBootedKernel: "vmlinuz-5.2.0-8-generic",
+				LastUsed: int(lastUsed.Unix()), Used: 4000, Referenced: 3000, Written: 1000,
+				EncryptionRoot: "rpool", KeyStatus: "available"}}},
+			want: []*zsys.Dataset{{Name: "rpool/ROOT/ubuntu_1234",
+				Mountpoint: "/", CanMount: "on", Mounted: true, BootFS: true, LastBootedKernel: "vmlinuz-5.2.0-8-generic",
+				LastUsed: timestamppb.New(lastUsed), Used: 4000, Referenced: 3000, Written: 1000,
+				EncryptionRoot: "rpool", KeyStatus: "available"}},
+		},
+		"Snapshot with origin": {
+			datasets: []*zfs.Dataset{{Name: "rpool/ROOT/ubuntu_1234@snap", IsSnapshot: true, DatasetProp: zfs.DatasetProp{Origin: "rpool/ROOT/ubuntu_5678@snap"}}},
+			want:     []*zsys.Dataset{{Name: "rpool/ROOT/ubuntu_1234@snap", IsSnapshot: true, Origin: "rpool/ROOT/ubuntu_5678@snap"}},
+		},
+		"Bootfs datasets are split and trimmed": {
+			datasets: []*zfs.Dataset{{Name: "rpool/USERDATA/user1_abcd", DatasetProp: zfs.DatasetProp{BootfsDatasets: "rpool/ROOT/ubuntu_1234, rpool/ROOT/ubuntu_5678,"}}},
+			want:     []*zsys.Dataset{{Name: "rpool/USERDATA/user1_abcd", BootfsDatasets: []string{"rpool/ROOT/ubuntu_1234", "rpool/ROOT/ubuntu_5678"}}},
+		},
+		"Flags of persistent datasets and bookmarks": {
+			datasets: []*zfs.Dataset{
+				{Name: "rpool/srv", DatasetProp: zfs.DatasetProp{Excluded: true, FollowSystem: true}},
+				{Name: "rpool/ROOT/ubuntu_1234#snap", IsBookmark: true}},
+			want: []*zsys.Dataset{
+				{Name: "rpool/srv", Excluded: true, FollowSystem: true},
+				{Name: "rpool/ROOT/ubuntu_1234#snap", IsBookmark: true}},
+		},
+		"Keep order of datasets": {
+			datasets: []*zfs.Dataset{{Name: "rpool/b"}, {Name: "rpool/a"}},
+			want:     []*zsys.Dataset{{Name: "rpool/b"}, {Name: "rpool/a"}},
+		},
+
+		"No datasets": {},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := datasetsToProto(tc.datasets)
+
+			assert.Len(t, got, len(tc.want), "number of datasets")
+			for i := range tc.want {
+				assert.True(t, proto.Equal(tc.want[i], got[i]), "dataset %d should match.\nWant: %v\nGot:  %v", i, tc.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestBootMenuAnnotations(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "machines.yaml"), testutils.WithLibZFS(libzfs))
+	defer fPools.Create(dir)()
+
+	ms, err := machines.New(context.Background(), "BOOT_IMAGE=vmlinuz-5.2.0-8-generic root=ZFS=rpool/ROOT/ubuntu_1234 ro quiet splash",
+		machines.WithLibZFS(libzfs))
+	if err != nil {
+		t.Fatal("expected success but got an error scanning for machines", err)
+	}
+
+	got := bootMenuAnnotations(&ms)
+
+	assert.Equal(t, map[string]string{"rpool/ROOT/ubuntu_1234@manual_snapshot": "Before upgrade [upgrade]"}, got, "only annotated system states are in the boot menu")
+}
@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics returns the daemon metrics in the Prometheus text format.
+func (s *Server) Metrics(req *zsys.Empty, stream zsys.Zsys_MetricsServer) error {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
+		return err
+	}
+
+	log.Info(stream.Context(), i18n.G("Retrieving daemon metrics"))
+
+	var b bytes.Buffer
+	if err := s.writeMetrics(&b); err != nil {
+		return fmt.Errorf(i18n.G("couldn't collect metrics: ")+config.ErrorFormat, err)
+	}
+
+	if err := stream.Send(&zsys.MetricsResponse{
+		Reply: &zsys.MetricsResponse_Metrics{Metrics: b.String()},
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't send metrics to client: %v"), err)
+	}
+
+	return nil
+}
+
+// writeMetrics writes the daemon metrics in the Prometheus text format to w.
+func (s *Server) writeMetrics(w io.Writer) error {
+	var pools []machines.PoolSpace
+	if err := s.read(context.Background(), func(ms *machines.Machines) (err error) {
+		pools, err = ms.PoolsSpace()
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return s.metrics.Write(w, s.snapshot(), pools, time.Now())
+}
+
+// writeMetricsTextfile writes the daemon metrics to the configured textfile collector directory, if any.
+// Failures are only logged as metrics are not part of the request.
+func (s *Server) writeMetricsTextfile(ctx context.Context) {
+	dir := s.snapshot().Config().Metrics.TextfileDirectory
+	if dir == "" {
+		return
+	}
+
+	if err := metrics.WriteTextfile(dir, s.writeMetrics); err != nil {
+		log.Warningf(ctx, i18n.G("Couldn't export metrics: %v"), err)
+	}
+}
+
+// saveMetrics persists recorded metrics so that the next daemon instance restores them.
+// Failures are only logged as metrics are not part of the request.
+func (s *Server) saveMetrics(ctx context.Context) {
+	if err := os.MkdirAll(filepath.Dir(s.metricsPath), 0700); err != nil {
+		log.Warningf(ctx, i18n.G("Couldn't save metrics: %v"), err)
+		return
+	}
+	if err := s.metrics.Save(s.metricsPath); err != nil {
+		log.Warningf(ctx, i18n.G("Couldn't save metrics: %v"), err)
+	}
+}
+
+// observeBootCommit records the result of a boot commit, then saves and exports metrics.
+func (s *Server) observeBootCommit(ctx context.Context, changed bool, err error) {
+	result := metrics.CommitUnchanged
+	if err != nil {
+		result = metrics.CommitFailed
+	} else if changed {
+		result = metrics.CommitChanged
+	}
+	s.metrics.ObserveBootCommit(time.Now(), result)
+	s.saveMetrics(ctx)
+	s.writeMetricsTextfile(ctx)
+}
+
+// observeRequest records the count and duration of each grpc request, by method and status code.
+func (s *Server) observeRequest(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	s.metrics.ObserveRequest(path.Base(info.FullMethod), status.Code(err).String(), time.Since(start))
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"runtime/trace"
+	"strconv"
 	"time"
 
 	"github.com/ubuntu/zsys"
@@ -14,6 +15,7 @@ import (
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
 )
 
 // DaemonStop stops zsys daemon
@@ -40,16 +42,22 @@ func (s *Server) DumpStates(req *zsys.Empty, stream zsys.Zsys_DumpStatesServer)
 		return err
 	}
 
-	s.RWRequest.RLock()
-	defer s.RWRequest.RUnlock()
-
 	log.Info(stream.Context(), i18n.G("Requesting service states dump"))
 
-	b, err := json.MarshalIndent(s.Machines, "", "   ")
+	ms := s.snapshot()
+	b, err := json.MarshalIndent(ms, "", "   ")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't convert internal state to json: %v"), err)
 	}
 
+	if err := stream.Send(&zsys.DumpStatesResponse{
+		Reply: &zsys.DumpStatesResponse_Machines{
+			Machines: machinesToProto(*ms),
+		},
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't dump machine state")+config.ErrorFormat, err)
+	}
+
 	if err := stream.Send(&zsys.DumpStatesResponse{
 		Reply: &zsys.DumpStatesResponse_States{
 			States: string(b),
@@ -81,7 +89,19 @@ func (s *Server) Refresh(req *zsys.Empty, stream zsys.Zsys_RefreshServer) error
 	}
 	log.Info(stream.Context(), i18n.G("Requesting a refresh"))
 
-	return s.Machines.Refresh(stream.Context())
+	unlock, err := s.lock(stream.Context(), resourceAll)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.update(stream.Context(), func(ms *machines.Machines) error {
+		return ms.Refresh(stream.Context())
+	}); err != nil {
+		return err
+	}
+	s.events.publish(&zsys.Event{Event: &zsys.Event_RefreshCompleted{RefreshCompleted: &zsys.Empty{}}})
+	return nil
 }
 
 type traceForwarder struct {
@@ -144,12 +164,11 @@ func (s *Server) Status(req *zsys.Empty, stream zsys.Zsys_StatusServer) error {
 			return
 		}
 		log.Info(stream.Context(), i18n.G("Requesting zsys daemon status"))
-		s.RWRequest.RLock()
-		defer s.RWRequest.RUnlock()
-
 		// TODO: replace with machines.List
-		_, err := s.Machines.EnsureBoot(stream.Context())
-		rErr <- err
+		rErr <- s.read(stream.Context(), func(ms *machines.Machines) error {
+			_, err := ms.EnsureBoot(stream.Context())
+			return err
+		})
 	}()
 
 	select {
@@ -167,18 +186,32 @@ func (s *Server) Reload(req *zsys.Empty, stream zsys.Zsys_ReloadServer) error {
 	}
 	log.Info(stream.Context(), i18n.G("Reloading daemon configuration"))
 
-	return s.Machines.Reload(stream.Context())
+	unlock, err := s.lock(stream.Context(), resourceAll)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.update(stream.Context(), func(ms *machines.Machines) error {
+		return ms.Reload(stream.Context())
+	})
 }
 
 // GC call machine garbage collection stops zsys daemon
-func (s *Server) GC(req *zsys.GCRequest, stream zsys.Zsys_GCServer) error {
+func (s *Server) GC(req *zsys.GCRequest, stream zsys.Zsys_GCServer) (err error) {
 	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
 		return err
 	}
-	log.Info(stream.Context(), i18n.G("Requesting zsys daemon to garbage collect"))
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	ctx, done := s.audit(stream.Context(), "GC", map[string]string{"all": strconv.FormatBool(req.GetAll()), "dryrun": strconv.FormatBool(req.GetDryrun())})
+	defer func() { done(err) }()
+	log.Info(ctx, i18n.G("Requesting zsys daemon to garbage collect"))
+
+	unlock, err := s.lock(ctx, resourceAll)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	return s.Machines.GC(stream.Context(), req.GetAll())
+	return s.gc(ctx, req.GetAll(), req.GetDryrun(), req.GetExplain())
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
@@ -24,43 +25,96 @@ func (s *Server) SaveSystemState(req *zsys.SaveSystemStateRequest, stream zsys.Z
 		return err
 	}
 
-	stateName := req.GetStateName()
+	ctx, done := s.audit(stream.Context(), "SaveSystemState", map[string]string{
+		"state":            req.GetStateName(),
+		"description":      req.GetDescription(),
+		"label":            req.GetLabel(),
+		"autosave":         strconv.FormatBool(req.GetAutosave()),
+		"update-boot-menu": strconv.FormatBool(req.GetUpdateBootMenu()),
+	})
+	defer func() { done(err) }()
+
+	stateName, err := s.saveSystemState(ctx, req.GetStateName(), req.GetDescription(), req.GetLabel(), req.GetAutosave(), req.GetUpdateBootMenu())
+	if err != nil || stateName == "" {
+		return err
+	}
+
+	stream.Send(&zsys.CreateSaveStateResponse{
+		Reply: &zsys.CreateSaveStateResponse_StateName{StateName: stateName},
+	})
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	return nil
+}
 
+// saveSystemState creates a snapshot of a system and all users datasets and returns its name.
+// It returns an empty name when an automatic save is requested on a non zsys system.
+func (s *Server) saveSystemState(ctx context.Context, stateName, description, label string, autosave, bootMenu bool) (string, error) {
 	// autosave triggered by apt or other system on non zsys system. Do nothing
-	if !s.Machines.CurrentIsZsys() && req.GetAutosave() {
-		return nil
+	if !s.snapshot().CurrentIsZsys() && autosave {
+		return "", nil
 	}
 
+	// Automatic saves can garbage collect any state to free up space
+	resources := []resource{resourceAll}
+	if !autosave {
+		resources = []resource{resourceSystem, resourceUsers, resourcePersistent}
+		if bootMenu {
+			resources = append(resources, resourceBootMenu)
+		}
+	}
+	unlock, err := s.lock(ctx, resources...)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
 	if stateName != "" {
-		log.Infof(stream.Context(), i18n.G("Requesting to save current system state %q"), stateName)
+		log.Infof(ctx, i18n.G("Requesting to save current system state %q"), stateName)
 	} else {
 		msg := i18n.G("Requesting to save current system state")
 		// Always print the message as it was automatically requested
-		if req.GetAutosave() {
-			log.RemotePrintln(stream.Context(), msg)
+		if autosave {
+			log.RemotePrintln(ctx, msg)
 		} else {
-			log.Info(stream.Context(), msg)
+			log.Info(ctx, msg)
 		}
 	}
 
-	if stateName, err = s.Machines.CreateSystemSnapshot(stream.Context(), stateName); err != nil {
-		return fmt.Errorf(i18n.G("couldn't save system state: ")+config.ErrorFormat, err)
+	var newStateName string
+	createSnapshot := func(ms *machines.Machines) (err error) {
+		newStateName, err = ms.CreateSystemSnapshot(ctx, stateName, description, label)
+		return err
 	}
 
-	if req.GetUpdateBootMenu() {
-		if err := updateBootMenu(stream.Context()); err != nil {
-			return err
+	err = s.update(ctx, createSnapshot)
+	// Automatic saves try to free up space on pools below the free space target before giving up,
+	// if reaching this target leaves enough space to save.
+	if e := lowSpaceEvent(err); e != nil {
+		s.events.publish(e)
+		if general := s.snapshot().Config().General; autosave && general.TargetFreePoolSpace > general.MinFreePoolSpace {
+			log.RemotePrintln(ctx, i18n.G("Not enough free space to save current system state, removing oldest automatic states"))
+			if err := s.gc(ctx, false, false, false); err != nil {
+				return "", fmt.Errorf(i18n.G("couldn't free up space to save system state: ")+config.ErrorFormat, err)
+			}
+			err = s.update(ctx, createSnapshot)
+			if e := lowSpaceEvent(err); e != nil {
+				s.events.publish(e)
+			}
 		}
 	}
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't save system state: ")+config.ErrorFormat, err)
+	}
+	stateName = newStateName
+	s.events.publish(stateSavedEvent(stateName, ""))
 
-	stream.Send(&zsys.CreateSaveStateResponse{
-		Reply: &zsys.CreateSaveStateResponse_StateName{StateName: stateName},
-	})
+	if bootMenu {
+		if err := s.updateBootMenu(ctx); err != nil {
+			return "", err
+		}
+	}
 
-	return nil
+	return stateName, nil
 }
 
 // SaveUserState creates a snapshot for the provided user.
@@ -75,19 +129,12 @@ func (s *Server) SaveUserState(req *zsys.SaveUserStateRequest, stream zsys.Zsys_
 		return err
 	}
 
-	stateName := req.GetStateName()
+	ctx, done := s.audit(stream.Context(), "SaveUserState", map[string]string{"user": userName, "state": req.GetStateName(), "description": req.GetDescription(), "label": req.GetLabel()})
+	defer func() { done(err) }()
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
-
-	if stateName != "" {
-		log.Infof(stream.Context(), i18n.G("Requesting to save state %q for user %q"), stateName, userName)
-	} else {
-		log.Infof(stream.Context(), i18n.G("Requesting to save state for user %q"), userName)
-	}
-
-	if stateName, err = s.Machines.CreateUserSnapshot(stream.Context(), userName, stateName); err != nil {
-		return fmt.Errorf(i18n.G("couldn't save state for user %q: ")+config.ErrorFormat, userName, err)
+	stateName, err := s.saveUserState(ctx, userName, req.GetStateName(), req.GetDescription(), req.GetLabel())
+	if err != nil {
+		return err
 	}
 
 	stream.Send(&zsys.CreateSaveStateResponse{
@@ -97,52 +144,79 @@ func (s *Server) SaveUserState(req *zsys.SaveUserStateRequest, stream zsys.Zsys_
 	return nil
 }
 
+// saveUserState creates a snapshot for userName and returns its name.
+func (s *Server) saveUserState(ctx context.Context, userName, stateName, description, label string) (_ string, err error) {
+	unlock, err := s.lock(ctx, userResource(userName))
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if stateName != "" {
+		log.Infof(ctx, i18n.G("Requesting to save state %q for user %q"), stateName, userName)
+	} else {
+		log.Infof(ctx, i18n.G("Requesting to save state for user %q"), userName)
+	}
+
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		stateName, err = ms.CreateUserSnapshot(ctx, userName, stateName, description, label)
+		return err
+	}); err != nil {
+		if e := lowSpaceEvent(err); e != nil {
+			s.events.publish(e)
+		}
+		return "", fmt.Errorf(i18n.G("couldn't save state for user %q: ")+config.ErrorFormat, userName, err)
+	}
+	s.events.publish(stateSavedEvent(stateName, userName))
+
+	return stateName, nil
+}
+
 // RemoveSystemState removes this and all depending states from system.
 func (s *Server) RemoveSystemState(req *zsys.RemoveSystemStateRequest, stream zsys.Zsys_RemoveSystemStateServer) (err error) {
 	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
 		return err
 	}
 
-	stateName := req.GetStateName()
+	ctx, done := s.audit(stream.Context(), "RemoveSystemState", map[string]string{"state": req.GetStateName(), "force": strconv.FormatBool(req.GetForce()), "dryrun": strconv.FormatBool(req.GetDryrun())})
+	defer func() { done(err) }()
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	return toConfirmationNeededErr(s.removeSystemState(ctx, req.GetStateName(), req.GetForce(), req.GetDryrun()))
+}
+
+// removeSystemState removes this and all depending states from system.
+func (s *Server) removeSystemState(ctx context.Context, stateName string, force, dryrun bool) error {
+	unlock, err := s.lock(ctx, resourceSystem, resourceUsers, resourcePersistent, resourceBootMenu)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	if stateName == "" {
 		return fmt.Errorf(i18n.G("System state name is required"))
 	}
 
-	log.Infof(stream.Context(), i18n.G("Requesting to remove system state %q"), stateName)
+	log.Infof(ctx, i18n.G("Requesting to remove system state %q"), stateName)
 
-	err = s.Machines.RemoveState(stream.Context(), stateName, "", req.GetForce(), req.GetDryrun())
-	if err != nil {
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.RemoveState(ctx, stateName, "", force, dryrun)
+	}); err != nil {
 		var e *machines.ErrStateRemovalNeedsConfirmation
 		if errors.As(err, &e) {
-			st := status.New(codes.FailedPrecondition, config.UserConfirmationNeeded)
-			stdetails, err := st.WithDetails(&errdetails.ErrorInfo{
-				Reason: config.UserConfirmationNeeded,
-				Domain: "",
-				Metadata: map[string]string{
-					"msg": e.Error(),
-				},
-			})
-			if err != nil {
-				return st.Err()
-			}
-
-			return stdetails.Err()
+			return e
 		}
 		return fmt.Errorf(i18n.G("couldn't remove system state %s: ")+config.ErrorFormat, stateName, err)
 	}
 
-	if req.GetDryrun() {
+	if dryrun {
 		return nil
 	}
-	return updateBootMenu(stream.Context())
+	s.events.publish(stateRemovedEvent(stateName, ""))
+	return s.updateBootMenu(ctx)
 }
 
 // RemoveUserState removes a user state
-func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Zsys_RemoveUserStateServer) error {
+func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Zsys_RemoveUserStateServer) (err error) {
 	userName := req.GetUserName()
 
 	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, userName),
@@ -150,37 +224,445 @@ func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Z
 		return err
 	}
 
-	stateName := req.GetStateName()
+	ctx, done := s.audit(stream.Context(), "RemoveUserState", map[string]string{
+		"user":   userName,
+		"state":  req.GetStateName(),
+		"force":  strconv.FormatBool(req.GetForce()),
+		"dryrun": strconv.FormatBool(req.GetDryrun()),
+	})
+	defer func() { done(err) }()
 
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	return toConfirmationNeededErr(s.removeUserState(ctx, userName, req.GetStateName(), req.GetForce(), req.GetDryrun()))
+}
+
+// removeUserState removes a user state of userName.
+func (s *Server) removeUserState(ctx context.Context, userName, stateName string, force, dryrun bool) error {
+	unlock, err := s.lock(ctx, userResource(userName))
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	if stateName == "" {
 		return fmt.Errorf(i18n.G("State name is required"))
 	}
 
-	log.Infof(stream.Context(), i18n.G("Requesting to remove user state %q for user %s"), stateName, userName)
+	log.Infof(ctx, i18n.G("Requesting to remove user state %q for user %s"), stateName, userName)
 
-	err := s.Machines.RemoveState(stream.Context(), stateName, userName, req.GetForce(), req.GetDryrun())
-	if err != nil {
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.RemoveState(ctx, stateName, userName, force, dryrun)
+	}); err != nil {
 		var e *machines.ErrStateRemovalNeedsConfirmation
 		if errors.As(err, &e) {
-			st := status.New(codes.FailedPrecondition, config.UserConfirmationNeeded)
-			stdetails, err := st.WithDetails(&errdetails.ErrorInfo{
-				Reason: config.UserConfirmationNeeded,
-				Domain: "",
-				Metadata: map[string]string{
-					"msg": e.Error(),
-				},
-			})
-			if err != nil {
-				return st.Err()
+			return e
+		}
+		return fmt.Errorf(i18n.G("couldn't remove user state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	if !dryrun {
+		s.events.publish(stateRemovedEvent(stateName, userName))
+	}
+	return nil
+}
+
+// toConfirmationNeededErr converts err to a grpc error the client can act on if it's a removal needing confirmation.
+// Any other error is returned as is.
+func toConfirmationNeededErr(err error) error {
+	var e *machines.ErrStateRemovalNeedsConfirmation
+	if errors.As(err, &e) {
+		return confirmationNeededErr(e)
+	}
+	return err
+}
+
+// confirmationNeededErr converts a removal needing confirmation to a grpc error that the client can act on.
+func confirmationNeededErr(e *machines.ErrStateRemovalNeedsConfirmation) error {
+	st := status.New(codes.FailedPrecondition, config.UserConfirmationNeeded)
+	stdetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: config.UserConfirmationNeeded,
+		Domain: "",
+		Metadata: map[string]string{
+			"msg": e.Error(),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return stdetails.Err()
+}
+
+// RevertSystemState schedules next boot on a given system state, optionally reverting user data.
+// The request is only applied once, on next boot.
+func (s *Server) RevertSystemState(req *zsys.RevertSystemStateRequest, stream zsys.Zsys_RevertSystemStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "RevertSystemState", map[string]string{"state": req.GetStateName(), "revert-user-data": strconv.FormatBool(req.GetRevertUserData())})
+	defer func() { done(err) }()
+
+	stateName := req.GetStateName()
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("System state name is required"))
+	}
+
+	unlock, err := s.lock(ctx, resourceSystem, resourceUsers, resourceBootMenu)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Infof(ctx, i18n.G("Requesting to revert to system state %q on next boot"), stateName)
+
+	var params string
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		if err := ms.RevertState(ctx, stateName, req.GetRevertUserData()); err != nil {
+			return err
+		}
+		m, err := ms.GetMachine("")
+		if err != nil {
+			return err
+		}
+		params = m.NextBootParameters()
+		return nil
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't revert to system state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	// The menu entry of the state must exist before asking the bootloader to boot on it.
+	if err := s.updateBootMenu(ctx); err != nil {
+		return err
+	}
+	return scheduleNextBoot(ctx, params)
+}
+
+// AnnotateSystemState changes the description and label of a given system state.
+// Only the annotations set in the request are changed.
+func (s *Server) AnnotateSystemState(req *zsys.AnnotateSystemStateRequest, stream zsys.Zsys_AnnotateSystemStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "AnnotateSystemState", map[string]string{"state": req.GetStateName(), "description": req.GetDescription(), "label": req.GetLabel()})
+	defer func() { done(err) }()
+
+	stateName := req.GetStateName()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourceBootMenu)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("System state name is required"))
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to annotate system state %q"), stateName)
+
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.AnnotateState(ctx, stateName, "", req.Description, req.Label)
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't annotate system state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	return s.updateBootMenu(ctx)
+}
+
+// AnnotateUserState changes the description and label of a given user state.
+// Only the annotations set in the request are changed.
+func (s *Server) AnnotateUserState(req *zsys.AnnotateUserStateRequest, stream zsys.Zsys_AnnotateUserStateServer) (err error) {
+	userName := req.GetUserName()
+
+	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, userName),
+		authorizer.ActionUserWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "AnnotateUserState", map[string]string{"user": userName, "state": req.GetStateName(), "description": req.GetDescription(), "label": req.GetLabel()})
+	defer func() { done(err) }()
+
+	stateName := req.GetStateName()
+
+	unlock, err := s.lock(ctx, userResource(userName))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to annotate user state %q for user %s"), stateName, userName)
+
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.AnnotateState(ctx, stateName, userName, req.Description, req.Label)
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't annotate user state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	return nil
+}
+
+// PinSystemState pins or unpins a given system state.
+// Pinned states are never removed by garbage collection.
+func (s *Server) PinSystemState(req *zsys.PinSystemStateRequest, stream zsys.Zsys_PinSystemStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "PinSystemState", map[string]string{"state": req.GetStateName(), "pin": strconv.FormatBool(req.GetPin())})
+	defer func() { done(err) }()
+
+	stateName := req.GetStateName()
+
+	unlock, err := s.lock(ctx, resourceSystem)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("System state name is required"))
+	}
+
+	if req.GetPin() {
+		log.Infof(ctx, i18n.G("Requesting to pin system state %q"), stateName)
+	} else {
+		log.Infof(ctx, i18n.G("Requesting to unpin system state %q"), stateName)
+	}
+
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.PinState(ctx, stateName, "", req.GetPin())
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't change pin on system state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	return nil
+}
+
+// PinUserState pins or unpins a given user state.
+// Pinned states are never removed by garbage collection.
+func (s *Server) PinUserState(req *zsys.PinUserStateRequest, stream zsys.Zsys_PinUserStateServer) (err error) {
+	userName := req.GetUserName()
+
+	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, userName),
+		authorizer.ActionUserWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "PinUserState", map[string]string{"user": userName, "state": req.GetStateName(), "pin": strconv.FormatBool(req.GetPin())})
+	defer func() { done(err) }()
+
+	stateName := req.GetStateName()
+
+	unlock, err := s.lock(ctx, userResource(userName))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+
+	if req.GetPin() {
+		log.Infof(ctx, i18n.G("Requesting to pin user state %q for user %s"), stateName, userName)
+	} else {
+		log.Infof(ctx, i18n.G("Requesting to unpin user state %q for user %s"), stateName, userName)
+	}
+
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.PinState(ctx, stateName, userName, req.GetPin())
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't change pin on user state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	return nil
+}
+
+// StateDiff streams changed paths between two system states, dataset per dataset.
+// from needs to be a snapshot state. If to is empty, the current system state is used.
+func (s *Server) StateDiff(req *zsys.StateDiffRequest, stream zsys.Zsys_StateDiffServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemList); err != nil {
+		return err
+	}
+
+	from, to := req.GetFrom(), req.GetTo()
+
+	if from == "" {
+		return fmt.Errorf(i18n.G("System state name to compare from is required"))
+	}
+
+	if to != "" {
+		log.Infof(stream.Context(), i18n.G("Requesting differences between system states %q and %q"), from, to)
+	} else {
+		log.Infof(stream.Context(), i18n.G("Requesting differences between system state %q and current system state"), from)
+	}
+
+	var diffs []machines.DatasetDiff
+	if err := s.read(stream.Context(), func(ms *machines.Machines) (err error) {
+		diffs, err = ms.StateDiff(stream.Context(), from, to, req.GetUserData())
+		return err
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't compare system states: ")+config.ErrorFormat, err)
+	}
+
+	for _, d := range diffs {
+		for _, c := range d.Changes {
+			if err := stream.Send(&zsys.StateDiffResponse{
+				Reply: &zsys.StateDiffResponse_Change{Change: &zsys.FileChange{
+					FromDataset: d.From,
+					ToDataset:   d.To,
+					Change:      string(c.Change),
+					Path:        c.Path,
+					NewPath:     c.NewPath,
+				}},
+			}); err != nil {
+				return fmt.Errorf(i18n.G("couldn't send state differences to client: %v"), err)
 			}
+		}
+	}
+
+	return nil
+}
+
+// exportChunkSize is the maximum size of each stream message sent to the client, below grpc message size limit.
+const exportChunkSize = 1 << 20
 
-			return stdetails.Err()
+// exportForwarder sends exported state data to the client.
+type exportForwarder struct {
+	zsys.Zsys_ExportSystemStateServer
+}
+
+func (e exportForwarder) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > exportChunkSize {
+			chunk = chunk[:exportChunkSize]
 		}
-		return fmt.Errorf(i18n.G("couldn't remove user state %s: ")+config.ErrorFormat, stateName, err)
+		if err := e.Send(&zsys.ExportSystemStateResponse{
+			Reply: &zsys.ExportSystemStateResponse_Stream{Stream: chunk},
+		}); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// ExportSystemState streams to the client every dataset of a system state, optionally with its user datasets.
+func (s *Server) ExportSystemState(req *zsys.ExportSystemStateRequest, stream zsys.Zsys_ExportSystemStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	stateName, from := req.GetStateName(), req.GetIncrementalFrom()
+
+	if stateName == "" {
+		return errors.New(i18n.G("System state name to export is required"))
+	}
+
+	if from != "" {
+		log.Infof(stream.Context(), i18n.G("Requesting to export system state %q incrementally from %q"), stateName, from)
+	} else {
+		log.Infof(stream.Context(), i18n.G("Requesting to export system state %q"), stateName)
+	}
+
+	if err := s.read(stream.Context(), func(ms *machines.Machines) error {
+		return ms.ExportState(stream.Context(), stateName, from, req.GetUserData(), exportForwarder{stream})
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't export system state: ")+config.ErrorFormat, err)
+	}
+
+	return nil
+}
+
+// importReceiver reads the export streamed by the client in import requests.
+type importReceiver struct {
+	zsys.Zsys_ImportSystemStateServer
+	buf []byte
+}
+
+func (r *importReceiver) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.GetStream()
 	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// ImportSystemState imports an exported system state streamed by the client.
+// The first request sets the pool: if not empty, system and user datasets are imported in this pool.
+func (s *Server) ImportSystemState(stream zsys.Zsys_ImportSystemStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't receive import request: %v"), err)
+	}
+	pool := req.GetPool()
+
+	ctx, done := s.audit(stream.Context(), "ImportSystemState", map[string]string{"pool": pool})
+	defer func() { done(err) }()
 
+	unlock, err := s.lock(ctx, resourceAll)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Info(ctx, i18n.G("Requesting to import system state"))
+
+	r := &importReceiver{Zsys_ImportSystemStateServer: stream, buf: req.GetStream()}
+	var stateName string
+	if err := s.update(ctx, func(ms *machines.Machines) (err error) {
+		stateName, err = ms.ImportState(ctx, r, pool)
+		return err
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't import system state: ")+config.ErrorFormat, err)
+	}
+	s.events.publish(stateSavedEvent(stateName, ""))
+
+	stream.Send(&zsys.CreateSaveStateResponse{
+		Reply: &zsys.CreateSaveStateResponse_StateName{StateName: stateName},
+	})
+
+	return nil
+}
+
+// ReplicateStates copies new system and user states of the current machine to the configured target pool.
+func (s *Server) ReplicateStates(req *zsys.Empty, stream zsys.Zsys_ReplicateStatesServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	ctx, done := s.audit(stream.Context(), "ReplicateStates", nil)
+	defer func() { done(err) }()
+
+	unlock, err := s.lock(ctx, resourceSystem, resourceUsers)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	log.Info(ctx, i18n.G("Requesting to replicate states"))
+
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.Replicate(ctx)
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't replicate states: ")+config.ErrorFormat, err)
+	}
 	return nil
 }
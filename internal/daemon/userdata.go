@@ -2,12 +2,14 @@ package daemon
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
 )
 
 // CreateUserData creates a new userdata for user and set it to homepath on current zsys system.
@@ -18,16 +20,36 @@ func (s *Server) CreateUserData(req *zsys.CreateUserDataRequest, stream zsys.Zsy
 		return err
 	}
 
+	ctx, done := s.audit(stream.Context(), "CreateUserData", map[string]string{
+		"user":      req.GetUser(),
+		"home":      req.GetHomepath(),
+		"container": req.GetContainer(),
+		// Never record the passphrase
+		"encrypted": strconv.FormatBool(req.GetPassphrase() != "" || req.GetKeyFile() != ""),
+	})
+	defer func() { done(err) }()
+
 	user := req.GetUser()
 	homepath := req.GetHomepath()
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	unlock, err := s.lock(ctx, userResource(user))
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	log.Infof(stream.Context(), i18n.G("Create user dataset for %q on %q"), user, homepath)
+	log.Infof(ctx, i18n.G("Create user dataset for %q on %q"), user, homepath)
 
-	if err := s.Machines.CreateUserData(stream.Context(), user, homepath); err != nil {
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.CreateUserData(ctx, user, homepath,
+			machines.WithPassphrase(req.GetPassphrase()), machines.WithKeyFile(req.GetKeyFile()),
+			machines.WithUserDataContainer(req.GetContainer()), machines.WithUserDataProperties(req.GetProperties()))
+	}); err != nil {
 		return fmt.Errorf(i18n.G("couldn't create userdataset for %q: ")+config.ErrorFormat, homepath, err)
 	}
+	s.events.publish(&zsys.Event{Event: &zsys.Event_UserDataCreated{UserDataCreated: &zsys.UserDataEvent{
+		User: user,
+		Home: homepath,
+	}}})
 	return nil
 }
 
@@ -37,14 +59,23 @@ func (s *Server) ChangeHomeOnUserData(req *zsys.ChangeHomeOnUserDataRequest, str
 		return err
 	}
 
+	ctx, done := s.audit(stream.Context(), "ChangeHomeOnUserData", map[string]string{"home": req.GetHome(), "new-home": req.GetNewHome()})
+	defer func() { done(err) }()
+
 	home := req.GetHome()
 	newHome := req.GetNewHome()
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	// The user owning home isn't known before looking at its datasets
+	unlock, err := s.lock(ctx, resourceUsers)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	log.Infof(stream.Context(), i18n.G("Rename home user dataset from %q to %q"), home, newHome)
+	log.Infof(ctx, i18n.G("Rename home user dataset from %q to %q"), home, newHome)
 
-	if err := s.Machines.ChangeHomeOnUserData(stream.Context(), home, newHome); err != nil {
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.ChangeHomeOnUserData(ctx, home, newHome)
+	}); err != nil {
 		return fmt.Errorf(i18n.G("couldn't change home userdataset for %q: ")+config.ErrorFormat, home, err)
 	}
 	return nil
@@ -57,15 +88,26 @@ func (s *Server) DissociateUser(req *zsys.DissociateUserRequest, stream zsys.Zsy
 		return err
 	}
 
+	ctx, done := s.audit(stream.Context(), "DissociateUser", map[string]string{"user": req.GetUser(), "remove-home": strconv.FormatBool(req.GetRemoveHome())})
+	defer func() { done(err) }()
+
 	user := req.GetUser()
 	removeHome := req.GetRemoveHome()
-	s.RWRequest.Lock()
-	defer s.RWRequest.Unlock()
+	unlock, err := s.lock(ctx, userResource(user))
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	log.Infof(stream.Context(), i18n.G("Dissociate user %q"), user)
+	log.Infof(ctx, i18n.G("Dissociate user %q"), user)
 
-	if err := s.Machines.DissociateUser(stream.Context(), user, removeHome); err != nil {
+	if err := s.update(ctx, func(ms *machines.Machines) error {
+		return ms.DissociateUser(ctx, user, removeHome)
+	}); err != nil {
 		return fmt.Errorf(i18n.G("couldn't dissociate user %q: ")+config.ErrorFormat, user, err)
 	}
+	s.events.publish(&zsys.Event{Event: &zsys.Event_UserDataDissociated{UserDataDissociated: &zsys.UserDataEvent{
+		User: user,
+	}}})
 	return nil
 }
@@ -0,0 +1,320 @@
+// Package grub schedules one-shot boots through the GRUB menu and environment block, and annotates the history
+// entries of the GRUB menu.
+package grub
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+)
+
+const (
+	// nextEntryVar is the environment variable GRUB boots on once, then resets.
+	nextEntryVar = "next_entry"
+
+	envHeader    = "# GRUB Environment Block\n"
+	envBlockSize = 1024
+
+	// historyIDPrefix prefixes the id of the submenu of each state in the history of a machine.
+	historyIDPrefix = "gnulinux-history-"
+)
+
+// ScheduleNextBoot sets in the environment block envPath the menu entry of cfgPath booting with params as the
+// entry to boot on once.
+func ScheduleNextBoot(cfgPath, envPath, params string) error {
+	f, err := os.Open(cfgPath)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't read boot menu: %v"), err)
+	}
+	defer f.Close()
+
+	id, err := EntryID(f, params)
+	if err != nil {
+		return err
+	}
+	return SetEnv(envPath, nextEntryVar, id)
+}
+
+// ResetNextBoot removes any entry to boot on once from the environment block envPath.
+func ResetNextBoot(envPath string) error {
+	return SetEnv(envPath, nextEntryVar, "")
+}
+
+// EntryID returns the identifier of the first menu entry of cfg whose kernel command line contains all params.
+// Entries in submenus are identified by their submenu path, separated by ">".
+func EntryID(cfg io.Reader, params string) (string, error) {
+	want := strings.Fields(params)
+	if len(want) == 0 {
+		return "", errors.New(i18n.G("no boot parameters to look for"))
+	}
+
+	// blocks are the enclosing blocks of the current line. Only submenus and menu entries have an id.
+	type block struct {
+		id    string
+		entry bool
+	}
+	var blocks []block
+
+	s := bufio.NewScanner(cfg)
+	for s.Scan() {
+		words := splitWords(s.Text())
+		if len(words) == 0 {
+			continue
+		}
+
+		switch {
+		case (words[0] == "submenu" || words[0] == "menuentry") && len(words) > 2:
+			blocks = append(blocks, block{id: entryID(words), entry: words[0] == "menuentry"})
+		case words[len(words)-1] == "{":
+			blocks = append(blocks, block{})
+		case words[0] == "}":
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+		case words[0] == "linux" || words[0] == "linux16" || words[0] == "linuxefi":
+			var path []string
+			var inEntry bool
+			for _, b := range blocks {
+				if b.id != "" {
+					path = append(path, b.id)
+				}
+				inEntry = inEntry || b.entry
+			}
+			if inEntry && hasParams(words[1:], want) {
+				return strings.Join(path, ">"), nil
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't read boot menu: %v"), err)
+	}
+
+	return "", fmt.Errorf(i18n.G("no boot menu entry with %q"), params)
+}
+
+// AnnotateHistory appends to the title of the history submenu of each state in the boot menu cfgPath its annotation
+// in annotations, keyed by state ID. The boot menu is replaced atomically.
+func AnnotateHistory(cfgPath string, annotations map[string]string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf(i18n.G("couldn't annotate boot menu: %v"), err)
+		}
+	}()
+
+	in, err := os.Open(cfgPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.CreateTemp(filepath.Dir(cfgPath), "."+filepath.Base(cfgPath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	if err := WriteAnnotatedHistory(in, out, annotations); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Chmod(fi.Mode().Perm()); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), cfgPath)
+}
+
+// WriteAnnotatedHistory copies the boot menu cfg to w, appending to the title of the history submenu of each state
+// its annotation in annotations, keyed by state ID.
+func WriteAnnotatedHistory(cfg io.Reader, w io.Writer, annotations map[string]string) error {
+	s := bufio.NewScanner(cfg)
+	for s.Scan() {
+		line := s.Text()
+		if words := splitWords(line); len(words) > 2 && words[0] == "submenu" {
+			id := entryID(words)
+			if a := annotations[strings.TrimPrefix(id, historyIDPrefix)]; a != "" && strings.HasPrefix(id, historyIDPrefix) {
+				start, end := titleSpan(line)
+				line = line[:start] + quote(words[1]+" - "+a) + line[end:]
+			}
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// titleSpan returns the start and end offsets in line of the title of a menuentry or submenu, its second word.
+func titleSpan(line string) (start, end int) {
+	start = skipBlanks(line, wordEnd(line, skipBlanks(line, 0)))
+	return start, wordEnd(line, start)
+}
+
+// skipBlanks returns the offset of the first non blank character of line from i.
+func skipBlanks(line string, i int) int {
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// wordEnd returns the offset after the end of the word of line starting at i, handling quotes and escapes.
+func wordEnd(line string, i int) int {
+	var singleQuoted, doubleQuoted bool
+	for ; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case singleQuoted:
+			singleQuoted = c != '\''
+		case c == '\\':
+			i++
+		case doubleQuoted:
+			doubleQuoted = c != '"'
+		case c == '\'':
+			singleQuoted = true
+		case c == '"':
+			doubleQuoted = true
+		case c == ' ' || c == '\t':
+			return i
+		}
+	}
+	return len(line)
+}
+
+// quote returns s as a single quoted GRUB word.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// entryID returns the id of a menuentry or submenu, falling back to its title.
+func entryID(words []string) string {
+	for i, w := range words[:len(words)-1] {
+		if w == "$menuentry_id_option" || w == "${menuentry_id_option}" || w == "--id" {
+			return words[i+1]
+		}
+	}
+	return words[1]
+}
+
+// hasParams returns if args contains every parameter in want and no other zsys one, so that a system only revert
+// doesn't select an entry reverting user data too.
+func hasParams(args, want []string) bool {
+	wanted := make(map[string]bool)
+	for _, w := range want {
+		wanted[w] = true
+	}
+	found := make(map[string]bool)
+	for _, a := range args {
+		if strings.HasPrefix(a, "zsys-") && !wanted[a] {
+			return false
+		}
+		found[a] = true
+	}
+	for _, w := range want {
+		if !found[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitWords splits a GRUB script line in words, handling quotes and escapes.
+func splitWords(line string) []string {
+	var words []string
+	var w strings.Builder
+	var inWord, singleQuoted, doubleQuoted, escaped bool
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			w.WriteRune(c)
+			escaped = false
+		case singleQuoted:
+			if c == '\'' {
+				singleQuoted = false
+				continue
+			}
+			w.WriteRune(c)
+		case c == '\\':
+			escaped, inWord = true, true
+		case doubleQuoted:
+			if c == '"' {
+				doubleQuoted = false
+				continue
+			}
+			w.WriteRune(c)
+		case c == '\'':
+			singleQuoted, inWord = true, true
+		case c == '"':
+			doubleQuoted, inWord = true, true
+		case c == '#' && !inWord:
+			return words
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, w.String())
+				w.Reset()
+				inWord = false
+			}
+		default:
+			w.WriteRune(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, w.String())
+	}
+
+	return words
+}
+
+// SetEnv sets key to value in the GRUB environment block at path, creating it if needed.
+// An empty value removes the key.
+func SetEnv(path, key, value string) error {
+	if strings.ContainsAny(key+value, "\n\\") || strings.Contains(key, "=") {
+		return fmt.Errorf(i18n.G("invalid boot environment variable %s=%q"), key, value)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf(i18n.G("couldn't read boot environment: %v"), err)
+	}
+	if err == nil && !bytes.HasPrefix(content, []byte(envHeader)) {
+		return fmt.Errorf(i18n.G("%q isn't a GRUB environment block"), path)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(envHeader)
+	for _, l := range strings.Split(strings.TrimPrefix(string(content), envHeader), "\n") {
+		if l == "" || strings.HasPrefix(l, "#") || strings.HasPrefix(l, key+"=") {
+			continue
+		}
+		b.WriteString(l + "\n")
+	}
+	if value != "" {
+		b.WriteString(key + "=" + value + "\n")
+	}
+	if b.Len() > envBlockSize {
+		return fmt.Errorf(i18n.G("boot environment is full, can't set %s"), key)
+	}
+	b.Write(bytes.Repeat([]byte("#"), envBlockSize-b.Len()))
+
+	// GRUB writes the block in place: keep the same file.
+	if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write boot environment: %v"), err)
+	}
+	return nil
+}
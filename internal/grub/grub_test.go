@@ -0,0 +1,268 @@
+package grub_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/grub"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+func TestEntryID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		params string
+
+		want    string
+		wantErr bool
+	}{
+		"Current state": {params: "root=ZFS=rpool/ROOT/ubuntu_1234", want: "gnulinux-rpool/ROOT/ubuntu_1234-5.4.0-26-generic"},
+		"Snapshot state, system only": {params: "root=ZFS=rpool/ROOT/ubuntu_1234@autozsys_k2mfbe",
+			want: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe>gnulinux-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe-5.4.0-26-generic"},
+		"Snapshot state with user data": {params: "root=ZFS=rpool/ROOT/ubuntu_1234@autozsys_k2mfbe zsys-revert=userdata",
+			want: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe>gnulinux-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe-5.4.0-26-generic-userdata"},
+		"System only doesn't select an entry reverting user data": {params: "root=ZFS=rpool/ROOT/ubuntu_1234_clone",
+			want: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234_clone>gnulinux-rpool/ROOT/ubuntu_1234_clone-5.4.0-21-generic"},
+		"Entry without id uses its title": {params: "root=ZFS=rpool/ROOT/ubuntu_5678", want: "Ubuntu 18.04, untitled"},
+
+		"Error on no matching entry":     {params: "root=ZFS=rpool/ROOT/ubuntu_1234@doesntexist", wantErr: true},
+		"Error on prefix of a root only": {params: "root=ZFS=rpool/ROOT/ubuntu", wantErr: true},
+		"Error on no parameters":         {params: "", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filepath.Join("testdata", "grub.cfg"))
+			if err != nil {
+				t.Fatalf("Setup: couldn't open grub.cfg: %v", err)
+			}
+			defer f.Close()
+
+			got, err := grub.EntryID(f, tc.params)
+			if tc.wantErr {
+				assert.Error(t, err, "EntryID should fail")
+				return
+			}
+			assert.NoError(t, err, "EntryID should succeed")
+			assert.Equal(t, tc.want, got, "menu entry id")
+		})
+	}
+}
+
+func TestSetEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		env        string
+		noEnv      bool
+		key, value string
+
+		wantVars []string
+		wantErr  bool
+	}{
+		"Create environment block": {noEnv: true, key: "next_entry", value: "foo", wantVars: []string{"next_entry=foo"}},
+		"Add to existing variables": {env: "saved_entry=0\nrecordfail=1\n", key: "next_entry", value: "foo",
+			wantVars: []string{"saved_entry=0", "recordfail=1", "next_entry=foo"}},
+		"Replace existing value": {env: "next_entry=bar\nsaved_entry=0\n", key: "next_entry", value: "foo",
+			wantVars: []string{"saved_entry=0", "next_entry=foo"}},
+		"Remove variable": {env: "next_entry=bar\nsaved_entry=0\n", key: "next_entry",
+			wantVars: []string{"saved_entry=0"}},
+		"Remove missing variable": {env: "saved_entry=0\n", key: "next_entry", wantVars: []string{"saved_entry=0"}},
+
+		"Error on invalid environment block": {env: "not an environment block", key: "next_entry", value: "foo", wantErr: true},
+		"Error on value with new line":       {noEnv: true, key: "next_entry", value: "foo\nbar", wantErr: true},
+		"Error on full environment block":    {noEnv: true, key: "next_entry", value: strings.Repeat("a", 1024), wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			path := filepath.Join(dir, "grubenv")
+			if !tc.noEnv {
+				env := tc.env
+				if env != "not an environment block" {
+					env = "# GRUB Environment Block\n" + env
+					env += strings.Repeat("#", 1024-len(env))
+				}
+				if err := os.WriteFile(path, []byte(env), 0644); err != nil {
+					t.Fatalf("Setup: couldn't write environment block: %v", err)
+				}
+			}
+
+			err := grub.SetEnv(path, tc.key, tc.value)
+			if tc.wantErr {
+				assert.Error(t, err, "SetEnv should fail")
+				return
+			}
+			assert.NoError(t, err, "SetEnv should succeed")
+
+			assert.Equal(t, tc.wantVars, readEnv(t, path), "environment variables")
+		})
+	}
+}
+
+func TestScheduleNextBoot(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		params string
+		noCfg  bool
+
+		wantNextEntry string
+		wantErr       bool
+	}{
+		"Schedule history entry": {params: "root=ZFS=rpool/ROOT/ubuntu_1234@autozsys_k2mfbe zsys-revert=userdata",
+			wantNextEntry: "next_entry=gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe>gnulinux-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe-5.4.0-26-generic-userdata"},
+
+		"Error on missing menu entry": {params: "root=ZFS=rpool/ROOT/ubuntu_1234@doesntexist", wantErr: true},
+		"Error on missing boot menu":  {params: "root=ZFS=rpool/ROOT/ubuntu_1234", noCfg: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			cfg := filepath.Join("testdata", "grub.cfg")
+			if tc.noCfg {
+				cfg = filepath.Join(dir, "grub.cfg")
+			}
+			env := filepath.Join(dir, "grubenv")
+
+			err := grub.ScheduleNextBoot(cfg, env, tc.params)
+			if tc.wantErr {
+				assert.Error(t, err, "ScheduleNextBoot should fail")
+				_, err := os.Stat(env)
+				assert.True(t, os.IsNotExist(err), "environment block is not written on failure")
+				return
+			}
+			assert.NoError(t, err, "ScheduleNextBoot should succeed")
+			assert.Equal(t, []string{tc.wantNextEntry}, readEnv(t, env), "next entry is scheduled")
+
+			// Reset removes the one-shot entry
+			if err := grub.ResetNextBoot(env); err != nil {
+				t.Fatalf("ResetNextBoot should succeed but got: %v", err)
+			}
+			assert.Empty(t, readEnv(t, env), "next entry is reset")
+		})
+	}
+}
+
+// readEnv returns the variables set in the environment block at path, checking its format.
+func readEnv(t *testing.T, path string) []string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("couldn't read environment block: %v", err)
+	}
+	assert.Len(t, b, 1024, "environment block has a fixed size")
+
+	content := strings.TrimPrefix(string(b), "# GRUB Environment Block\n")
+	assert.NotEqual(t, string(b), content, "environment block has a header")
+
+	var vars []string
+	for _, l := range strings.Split(content, "\n") {
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		vars = append(vars, l)
+	}
+	return vars
+}
+
+func TestAnnotateHistory(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		annotations map[string]string
+		noCfg       bool
+
+		wantTitles []string
+		wantErr    bool
+	}{
+		"Annotate snapshot state": {annotations: map[string]string{"rpool/ROOT/ubuntu_1234@autozsys_k2mfbe": "before nvidia driver [pre-upgrade]"},
+			wantTitles: []string{"Revert to 05/04/2020 @ 13:51 - before nvidia driver [pre-upgrade]", "Revert to 01/04/2020 @ 09:12"}},
+		"Annotate clone state": {annotations: map[string]string{"rpool/ROOT/ubuntu_1234_clone": "pre-upgrade"},
+			wantTitles: []string{"Revert to 05/04/2020 @ 13:51", "Revert to 01/04/2020 @ 09:12 - pre-upgrade"}},
+		"Annotation is quoted": {annotations: map[string]string{"rpool/ROOT/ubuntu_1234@autozsys_k2mfbe": `it's "$1" \o/`},
+			wantTitles: []string{`Revert to 05/04/2020 @ 13:51 - it's "$1" \o/`, "Revert to 01/04/2020 @ 09:12"}},
+		"Unknown states are ignored": {annotations: map[string]string{"rpool/ROOT/ubuntu_1234@doesntexist": "foo"},
+			wantTitles: []string{"Revert to 05/04/2020 @ 13:51", "Revert to 01/04/2020 @ 09:12"}},
+		"No annotations": {wantTitles: []string{"Revert to 05/04/2020 @ 13:51", "Revert to 01/04/2020 @ 09:12"}},
+
+		"Error on missing boot menu": {noCfg: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			cfg := filepath.Join(dir, "grub.cfg")
+			orig, err := os.ReadFile(filepath.Join("testdata", "grub.cfg"))
+			if err != nil {
+				t.Fatalf("Setup: couldn't read grub.cfg: %v", err)
+			}
+			if !tc.noCfg {
+				if err := os.WriteFile(cfg, orig, 0600); err != nil {
+					t.Fatalf("Setup: couldn't write grub.cfg: %v", err)
+				}
+			}
+
+			err = grub.AnnotateHistory(cfg, tc.annotations)
+			if tc.wantErr {
+				assert.Error(t, err, "AnnotateHistory should fail")
+				return
+			}
+			assert.NoError(t, err, "AnnotateHistory should succeed")
+
+			got, err := os.ReadFile(cfg)
+			if err != nil {
+				t.Fatalf("couldn't read annotated grub.cfg: %v", err)
+			}
+			var titles []string
+			for _, l := range strings.Split(string(got), "\n") {
+				if strings.Contains(l, "'gnulinux-history-rpool/ROOT/ubuntu_1234") && strings.Contains(l, "Revert to") {
+					titles = append(titles, l)
+				}
+			}
+			if assert.Len(t, titles, len(tc.wantTitles), "history submenus") {
+				for i, want := range tc.wantTitles {
+					assert.Contains(t, titles[i], "submenu '"+strings.ReplaceAll(want, "'", `'\''`)+"' ", "history submenu title")
+				}
+			}
+			assert.Equal(t, strings.Count(string(orig), "\n"), strings.Count(string(got), "\n"), "only titles are changed")
+			fi, err := os.Stat(cfg)
+			if err != nil {
+				t.Fatalf("couldn't stat annotated grub.cfg: %v", err)
+			}
+			assert.Equal(t, os.FileMode(0600), fi.Mode().Perm(), "boot menu permissions are kept")
+
+			// Annotated entries are still found
+			f, err := os.Open(cfg)
+			if err != nil {
+				t.Fatalf("couldn't open annotated grub.cfg: %v", err)
+			}
+			defer f.Close()
+			id, err := grub.EntryID(f, "root=ZFS=rpool/ROOT/ubuntu_1234@autozsys_k2mfbe")
+			assert.NoError(t, err, "EntryID should succeed on annotated boot menu")
+			assert.Equal(t, "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe>gnulinux-rpool/ROOT/ubuntu_1234@autozsys_k2mfbe-5.4.0-26-generic", id, "menu entry id")
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Package journal records write requests handled by the daemon in an append only log, rotated by size.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+)
+
+const (
+	fileName = "journal.log"
+
+	defaultMaxSize  = 1 << 20
+	defaultMaxFiles = 5
+
+	// maxEntrySize is the maximum size of a line read from the journal.
+	maxEntrySize = 16 << 20
+)
+
+// Entry is a write request recorded in the journal.
+type Entry struct {
+	// Time is when the request was received.
+	Time time.Time
+	// RequestID is the log id of the request.
+	RequestID string `json:",omitempty"`
+	// UID and PID identify the process which sent the request.
+	UID uint32
+	PID int32 `json:",omitempty"`
+	// Operation is the name of the request.
+	Operation string
+	// Parameters are the request arguments.
+	Parameters map[string]string `json:",omitempty"`
+	// Error is the reason of the failure, empty if the request succeeded.
+	Error string `json:",omitempty"`
+	// Datasets are the datasets created, removed or changed by the request.
+	Datasets []string `json:",omitempty"`
+}
+
+// Journal is an append only log of entries, stored as one json object per line in a directory.
+// Once the current file grows over a maximum size, it is rotated and only a given number of rotated files are kept.
+type Journal struct {
+	mu       sync.Mutex
+	dir      string
+	maxSize  int64
+	maxFiles int
+}
+
+// WithMaxSize changes the size in bytes after which the journal file is rotated.
+func WithMaxSize(size int64) func(o *options) error {
+	return func(o *options) error {
+		if size <= 0 {
+			return fmt.Errorf(i18n.G("journal maximum size needs to be positive, got %d"), size)
+		}
+		o.maxSize = size
+		return nil
+	}
+}
+
+// WithMaxFiles changes the number of rotated files kept in addition to the current one.
+func WithMaxFiles(n int) func(o *options) error {
+	return func(o *options) error {
+		if n < 0 {
+			return fmt.Errorf(i18n.G("number of rotated journal files can't be negative, got %d"), n)
+		}
+		o.maxFiles = n
+		return nil
+	}
+}
+
+type options struct {
+	maxSize  int64
+	maxFiles int
+}
+
+type option func(*options) error
+
+// New returns a journal stored in dir. The directory is only created on first write.
+func New(dir string, opts ...option) (*Journal, error) {
+	args := options{
+		maxSize:  defaultMaxSize,
+		maxFiles: defaultMaxFiles,
+	}
+	for _, o := range opts {
+		if err := o(&args); err != nil {
+			return nil, fmt.Errorf(i18n.G("couldn't apply option to journal: %v"), err)
+		}
+	}
+
+	return &Journal{
+		dir:      dir,
+		maxSize:  args.maxSize,
+		maxFiles: args.maxFiles,
+	}, nil
+}
+
+// path returns the path of the nth rotated file, the current one being 0.
+func (j *Journal) path(n int) string {
+	p := filepath.Join(j.dir, fileName)
+	if n > 0 {
+		p = fmt.Sprintf("%s.%d", p, n)
+	}
+	return p
+}
+
+// Append records e at the end of the journal, rotating it first if needed.
+func (j *Journal) Append(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't convert journal entry to json: %v"), err)
+	}
+	b = append(b, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(j.dir, 0700); err != nil {
+		return fmt.Errorf(i18n.G("couldn't create journal directory: %v"), err)
+	}
+
+	if fi, err := os.Stat(j.path(0)); err == nil && fi.Size() > 0 && fi.Size()+int64(len(b)) > j.maxSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(j.path(0), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't open journal: %v"), err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write to journal: %v"), err)
+	}
+	return f.Sync()
+}
+
+// rotate shifts every journal file by one, removing the oldest one past the number of files to keep.
+// The caller needs to hold j.mu.
+func (j *Journal) rotate() error {
+	if err := os.Remove(j.path(j.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf(i18n.G("couldn't remove oldest journal file: %v"), err)
+	}
+	for n := j.maxFiles - 1; n >= 0; n-- {
+		if err := os.Rename(j.path(n), j.path(n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf(i18n.G("couldn't rotate journal: %v"), err)
+		}
+	}
+	return nil
+}
+
+// Filter selects entries returned by Entries. Empty fields select every entry.
+type Filter struct {
+	// Since skips entries older than this time.
+	Since time.Time
+	// Operation only selects entries of this operation, case insensitively.
+	Operation string
+	// Match only selects entries with a parameter value or a dataset containing this string.
+	Match string
+	// Limit only returns that many most recent matching entries.
+	Limit int
+}
+
+func (f Filter) matches(e Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if f.Operation != "" && !strings.EqualFold(f.Operation, e.Operation) {
+		return false
+	}
+	if f.Match == "" {
+		return true
+	}
+	for _, v := range e.Parameters {
+		if strings.Contains(v, f.Match) {
+			return true
+		}
+	}
+	for _, d := range e.Datasets {
+		if strings.Contains(d, f.Match) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns journal entries matching f, from the oldest to the most recent one.
+// Lines which can't be read, like one partially written on a crash, are skipped.
+func (j *Journal) Entries(f Filter) ([]Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var entries []Entry
+	for n := j.maxFiles; n >= 0; n-- {
+		file, err := os.Open(j.path(n))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf(i18n.G("couldn't open journal: %v"), err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+		for scanner.Scan() {
+			var e Entry
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				continue
+			}
+			if f.matches(e) {
+				entries = append(entries, e)
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf(i18n.G("couldn't read journal: %v"), err)
+		}
+	}
+
+	if f.Limit > 0 && len(entries) > f.Limit {
+		entries = entries[len(entries)-f.Limit:]
+	}
+	return entries, nil
+}
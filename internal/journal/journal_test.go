@@ -0,0 +1,197 @@
+package journal_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/journal"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+var refTime = time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	entries := []journal.Entry{
+		{Time: refTime, UID: 0, PID: 42, Operation: "SaveSystemState", Parameters: map[string]string{"state": "foo"},
+			Datasets: []string{"rpool/ROOT/ubuntu_1234@foo"}},
+		{Time: refTime.Add(time.Hour), UID: 1000, Operation: "SaveUserState", Parameters: map[string]string{"user": "alice", "state": "bar"},
+			Error: "not enough space"},
+		{Time: refTime.Add(2 * time.Hour), UID: 1000, Operation: "RemoveSystemState", Parameters: map[string]string{"state": "foo"},
+			Datasets: []string{"rpool/ROOT/ubuntu_1234@foo"}},
+		{Time: refTime.Add(3 * time.Hour), UID: 0, Operation: "GC",
+			Datasets: []string{"rpool/ROOT/ubuntu_1234@autozsys_abc"}},
+	}
+
+	tests := map[string]struct {
+		filter journal.Filter
+
+		want []int
+	}{
+		"All entries":                   {want: []int{0, 1, 2, 3}},
+		"Since a given time":            {filter: journal.Filter{Since: refTime.Add(90 * time.Minute)}, want: []int{2, 3}},
+		"Operation":                     {filter: journal.Filter{Operation: "RemoveSystemState"}, want: []int{2}},
+		"Operation is case insensitive": {filter: journal.Filter{Operation: "gc"}, want: []int{3}},
+		"Match a parameter":             {filter: journal.Filter{Match: "foo"}, want: []int{0, 2}},
+		"Match a dataset":               {filter: journal.Filter{Match: "autozsys_abc"}, want: []int{3}},
+		"Limit to most recent ones":     {filter: journal.Filter{Limit: 2}, want: []int{2, 3}},
+		"Limit over matching entries":   {filter: journal.Filter{Match: "foo", Limit: 1}, want: []int{2}},
+		"Combined filters":              {filter: journal.Filter{Since: refTime.Add(30 * time.Minute), Match: "foo"}, want: []int{2}},
+
+		"No match": {filter: journal.Filter{Operation: "DissociateUser"}},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			j, err := journal.New(filepath.Join(dir, "journal"))
+			if err != nil {
+				t.Fatalf("expected no error creating journal but got: %v", err)
+			}
+			for _, e := range entries {
+				if err := j.Append(e); err != nil {
+					t.Fatalf("expected no error appending to journal but got: %v", err)
+				}
+			}
+
+			got, err := j.Entries(tc.filter)
+			if err != nil {
+				t.Fatalf("expected no error reading journal but got: %v", err)
+			}
+
+			var want []journal.Entry
+			for _, i := range tc.want {
+				want = append(want, entries[i])
+			}
+			assert.Equal(t, want, got, "journal entries")
+		})
+	}
+}
+
+func TestEntriesOnNoJournal(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	j, err := journal.New(filepath.Join(dir, "journal"))
+	if err != nil {
+		t.Fatalf("expected no error creating journal but got: %v", err)
+	}
+
+	got, err := j.Entries(journal.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error reading journal before any write but got: %v", err)
+	}
+	assert.Empty(t, got, "no entries before any write")
+	assert.NoDirExists(t, filepath.Join(dir, "journal"), "journal directory is only created on first write")
+}
+
+func TestEntriesSkipsCorruptedLines(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	j, err := journal.New(dir)
+	if err != nil {
+		t.Fatalf("expected no error creating journal but got: %v", err)
+	}
+	if err := j.Append(journal.Entry{Time: refTime, Operation: "GC"}); err != nil {
+		t.Fatalf("expected no error appending to journal but got: %v", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, "journal.log"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Setup: couldn't open journal: %v", err)
+	}
+	fmt.Fprintln(f, `{"Time": "2020-06-01T11:00:00Z", "Opera`)
+	f.Close()
+	if err := j.Append(journal.Entry{Time: refTime.Add(time.Hour), Operation: "Refresh"}); err != nil {
+		t.Fatalf("expected no error appending to journal but got: %v", err)
+	}
+
+	got, err := j.Entries(journal.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error reading journal but got: %v", err)
+	}
+	assert.Equal(t, []journal.Entry{{Time: refTime, Operation: "GC"}, {Time: refTime.Add(time.Hour), Operation: "Refresh"}}, got,
+		"only valid entries are returned")
+}
+
+func TestRotation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		maxFiles int
+		appends  int
+
+		wantFiles   []string
+		wantEntries int
+	}{
+		"No rotation below maximum size":               {maxFiles: 2, appends: 1, wantFiles: []string{"journal.log"}, wantEntries: 1},
+		"Rotate once over maximum size":                {maxFiles: 2, appends: 2, wantFiles: []string{"journal.log", "journal.log.1"}, wantEntries: 2},
+		"Keep maximum number of files":                 {maxFiles: 2, appends: 3, wantFiles: []string{"journal.log", "journal.log.1", "journal.log.2"}, wantEntries: 3},
+		"Remove files over maximum":                    {maxFiles: 2, appends: 5, wantFiles: []string{"journal.log", "journal.log.1", "journal.log.2"}, wantEntries: 3},
+		"Only keep current file without rotated files": {maxFiles: 0, appends: 3, wantFiles: []string{"journal.log"}, wantEntries: 1},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			// Each entry is bigger than the maximum size: one entry per file
+			j, err := journal.New(dir, journal.WithMaxSize(10), journal.WithMaxFiles(tc.maxFiles))
+			if err != nil {
+				t.Fatalf("expected no error creating journal but got: %v", err)
+			}
+			for i := 0; i < tc.appends; i++ {
+				if err := j.Append(journal.Entry{Time: refTime.Add(time.Duration(i) * time.Hour), Operation: "GC"}); err != nil {
+					t.Fatalf("expected no error appending to journal but got: %v", err)
+				}
+			}
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("couldn't list journal directory: %v", err)
+			}
+			var got []string
+			for _, f := range files {
+				got = append(got, f.Name())
+				assert.Equal(t, os.FileMode(0600), f.Mode().Perm(), "journal files are only readable by root")
+			}
+			assert.Equal(t, tc.wantFiles, got, "journal files")
+
+			entries, err := j.Entries(journal.Filter{})
+			if err != nil {
+				t.Fatalf("expected no error reading journal but got: %v", err)
+			}
+			assert.Len(t, entries, tc.wantEntries, "entries kept after rotation")
+			// Most recent entries are kept, in order
+			for i, e := range entries {
+				assert.Equal(t, refTime.Add(time.Duration(tc.appends-tc.wantEntries+i)*time.Hour), e.Time, "entry time")
+			}
+		})
+	}
+}
+
+func TestNewWithInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	if _, err := journal.New("foo", journal.WithMaxSize(0)); err == nil {
+		t.Error("expected an error with a null maximum size but got none")
+	}
+	if _, err := journal.New("foo", journal.WithMaxFiles(-1)); err == nil {
+		t.Error("expected an error with a negative number of files but got none")
+	}
+}
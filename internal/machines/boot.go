@@ -2,6 +2,7 @@ package machines
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -38,6 +39,12 @@ func (ms *Machines) EnsureBoot(ctx context.Context) (bool, error) {
 	m, bootedState := ms.findFromRoot(root)
 	log.Infof(ctx, i18n.G("Ensure boot on %q"), root)
 
+	// A revert scheduled from the running system can't change kernel command line: take user data revert from it.
+	if id, revert := m.ScheduledRevert(); id == root && revert {
+		log.Infof(ctx, i18n.G("Reverting user data as scheduled for %q"), root)
+		revertUserData = true
+	}
+
 	bootedOnSnapshot := hasBootedOnSnapshot(ms.cmdline)
 	// We are creating new clones (bootfs and optionnally, userdata) if wasn't promoted already
 	if bootedOnSnapshot && ms.current.ID != bootedState.ID {
@@ -116,6 +123,24 @@ func (ms *Machines) Commit(ctx context.Context) (bool, error) {
 	m, bootedState := ms.findFromRoot(root)
 	log.Infof(ctx, i18n.G("Committing boot for %q"), root)
 
+	var changed bool
+
+	// Scheduled revert is a one-shot request: reset it on the first committed boot, whatever state we booted on.
+	// Its main dataset will be demoted if the revert was successful, so do it before any promotion.
+	if id, revert := m.ScheduledRevert(); id != "" {
+		if id == root {
+			log.Infof(ctx, i18n.G("Booted on scheduled state %q"), id)
+			revertUserData = revertUserData || revert
+		} else {
+			log.Warningf(ctx, i18n.G("Scheduled state %q wasn't booted, discarding request"), id)
+		}
+		if err := t.SetProperty(libzfs.NextBootProp, "", m.ID, false); err != nil {
+			cancel()
+			return false, fmt.Errorf(i18n.G("couldn't reset scheduled boot on %q: ")+config.ErrorFormat, m.ID, err)
+		}
+		changed = true
+	}
+
 	// Get user datasets. As we didn't tag the user datasets and promote the system one, the machines doesn't correspond
 	// to the reality.
 
@@ -150,8 +175,6 @@ func (ms *Machines) Commit(ctx context.Context) (bool, error) {
 		}
 	}
 
-	var changed bool
-
 	kernel := kernelFromCmdline(ms.cmdline)
 	log.Infof(ctx, i18n.G("Set latest booted kernel to %q\n"), kernel)
 	if systemDatasets[0].LastBootedKernel != kernel {
@@ -225,6 +248,40 @@ func (ms *Machines) UpdateLastUsed(ctx context.Context) error {
 	return nil
 }
 
+// RevertState schedules next boot on a given system state of current machine, optionally reverting user data too.
+// The request is stored on the current machine main dataset and only applies once: the next boot commit resets it,
+// whether or not the bootloader booted on the requested state.
+func (ms *Machines) RevertState(ctx context.Context, name string, revertUserData bool) error {
+	if !ms.current.isZsys() {
+		return errors.New(i18n.G("Current machine isn't Zsys, can't schedule a revert"))
+	}
+
+	s, err := ms.IDToState(ctx, name, "")
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	if s == &ms.current.State {
+		return errors.New(i18n.G("Reverting to current system state isn't allowed"))
+	}
+	if _, ok := ms.current.History[s.ID]; !ok {
+		return fmt.Errorf(i18n.G("%s isn't a system state of current machine"), s.ID)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	params := nextBootParameters(s.ID, revertUserData)
+	log.Infof(ctx, i18n.G("Scheduling next boot on %q"), s.ID)
+	if err := t.SetProperty(libzfs.NextBootProp, params, ms.current.ID, false); err != nil {
+		cancel()
+		return fmt.Errorf(i18n.G("couldn't schedule next boot on %q: ")+config.ErrorFormat, s.ID, err)
+	}
+	ms.nextState = s
+
+	return nil
+}
+
 // diffDatasets returns datasets in a that aren't in b
 func diffDatasets(a, b []*zfs.Dataset) []*zfs.Dataset {
 	mb := make(map[string]struct{}, len(b))
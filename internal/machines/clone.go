@@ -0,0 +1,107 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// CloneMachine creates a new machine from the system state name, optionally cloning its user data too.
+// The new machine system, boot and user datasets are tagged as cloned from this state: they are listed as a separate
+// machine and are promoted independently of the machine they were cloned from.
+// It returns the ID of the new machine.
+func (ms *Machines) CloneMachine(ctx context.Context, name string, withUserData bool) (string, error) {
+	s, err := ms.IDToState(ctx, name, "")
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	var isSystemHistory bool
+	for _, m := range ms.all {
+		if _, ok := m.History[s.ID]; ok {
+			isSystemHistory = true
+			break
+		}
+	}
+	if !isSystemHistory || !s.isSnapshot() {
+		return "", fmt.Errorf(i18n.G("%s isn't a saved system state"), s.ID)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	suffix := t.Zfs.GenerateID(6)
+	base, _ := splitSnapshotName(s.ID)
+	machineID := clonedDatasetName(base, suffix)
+
+	log.Infof(ctx, i18n.G("Cloning %q to new machine %q"), s.ID, machineID)
+	routes := make([]string, 0, len(s.Datasets))
+	for route := range s.Datasets {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		if err := t.Clone(route, suffix, false, true); err != nil {
+			cancel()
+			return "", fmt.Errorf(i18n.G("couldn't clone %q: %v"), route, err)
+		}
+		routeBase, _ := splitSnapshotName(route)
+		if err := tagClonedDatasets(t, s.ID, routeBase, clonedDatasetName(routeBase, suffix), s.Datasets[route]); err != nil {
+			cancel()
+			return "", err
+		}
+	}
+
+	if withUserData {
+		userSuffix := t.Zfs.GenerateID(6)
+		for _, user := range sortedStateKeys(s.Users) {
+			us := s.Users[user]
+			log.Infof(ctx, i18n.G("Cloning user data %q"), us.ID)
+			if err := t.Clone(us.ID, userSuffix, false, true); err != nil {
+				cancel()
+				return "", fmt.Errorf(i18n.G("couldn't clone user data %q: %v"), us.ID, err)
+			}
+			userBase, _ := splitSnapshotName(us.ID)
+			newUserBase := clonedDatasetName(userBase, userSuffix)
+			if err := tagClonedDatasets(t, s.ID, userBase, newUserBase, us.getDatasets()); err != nil {
+				cancel()
+				return "", err
+			}
+			if err := t.SetProperty(libzfs.BootfsDatasetsProp, machineID, newUserBase, false); err != nil {
+				cancel()
+				return "", fmt.Errorf(i18n.G("couldn't add %q to BootfsDatasets property of %q: ")+config.ErrorFormat, machineID, newUserBase, err)
+			}
+		}
+	}
+
+	ms.refresh(ctx)
+	return machineID, nil
+}
+
+// clonedDatasetName returns the name of the clone of the filesystem dataset base, with suffix as its new id,
+// as named by zfs.Transaction.Clone().
+func clonedDatasetName(base, suffix string) string {
+	if i := strings.Index(base, "_"); i > -1 {
+		return base[:i] + "_" + suffix
+	}
+	return base + "_" + suffix
+}
+
+// tagClonedDatasets marks every clone of the snapshots ds, moved from base to newBase, as cloned from state id.
+func tagClonedDatasets(t *zfs.Transaction, id, base, newBase string, ds []*zfs.Dataset) error {
+	for _, d := range ds {
+		n, _ := splitSnapshotName(d.Name)
+		n = newBase + strings.TrimPrefix(n, base)
+		if err := t.SetProperty(libzfs.ClonedFromProp, id, n, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't tag %q as cloned from %q: ")+config.ErrorFormat, n, id, err)
+		}
+	}
+	return nil
+}
@@ -27,6 +27,33 @@ func bootParametersFromCmdline(cmdline string) (rootDataset string, revertuserDa
 	return rootDataset, revertuserData
 }
 
+// nextBootParameters returns the boot parameters to store for a scheduled boot on rootDataset.
+// They use the same format than kernel command line, so that bootloaders can directly append them.
+func nextBootParameters(rootDataset string, revertUserData bool) string {
+	params := zfsRootPrefix + rootDataset
+	if revertUserData {
+		params += " " + zfsRevertUserDataTag
+	}
+	return params
+}
+
+// ScheduledRevert returns the state id and revertUserData state of a scheduled boot on the machine if any.
+func (m *Machine) ScheduledRevert() (id string, revertUserData bool) {
+	params := m.NextBootParameters()
+	if params == "" {
+		return "", false
+	}
+	return bootParametersFromCmdline(params)
+}
+
+// NextBootParameters returns the kernel parameters of a scheduled boot on the machine if any.
+func (m *Machine) NextBootParameters() string {
+	if m == nil || len(m.Datasets[m.ID]) == 0 {
+		return ""
+	}
+	return m.Datasets[m.ID][0].NextBoot
+}
+
 // kernelFromCmdline returns the used kernel name in cmdline
 func kernelFromCmdline(cmdline string) (kernel string) {
 	for _, entry := range strings.Fields(cmdline) {
@@ -73,6 +73,7 @@ func (ms *Machines) MakeComparable() {
 	ms.z = nil
 	ms.time = nil
 	ms.conf = config.ZConfig{}
+	ms.hooksDir = ""
 }
 
 // SplitSnapshotName calls internal splitSnapshotName to split a snapshot name in base and id of a snapshot
@@ -11,6 +11,7 @@ import (
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/units"
 	"github.com/ubuntu/zsys/internal/zfs"
 )
 
@@ -41,12 +42,18 @@ const (
 
 type stateWithKeep struct {
 	*State
-	keep keepStatus
+	keep   keepStatus
+	reason string // why the state is kept, if keep is keepYes
 }
 
 // GC starts garbage collection for system and users
 // If all is set manual snapshots are considered too
-func (ms *Machines) GC(ctx context.Context, all bool) error {
+// System states follow history rules, while user states follow user history rules, if any.
+// If dryrun is set, no dataset is touched: removals are simulated in memory and only reported to the requester.
+// If explain is set, each bucket and why its states are kept or removed is reported to the requester.
+// Finally, pools below the free space target are put under pressure: their oldest automatic states are removed
+// until the target is reached.
+func (ms *Machines) GC(ctx context.Context, all, dryrun, explain bool) error {
 	now := ms.time.Now()
 
 	buckets := computeBuckets(ctx, now, ms.conf.History)
@@ -75,21 +82,34 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 	var statesToRemove []*State
 	keepDueToErrorOnDelete := make(map[string]bool)
 
+	// Bookkeeping of states and datasets removed in dry run mode, as we don’t refresh our machines in that case.
+	removedStates := make(map[string]bool)
+	removedDatasets := make(map[string]bool)
+
 	// 1. System GC
 	var gcPassNum int
 	for {
 		gcPassNum++
 		log.Debugf(ctx, "GC System Pass #%d", gcPassNum)
+		if err := checkCancelled(ctx); err != nil {
+			return err
+		}
 		statesChanges := false
 
 		for _, m := range ms.all {
 			if !m.isZsys() {
 				continue
 			}
+			if explain {
+				log.RemotePrintf(ctx, i18n.G("System states of machine %s, pass #%d:\n"), m.ID, gcPassNum)
+			}
 
 			var newestStateIndex int
 			var sortedStates sortedReverseByTimeStates
 			for _, s := range m.History {
+				if removedStates[s.ID] {
+					continue
+				}
 				sortedStates = append(sortedStates, s)
 			}
 			sort.Sort(sortedStates)
@@ -124,6 +144,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 				// Don't touch anything for this bucket, skip all states in here and advance to next one.
 				if bucket.samples == -1 {
 					log.Debug(ctx, i18n.G("Keeping all snapshots for this bucket"))
+					if explain {
+						explainBucket(ctx, bucket, keepAll(sortedStates[newestStateIndex:oldestStateIndex+1]), nil)
+					}
 					newestStateIndex = oldestStateIndex + 1
 					continue
 				}
@@ -137,28 +160,34 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					s := sortedStates[i]
 
 					keep := keepUnknown
+					var reason string
 					// Previous deletion failed
 					if keepDueToErrorOnDelete[s.ID] {
-						keep = keepYes
+						keep, reason = keepYes, i18n.G("error on previous deletion")
+					}
+					// Pinned by the user
+					if keep == keepUnknown && s.Pinned() {
+						log.Debugf(ctx, i18n.G("Keeping %v as it's pinned"), s.ID)
+						keep, reason = keepYes, i18n.G("pinned")
 					}
 					// In keep last list
 					if keep == keepUnknown && i < keepLast {
 						log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's in the last %d snapshots"), s.ID, keepLast)
-						keep = keepYes
+						keep, reason = keepYes, fmt.Sprintf(i18n.G("in the last %d states"), keepLast)
 					}
 					// Has snapshots as children
 					if keep == keepUnknown && !s.isSnapshot() {
 						for _, ds := range s.Datasets {
-							if ds[0].HasSnapshotInHierarchy() {
+							if hasSnapshotInHierarchy(ds, snapshotsByDS, dryrun) {
 								log.Debugf(ctx, i18n.G("Keeping %v as it has a snapshot in its child hierarchy"), s.ID)
-								keep = keepYes
+								keep, reason = keepYes, i18n.G("has snapshots depending on it")
 							}
 						}
 					}
 					// Non automated snapshots
 					if keep == keepUnknown && s.isSnapshot() && !all && !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
 						log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's not a zsys one"), s.ID)
-						keep = keepYes
+						keep, reason = keepYes, i18n.G("manual snapshot")
 					}
 					// Has clones
 					if keep == keepUnknown && s.isSnapshot() {
@@ -169,7 +198,7 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 								// keep the whole state if any dataset is the origin of a clone of if it’s a clone with snapshots on it
 								if byOrigin[d.Name] != nil || snapshotsByDS[d.Name] != nil {
 									log.Debugf(ctx, i18n.G("Keeping snapshot %v as at least %s dataset has dependencies"), s.ID, d.Name)
-									keep = keepYes
+									keep, reason = keepYes, fmt.Sprintf(i18n.G("%s has dependencies"), d.Name)
 									break analyzeSystemDataset
 								}
 							}
@@ -177,8 +206,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					}
 
 					states = append(states, stateWithKeep{
-						State: s,
-						keep:  keep,
+						State:  s,
+						keep:   keep,
+						reason: reason,
 					})
 				}
 				// next bucket start point
@@ -188,11 +218,17 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 				nStatesToRemove := len(states) - bucket.samples
 				if nStatesToRemove <= 0 {
 					log.Debugf(ctx, i18n.G("No exceeding states for this bucket (delta: %d). Moving on."), nStatesToRemove)
+					if explain {
+						explainBucket(ctx, bucket, states, nil)
+					}
 					continue
 				}
 				log.Debugf(ctx, i18n.G("There are %d exceeding states to potentially remove"), nStatesToRemove)
 
 				statesToRemoveForBucket := selectStatesToRemove(ctx, bucket.samples, states)
+				if explain {
+					explainBucket(ctx, bucket, states, statesToRemoveForBucket)
+				}
 
 				for _, s := range statesToRemoveForBucket {
 					statesChanges = true
@@ -223,13 +259,22 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 
 		// Remove the given states.
 		for _, s := range statesToRemove {
+			if dryrun {
+				reportRemovedState(ctx, s, removedStates, removedDatasets)
+				continue
+			}
 			log.Infof(ctx, i18n.G("Selecting state to remove: %s"), s.ID)
+			ms.bookmarkState(ctx, s)
 			if err := s.remove(ctx, ms, ""); err != nil {
 				log.Errorf(ctx, i18n.G("Couldn't fully destroy state %s: %v\nPutting it in keep list."), s.ID, err)
 				keepDueToErrorOnDelete[s.ID] = true
 			}
 		}
 		statesToRemove = nil
+		if dryrun {
+			log.Debug(ctx, i18n.G("System have simulated changes, rerun system GC"))
+			continue
+		}
 		if err := ms.Refresh(ctx); err != nil {
 			return fmt.Errorf("Couldn't refresh machine list: %v", err)
 		}
@@ -248,11 +293,29 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 	for {
 		gcPassNum++
 		log.Debugf(ctx, "GC User Pass #%d", gcPassNum)
+		if err := checkCancelled(ctx); err != nil {
+			return err
+		}
 		statesChanges := false
 
 		for _, m := range ms.all {
 			// FIXME: we count same user state multiple times if linked to multiple bootfs systems
-			for _, us := range m.AllUsersStates {
+			// Sort users for a stable report
+			users := make([]string, 0, len(m.AllUsersStates))
+			for user := range m.AllUsersStates {
+				users = append(users, user)
+			}
+			sort.Strings(users)
+			for _, user := range users {
+				us := m.AllUsersStates[user]
+				// Each user can have its own retention policy
+				rules := ms.conf.UserHistoryFor(user)
+				buckets := computeBuckets(ctx, now, rules)
+				keepLast := rules.KeepLast
+				if explain {
+					log.RemotePrintf(ctx, i18n.G("States of user %s on machine %s, pass #%d:\n"), user, m.ID, gcPassNum)
+				}
+
 				var newestStateIndex int
 				var sortedStates sortedReverseByTimeStates
 
@@ -264,6 +327,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 							continue nextUserState
 						}
 					}
+					if removedStates[s.ID] {
+						continue
+					}
 
 					sortedStates = append(sortedStates, s)
 				}
@@ -297,6 +363,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					// Don't touch anything for this bucket, skip all states in here and advance to next one.
 					if bucket.samples == -1 {
 						log.Debug(ctx, i18n.G("Keeping all snapshots for this bucket"))
+						if explain {
+							explainBucket(ctx, bucket, keepAll(sortedStates[newestStateIndex:oldestStateIndex+1]), nil)
+						}
 						newestStateIndex = oldestStateIndex + 1
 						continue
 					}
@@ -309,43 +378,52 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 						log.Debugf(ctx, i18n.G("Analyzing state %v: %v"), s.ID, s.LastUsed.Format(timeFormat))
 
 						keep := keepUnknown
+						var reason string
 						// Previous deletion failed
 						if keepDueToErrorOnDelete[s.ID] {
-							keep = keepYes
+							keep, reason = keepYes, i18n.G("error on previous deletion")
+						}
+						// Pinned by the user
+						if keep == keepUnknown && s.Pinned() {
+							log.Debugf(ctx, i18n.G("Keeping %v as it's pinned"), s.ID)
+							keep, reason = keepYes, i18n.G("pinned")
 						}
 						// In keep last list
 						if keep == keepUnknown && i < keepLast {
 							log.Debugf(ctx, i18n.G("Keeping %v as it's in the last %d snapshots"), s.ID, keepLast)
-							keep = keepYes
+							keep, reason = keepYes, fmt.Sprintf(i18n.G("in the last %d states"), keepLast)
 						}
 						// Has snapshots as children
 						if keep == keepUnknown && !s.isSnapshot() {
 							for _, ds := range s.Datasets {
-								if ds[0].HasSnapshotInHierarchy() {
+								if hasSnapshotInHierarchy(ds, snapshotsByDS, dryrun) {
 									log.Debugf(ctx, i18n.G("Keeping %v as it has a snapshot in its child hierarchy"), s.ID)
-									keep = keepYes
+									keep, reason = keepYes, i18n.G("has snapshots depending on it")
 								}
 							}
 						}
 						// Non automated snapshots
 						if keep == keepUnknown && s.isSnapshot() && !all && !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
 							log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's not a zsys one"), s.ID)
-							keep = keepYes
+							keep, reason = keepYes, i18n.G("manual snapshot")
 						}
 						// Filesystem linked to system state
-						if keep == keepUnknown && !s.isSnapshot() && s.linkedToSystemState() {
+						if keep == keepUnknown && !s.isSnapshot() && s.linkedToSystemState(removedStates) {
 							log.Debugf(ctx, i18n.G("Keeping %v as it's not a snapshot and associated to a system state"), s.ID)
-							keep = keepYes
+							keep, reason = keepYes, i18n.G("associated to a system state")
 						}
 						// Snapshot linked to system state
 						if keep == keepUnknown && s.isSnapshot() {
 							_, snapshotName := splitSnapshotName(s.ID)
 							// Do we have a state associated with us?
 							for k := range m.History {
+								if removedStates[k] {
+									continue
+								}
 								_, n := splitSnapshotName(k)
 								if n == snapshotName {
 									log.Debugf(ctx, i18n.G("Keeping as snapshot %v is associated to a system snapshot"), s.ID)
-									keep = keepYes
+									keep, reason = keepYes, fmt.Sprintf(i18n.G("associated to system state %s"), k)
 									break
 								}
 							}
@@ -361,7 +439,7 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 									// do we have clones of us?
 									if byOrigin[d.Name] != nil {
 										log.Debugf(ctx, i18n.G("Keeping snapshot %v as at least %s dataset has dependencies"), s.ID, d.Name)
-										keep = keepYes
+										keep, reason = keepYes, fmt.Sprintf(i18n.G("%s has dependencies"), d.Name)
 										break analyzeUserDataset
 									}
 								}
@@ -369,8 +447,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 						}
 
 						states = append(states, stateWithKeep{
-							State: s,
-							keep:  keep,
+							State:  s,
+							keep:   keep,
+							reason: reason,
 						})
 						for route := range s.Datasets {
 							userDatasetsToKeep[route] = true
@@ -383,11 +462,17 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					nStatesToRemove := len(states) - bucket.samples
 					if nStatesToRemove <= 0 {
 						log.Debugf(ctx, i18n.G("No exceeding states for this bucket (delta: %d). Moving on."), nStatesToRemove)
+						if explain {
+							explainBucket(ctx, bucket, states, nil)
+						}
 						continue
 					}
 					log.Debugf(ctx, i18n.G("There are %d exceeding states to potentially remove"), nStatesToRemove)
 
 					statesToRemoveForBucket := selectStatesToRemove(ctx, bucket.samples, states)
+					if explain {
+						explainBucket(ctx, bucket, states, statesToRemoveForBucket)
+					}
 
 					for _, s := range statesToRemoveForBucket {
 						statesChanges = true
@@ -416,7 +501,15 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 
 		// Remove the given states.
 		for _, s := range statesToRemove {
+			if dryrun {
+				reportRemovedState(ctx, s, removedStates, removedDatasets)
+				for route := range s.Datasets {
+					delete(userDatasetsToKeep, route)
+				}
+				continue
+			}
 			log.Infof(ctx, i18n.G("Selecting state to remove: %s"), s.ID)
+			ms.bookmarkState(ctx, s)
 			if err := s.remove(ctx, ms, ""); err != nil {
 				log.Errorf(ctx, i18n.G("Couldn't fully destroy user state %s: %v.\nPutting it in keep list."), s.ID, err)
 				keepDueToErrorOnDelete[s.ID] = true
@@ -428,6 +521,10 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 		}
 
 		statesToRemove = nil
+		if dryrun {
+			log.Debug(ctx, i18n.G("Users states have simulated changes, rerun user GC"))
+			continue
+		}
 		if err := ms.Refresh(ctx); err != nil {
 			return fmt.Errorf("Couldn't refresh machine list: %v", err)
 		}
@@ -443,6 +540,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 nextUnmanagedUserPass:
 	for {
 		log.Debugf(ctx, "GC Unmanaged user Pass #%d", gcPassNum)
+		if err := checkCancelled(ctx); err != nil {
+			return err
+		}
 
 		destroyCandidates := make(map[string]*zfs.Dataset)
 		for _, d := range ms.unmanagedDatasets {
@@ -450,6 +550,10 @@ nextUnmanagedUserPass:
 			if _, ok := keepDatasets[d.Name]; ok {
 				continue
 			}
+			// Ignore datasets removed in dry run
+			if removedDatasets[d.Name] {
+				continue
+			}
 			// Ignore datasets we can’t destroy
 			if _, ok := keepDueToErrorOnDelete[d.Name]; ok {
 				continue
@@ -486,7 +590,7 @@ nextUnmanagedUserPass:
 		for _, d := range deps {
 			// Any snapshots has its parent listed in deps or is candidate, ignore it for the check as we didn’t add it to the destroyCandidates list.
 			// We only consider filesystem datasets here
-			if d.IsSnapshot {
+			if d.IsSnapshot || removedDatasets[d.Name] {
 				continue
 			}
 			if _, ok := destroyCandidates[d.Name]; !ok {
@@ -496,6 +600,18 @@ nextUnmanagedUserPass:
 			}
 		}
 
+		if dryrun {
+			for _, d := range append(deps, candidate) {
+				if removedDatasets[d.Name] {
+					continue
+				}
+				log.RemotePrintf(ctx, i18n.G("Deleting dataset %s\n"), d.Name)
+				removedDatasets[d.Name] = true
+			}
+			gcPassNum++
+			continue
+		}
+
 		log.Debugf(ctx, "Trying to destroy %s", candidate.Name)
 		for _, d := range append(deps, candidate) {
 			// We destroy here all snapshots and leaf attached. Snapshots won’t be taken into account, however, we don’t want
@@ -512,9 +628,304 @@ nextUnmanagedUserPass:
 		gcPassNum++
 	}
 
+	// 4. Free space pressure on pools below target.
+	log.Debug(ctx, i18n.G("Free space pressure GC"))
+	if err := ms.gcFreeSpace(ctx, dryrun, explain, removedStates, removedDatasets); err != nil {
+		return err
+	}
+
+	// 5. Bookmarks retention.
+	log.Debug(ctx, i18n.G("Bookmarks GC"))
+	return ms.gcBookmarks(ctx, dryrun)
+}
+
+// checkCancelled returns an error if the request ctx belongs to was cancelled, to stop before destroying anything else.
+// What was destroyed so far stays destroyed, as destructions can't be reverted.
+func checkCancelled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf(i18n.G("operation cancelled: %w"), err)
+	}
 	return nil
 }
 
+// bookmarkState bookmarks, if enabled, every snapshot of state s before its removal by garbage collection.
+// Failing to bookmark doesn't prevent the removal.
+func (ms *Machines) bookmarkState(ctx context.Context, s *State) {
+	if !ms.conf.Bookmarks.Enabled || !s.isSnapshot() {
+		return
+	}
+
+	nt := ms.z.NewNoTransaction(ctx)
+	for _, d := range s.getDatasets() {
+		if !d.IsSnapshot {
+			continue
+		}
+		log.Infof(ctx, i18n.G("Bookmarking %s"), d.Name)
+		if err := nt.Bookmark(d.Name); err != nil {
+			log.Warningf(ctx, i18n.G("Couldn't bookmark %s: %v"), d.Name, err)
+		}
+	}
+}
+
+// gcBookmarks removes bookmarks of machines older than the configured retention.
+// A bookmark of the last replicated snapshot of a filesystem is kept, as the base of the next replication.
+func (ms *Machines) gcBookmarks(ctx context.Context, dryrun bool) error {
+	keepDays := ms.conf.Bookmarks.KeepDays
+	if keepDays <= 0 {
+		return nil
+	}
+	limit := ms.time.Now().Add(-time.Duration(int64(keepDays) * timeDay))
+
+	lastReplicated := make(map[string]string)
+	for _, d := range append(append([]*zfs.Dataset(nil), ms.allSystemDatasets...), ms.allUsersDatasets...) {
+		if !d.IsSnapshot && d.LastReplicated != "" {
+			lastReplicated[d.Name] = d.LastReplicated
+		}
+	}
+
+	nt := ms.z.NewNoTransaction(ctx)
+	var removed bool
+	for _, k := range sortedMachineKeys(ms.all) {
+		for _, b := range ms.all[k].Bookmarks {
+			if !time.Unix(int64(b.LastUsed), 0).Before(limit) {
+				continue
+			}
+			fs, name := splitBookmarkName(b.Name)
+			if lastReplicated[fs] == name {
+				log.Debugf(ctx, "Keeping bookmark %s as it's the last replicated snapshot", b.Name)
+				continue
+			}
+
+			if dryrun {
+				log.RemotePrintf(ctx, i18n.G("Deleting bookmark %s\n"), b.Name)
+				continue
+			}
+			log.Infof(ctx, i18n.G("Removing bookmark %s older than %d days"), b.Name, keepDays)
+			if err := nt.Destroy(b.Name); err != nil {
+				log.Warningf(ctx, i18n.G("Couldn't destroy bookmark %s: %v"), b.Name, err)
+				continue
+			}
+			removed = true
+		}
+	}
+
+	if !removed {
+		return nil
+	}
+	return ms.Refresh(ctx)
+}
+
+// gcFreeSpace removes the oldest automatic states on pools where free space is below the configured target.
+// States are removed one at a time, system ones with their user states, until the space they free is estimated
+// to reach the target. Pinned states and states which are the origin of clones are never removed.
+func (ms *Machines) gcFreeSpace(ctx context.Context, dryrun, explain bool, removedStates, removedDatasets map[string]bool) error {
+	target := ms.conf.General.TargetFreePoolSpace
+	if target <= 0 {
+		return nil
+	}
+
+	for _, p := range ms.zsysPools() {
+		free, err := ms.z.GetPoolFreeSpace(p)
+		if err != nil {
+			return err
+		}
+		if free >= target {
+			continue
+		}
+		size, err := ms.z.GetPoolSize(p)
+		if err != nil {
+			return err
+		}
+		needed := uint64(target-free) * (size / 100)
+
+		log.Infof(ctx, i18n.G("Free space on pool %q is %d%%, below the target of %d%%. Removing oldest automatic states."), p, free, target)
+		if explain {
+			log.RemotePrintf(ctx, i18n.G("Pool %s has %d%% of free space, freeing %s to reach %d%%:\n"), p, free, units.HumanSize(needed), target)
+		}
+
+		keepDueToErrorOnDelete := make(map[string]bool)
+		var freed uint64
+		for freed < needed {
+			if err := checkCancelled(ctx); err != nil {
+				return err
+			}
+			// Previous removals, in this pass or the ones before, may have changed clones and their origins.
+			s := ms.oldestAutomaticState(p, ms.clonesByOrigin(removedDatasets), removedStates, keepDueToErrorOnDelete)
+			if s == nil {
+				log.Warningf(ctx, i18n.G("No more automatic states to remove on pool %q: free space target of %d%% can't be reached"), p, target)
+				break
+			}
+
+			// User states are removed first, as they are only unlinked otherwise.
+			var states []*State
+			for _, user := range sortedUsers(s.Users) {
+				states = append(states, s.Users[user])
+			}
+			states = append(states, s)
+
+			var datasets []*zfs.Dataset
+			for _, st := range states {
+				for _, d := range st.getDatasets() {
+					if poolName(d.Name) != p || removedDatasets[d.Name] {
+						continue
+					}
+					datasets = append(datasets, d)
+				}
+			}
+			stateFreed := reclaimableSpace(datasets)
+			if explain {
+				log.RemotePrintf(ctx, i18n.G("  - %s: removed (%s)\n"), s.ID, units.HumanSize(stateFreed))
+			}
+
+			if dryrun {
+				for _, st := range states {
+					reportRemovedState(ctx, st, removedStates, removedDatasets)
+				}
+				freed += stateFreed
+				continue
+			}
+
+			log.Infof(ctx, i18n.G("Selecting state to remove: %s"), s.ID)
+			removed := true
+			for _, st := range states {
+				ms.bookmarkState(ctx, st)
+				if err := st.remove(ctx, ms, ""); err != nil {
+					log.Errorf(ctx, i18n.G("Couldn't fully destroy state %s: %v\nPutting it in keep list."), st.ID, err)
+					keepDueToErrorOnDelete[s.ID] = true
+					removed = false
+					break
+				}
+			}
+			// Only count space of fully removed states: a partial removal frees an unknown part of it.
+			if removed {
+				freed += stateFreed
+			}
+			if err := ms.Refresh(ctx); err != nil {
+				return fmt.Errorf("Couldn't refresh machine list: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// clonesByOrigin returns the list of clones for each origin snapshot, from the current datasets.
+// Datasets already removed in dry run mode are ignored.
+func (ms *Machines) clonesByOrigin(removedDatasets map[string]bool) map[string][]string {
+	byOrigin := make(map[string][]string)
+	for _, d := range ms.z.Datasets() {
+		if d.IsSnapshot || d.Origin == "" || removedDatasets[d.Name] {
+			continue
+		}
+		byOrigin[d.Origin] = append(byOrigin[d.Origin], d.Name)
+	}
+	return byOrigin
+}
+
+// zsysPools returns the sorted list of pools containing system or user datasets.
+func (ms *Machines) zsysPools() []string {
+	poolsMap := make(map[string]bool)
+	for _, d := range append(append([]*zfs.Dataset(nil), ms.allSystemDatasets...), ms.allUsersDatasets...) {
+		poolsMap[poolName(d.Name)] = true
+	}
+	pools := make([]string, 0, len(poolsMap))
+	for p := range poolsMap {
+		pools = append(pools, p)
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+// oldestAutomaticState returns the oldest automatic state having datasets on pool which can be removed under
+// free space pressure, or nil if there is none.
+// System snapshots come with their user snapshots, while user snapshots are only candidates on their own when
+// not associated to a system snapshot.
+func (ms *Machines) oldestAutomaticState(pool string, byOrigin map[string][]string, removedStates, keepStates map[string]bool) *State {
+	var candidates sortedReverseByTimeStates
+	for _, m := range ms.all {
+		if !m.isZsys() {
+			continue
+		}
+
+		systemSnapshots := make(map[string]bool)
+		for id, s := range m.History {
+			if removedStates[id] || !s.isSnapshot() {
+				continue
+			}
+			_, n := splitSnapshotName(id)
+			systemSnapshots[n] = true
+
+			states := []*State{s}
+			for _, us := range s.Users {
+				states = append(states, us)
+			}
+			if isFreeSpaceCandidate(pool, states, byOrigin, keepStates) {
+				candidates = append(candidates, s)
+			}
+		}
+
+		for _, us := range m.AllUsersStates {
+			for id, s := range us {
+				if removedStates[id] || !s.isSnapshot() {
+					continue
+				}
+				if _, n := splitSnapshotName(id); systemSnapshots[n] {
+					continue
+				}
+				if isFreeSpaceCandidate(pool, []*State{s}, byOrigin, keepStates) {
+					candidates = append(candidates, s)
+				}
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].LastUsed.Equal(candidates[j].LastUsed) {
+			return candidates[i].ID < candidates[j].ID
+		}
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+	return candidates[0]
+}
+
+// isFreeSpaceCandidate returns if states, being removed together, are automatic, not pinned, without clones
+// and have at least one dataset on pool.
+func isFreeSpaceCandidate(pool string, states []*State, byOrigin map[string][]string, keepStates map[string]bool) bool {
+	var onPool bool
+	for _, s := range states {
+		if keepStates[s.ID] || s.Pinned() || !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
+			return false
+		}
+		for _, d := range s.getDatasets() {
+			if byOrigin[d.Name] != nil {
+				return false
+			}
+			if poolName(d.Name) == pool {
+				onPool = true
+			}
+		}
+	}
+	return onPool
+}
+
+// poolName returns the pool of a dataset.
+func poolName(name string) string {
+	return strings.Split(name, "/")[0]
+}
+
+// sortedUsers returns user names of users states, sorted.
+func sortedUsers(users map[string]*State) []string {
+	r := make([]string, 0, len(users))
+	for user := range users {
+		r = append(r, user)
+	}
+	sort.Strings(r)
+	return r
+}
+
 func removeFromSlice(s []string, name string) (r []string) {
 	var i int
 	var v string
@@ -586,7 +997,8 @@ func (b bucket) String() string {
 }
 
 // selectStatesToRemove selects the maximum number of states to keep to fill a bucket up to samples and spread them evenly over the width of the bucket.
-// When 2 solutions are equal the first match is kept
+// When 2 solutions are equal the first match is kept.
+// Pinned states are always kept and count as samples of the bucket.
 func selectStatesToRemove(ctx context.Context, samples int, states []stateWithKeep) (statesToRemove []*State) {
 	log.Debug(ctx, "selecting list of states to remove")
 
@@ -616,7 +1028,7 @@ func selectStatesToRemove(ctx context.Context, samples int, states []stateWithKe
 	}
 	log.Debugf(ctx, "Interval: %.2f - %.2f", start, end)
 	for _, s := range states {
-		if s.keep == keepYes {
+		if s.keep == keepYes || s.Pinned() {
 			sumKeep += s.LastUsed.Unix()
 			toKeep = append(toKeep, s)
 			continue
@@ -682,15 +1094,78 @@ func selectStatesToRemove(ctx context.Context, samples int, states []stateWithKe
 
 // linkedToSystemState returns if a datasets is potentially linked to a system state.
 // Note that it doesn’t check if the system state is currently accessible.
-func (s *State) linkedToSystemState() bool {
+// States in ignoredStates are not considered as linked.
+func (s *State) linkedToSystemState(ignoredStates map[string]bool) bool {
 	for _, ds := range s.Datasets {
-		if ds[0].BootfsDatasets != "" {
+		for _, n := range strings.Split(ds[0].BootfsDatasets, bootfsdatasetsSeparator) {
+			if n != "" && !ignoredStates[n] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSnapshotInHierarchy returns if any dataset of a state route has snapshots.
+// In dry run, as datasets are not refreshed, we rely on snapshotsByDS which is kept up to date with simulated removals.
+func hasSnapshotInHierarchy(ds []*zfs.Dataset, snapshotsByDS map[string][]string, dryrun bool) bool {
+	if !dryrun {
+		return ds[0].HasSnapshotInHierarchy()
+	}
+	for _, d := range ds {
+		if !d.IsSnapshot && snapshotsByDS[d.Name] != nil {
 			return true
 		}
 	}
 	return false
 }
 
+// reportRemovedState records a state as removed in dry run and reports its datasets to the requester.
+func reportRemovedState(ctx context.Context, s *State, removedStates, removedDatasets map[string]bool) {
+	log.RemotePrintf(ctx, i18n.G("Deleting state %s\n"), s.ID)
+	removedStates[s.ID] = true
+	for _, d := range s.getDatasets() {
+		if removedDatasets[d.Name] {
+			continue
+		}
+		log.RemotePrintf(ctx, i18n.G("Deleting dataset %s\n"), d.Name)
+		removedDatasets[d.Name] = true
+	}
+}
+
+// keepAll returns states marked as kept by a bucket keeping all of them.
+func keepAll(states []*State) []stateWithKeep {
+	r := make([]stateWithKeep, 0, len(states))
+	for _, s := range states {
+		r = append(r, stateWithKeep{State: s, keep: keepYes, reason: i18n.G("bucket keeps all states")})
+	}
+	return r
+}
+
+// explainBucket reports to the requester the bucket and why each of its states is kept or removed.
+func explainBucket(ctx context.Context, b bucket, states []stateWithKeep, statesToRemove []*State) {
+	if b.samples == -1 {
+		log.RemotePrintf(ctx, i18n.G("Bucket from %s to %s, keeping all states:\n"), b.start.Format(timeFormat), b.end.Format(timeFormat))
+	} else {
+		log.RemotePrintf(ctx, i18n.G("Bucket from %s to %s, keeping %d states:\n"), b.start.Format(timeFormat), b.end.Format(timeFormat), b.samples)
+	}
+
+	removed := make(map[*State]bool)
+	for _, s := range statesToRemove {
+		removed[s] = true
+	}
+	for _, s := range states {
+		switch {
+		case removed[s.State]:
+			log.RemotePrintf(ctx, i18n.G("  - %s: removed\n"), s.ID)
+		case s.keep == keepYes:
+			log.RemotePrintf(ctx, i18n.G("  - %s: kept (%s)\n"), s.ID, s.reason)
+		default:
+			log.RemotePrintf(ctx, i18n.G("  - %s: kept (bucket sample)\n"), s.ID)
+		}
+	}
+}
+
 const (
 	badNegInput = "combin: negative input"
 	badSetSize  = "combin: n < k"
@@ -88,6 +88,10 @@ func resolveOrigin(ctx context.Context, datasets []*zfs.Dataset, onlyOnMountpoin
 			origin = curDataset.Name
 
 		}
+		// datasets cloned from another machine start their own lineage
+		if curDataset.ClonedFrom != "" {
+			origin = ""
+		}
 		r[curDataset.Name] = &origin
 
 		if *r[curDataset.Name] == "" && !curDataset.IsSnapshot {
@@ -107,7 +111,7 @@ func resolveOrigin(ctx context.Context, datasets []*zfs.Dataset, onlyOnMountpoin
 				if *curOrig != d.Name {
 					continue
 				}
-				if d.Origin != "" {
+				if d.Origin != "" && d.ClonedFrom == "" {
 					*curOrig = d.Origin
 					break
 				}
@@ -187,6 +191,23 @@ func splitSnapshotName(name string) (string, string) {
 	return name[:i], name[i+1:]
 }
 
+// splitBookmarkName return base and trailing names of a bookmark
+func splitBookmarkName(name string) (string, string) {
+	i := strings.LastIndex(name, "#")
+	if i < 0 {
+		return name, ""
+	}
+	return name[:i], name[i+1:]
+}
+
+// splitSnapshotOrBookmarkName return base and trailing names of a snapshot or a bookmark
+func splitSnapshotOrBookmarkName(name string) (string, string) {
+	if strings.Contains(name, "#") {
+		return splitBookmarkName(name)
+	}
+	return splitSnapshotName(name)
+}
+
 // nameInBootfsDatasets returns if name is part of the bootfsdatsets list for d
 func nameInBootfsDatasets(name string, d zfs.Dataset) bool {
 	for _, bootfsDataset := range strings.Split(d.BootfsDatasets, bootfsdatasetsSeparator) {
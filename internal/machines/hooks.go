@@ -0,0 +1,136 @@
+package machines
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+const (
+	preSaveHooksDir  = "pre-save"
+	postSaveHooksDir = "post-save"
+)
+
+// saveHooksEnv returns the environment passed to save hooks for a given state.
+// user is empty for system states. result is only set for post-save hooks.
+// Datasets names are sorted and space separated.
+func saveHooksEnv(name, user string, datasets []*zfs.Dataset, result string) []string {
+	names := make([]string, 0, len(datasets))
+	for _, d := range datasets {
+		names = append(names, d.Name)
+	}
+	sort.Strings(names)
+	env := []string{
+		"ZSYS_STATE_NAME=" + name,
+		"ZSYS_USER=" + user,
+		"ZSYS_DATASETS=" + strings.Join(names, " "),
+	}
+	if result != "" {
+		env = append(env, "ZSYS_RESULT="+result)
+	}
+	return env
+}
+
+// runHooks runs in lexical order all executables in dir, with env appended to the current environment.
+// Their output is streamed to the requester. It stops on the first failing hook.
+// A non existing dir means that there are no hooks to run.
+// As hooks run with the daemon privileges, only hooks which can't be changed by other users are run: the hook,
+// dir and its parent need to be owned by root or the daemon user and not be writable by group or others.
+func runHooks(ctx context.Context, dir string, env []string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf(i18n.G("couldn't list hooks in %s: %v"), dir, err)
+	}
+
+	for _, d := range []string{dir, filepath.Dir(dir)} {
+		fi, err := os.Stat(d)
+		if err != nil {
+			return fmt.Errorf(i18n.G("couldn't check hooks directory %s: %v"), d, err)
+		}
+		if !isTrusted(fi) {
+			log.Warningf(ctx, i18n.G("Ignoring hooks in %s: %s can be modified by other users than root"), dir, d)
+			return nil
+		}
+	}
+
+	for _, e := range entries {
+		// Ignore hidden files, directories and non executables files.
+		if strings.HasPrefix(e.Name(), ".") || !e.Mode().IsRegular() || e.Mode().Perm()&0111 == 0 {
+			log.Debugf(ctx, "Ignoring %s in hooks directory", e.Name())
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		if !isTrusted(e) {
+			log.Warningf(ctx, i18n.G("Ignoring hook %s: it can be modified by other users than root"), path)
+			continue
+		}
+		log.Infof(ctx, i18n.G("Running hook %s"), path)
+
+		cmd := exec.CommandContext(ctx, path)
+		cmd.Env = append(os.Environ(), env...)
+		if err := runAndLog(ctx, cmd, e.Name()); err != nil {
+			return fmt.Errorf(i18n.G("hook %s failed: %v"), path, err)
+		}
+	}
+	return nil
+}
+
+// isTrusted returns if fi is owned by root or the daemon user, and isn't writable by group or others.
+func isTrusted(fi os.FileInfo) bool {
+	if fi.Mode().Perm()&0022 != 0 {
+		return false
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+	return st.Uid == 0 || int(st.Uid) == os.Geteuid()
+}
+
+// runAndLog runs cmd, streaming each line of its standard and error outputs prefixed by name to the requester.
+func runAndLog(ctx context.Context, cmd *exec.Cmd, name string) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, r := range []io.Reader{stdout, stderr} {
+		wg.Add(1)
+		go func(r io.Reader) {
+			defer wg.Done()
+			s := bufio.NewScanner(r)
+			for s.Scan() {
+				log.RemotePrintf(ctx, "%s: %s\n", name, s.Text())
+			}
+		}(r)
+	}
+	// Pipes need to be read entirely before waiting on the command.
+	wg.Wait()
+
+	return cmd.Wait()
+}
@@ -1,8 +1,13 @@
 package machines
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -512,6 +517,7 @@ func TestSelectStatesToRemove(t *testing.T) {
 		samples       int     // Number of slots in the bucket
 		statesToKeep  []int64 // List of seconds from startOfTime
 		statesToPlace []int64 // List of seconds from startOfTime
+		statesPinned  []int64 // List of seconds from startOfTime, not marked as keep
 		timeOffset    int64   // Seconds since epoch
 
 		wantStates []string
@@ -534,6 +540,11 @@ func TestSelectStatesToRemove(t *testing.T) {
 		"same timestamp - keep one":  {samples: 4, statesToKeep: []int64{1, 3, 5}, statesToPlace: []int64{2, 2, 4}, wantStates: []string{"p1", "p2"}},
 		"same timestamp - keep none": {samples: 3, statesToKeep: []int64{1, 3, 5}, statesToPlace: []int64{2, 2, 4}, wantStates: []string{"p0", "p1", "p2"}},
 		"same timestamp - keep all":  {samples: 6, statesToKeep: []int64{1, 3, 5}, statesToPlace: []int64{2, 2, 4}, wantStates: nil},
+
+		// pinned states
+		"pinned states count as kept samples":      {samples: 3, statesToKeep: []int64{1}, statesPinned: []int64{3, 5}, statesToPlace: []int64{2, 4}, wantStates: []string{"p0", "p1"}},
+		"pinned states are never removed":          {samples: 1, statesPinned: []int64{1, 3}, statesToPlace: []int64{2}, wantStates: []string{"p0"}},
+		"pinned states only in an overfull bucket": {samples: 1, statesPinned: []int64{1, 3, 5}, wantStates: nil},
 	}
 	for name, tc := range tests {
 		tc := tc
@@ -557,6 +568,16 @@ func TestSelectStatesToRemove(t *testing.T) {
 				}
 				states = append(states, stateWithKeep{State: &s, keep: keepUnknown})
 			}
+			for i, s := range tc.statesPinned {
+				lu := time.Unix(tc.timeOffset+s, 0)
+				id := "pin" + strconv.Itoa(i) // Unique index
+				s := State{
+					ID:       id,
+					LastUsed: lu,
+					Datasets: map[string][]*zfs.Dataset{id: {{Name: id, DatasetProp: zfs.DatasetProp{Pinned: true}}}},
+				}
+				states = append(states, stateWithKeep{State: &s, keep: keepUnknown})
+			}
 
 			got := selectStatesToRemove(context.Background(), tc.samples, states)
 			assertStatesToKeepMatch(t, tc.wantStates, got)
@@ -564,6 +585,99 @@ func TestSelectStatesToRemove(t *testing.T) {
 	}
 }
 
+func TestGCFreeSpaceWithRemovedClones(t *testing.T) {
+	t.Parallel()
+	const (
+		clone  = "rpool/ROOT/clone_20191228-1000"
+		origin = "rpool/ROOT/ubuntu_1234@autozsys_20191228-1000"
+	)
+	tests := map[string]struct {
+		dryrun bool
+	}{
+		"Origin of a clone destroyed by an earlier pass is removed":          {},
+		"Origin of a clone removed by an earlier pass in dry run is removed": {dryrun: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "gc_free_space.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ctx := context.Background()
+			ms, err := New(ctx, "", WithLibZFS(libzfs), WithTime(testutils.FixedTime{}),
+				WithConfig(filepath.Join("testdata", "confs", "free_space_pressure.conf")))
+			if err != nil {
+				t.Fatal("expected success but got an error scanning for machines", err)
+			}
+			libzfs.(*mock.LibZFS).SetPoolCapacity("rpool", "99")
+
+			// Earlier GC passes removed the clone, before the free space pressure one.
+			removedStates, removedDatasets := make(map[string]bool), make(map[string]bool)
+			if tc.dryrun {
+				removedStates[clone], removedDatasets[clone] = true, true
+			} else {
+				if err := ms.z.NewNoTransaction(ctx).Destroy(clone); err != nil {
+					t.Fatal("couldn't destroy clone:", err)
+				}
+				if err := ms.Refresh(ctx); err != nil {
+					t.Fatal("couldn't refresh machines:", err)
+				}
+			}
+
+			if err := ms.gcFreeSpace(ctx, tc.dryrun, false, removedStates, removedDatasets); err != nil {
+				t.Fatal("expected no error but got:", err)
+			}
+
+			if tc.dryrun {
+				assert.True(t, removedStates[origin], "origin of the removed clone should be removed in dry run")
+				return
+			}
+			for _, d := range ms.z.Datasets() {
+				assert.NotEqual(t, origin, d.Name, "origin of the destroyed clone should be removed")
+			}
+		})
+	}
+}
+
+func TestReclaimableSpace(t *testing.T) {
+	t.Parallel()
+
+	newDS := func(name string, used uint64) *zfs.Dataset {
+		return &zfs.Dataset{Name: name, IsSnapshot: strings.Contains(name, "@"), DatasetProp: zfs.DatasetProp{Used: used}}
+	}
+
+	tests := map[string]struct {
+		datasets []*zfs.Dataset
+
+		want uint64
+	}{
+		"One filesystem dataset":                             {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234", 100)}, want: 100},
+		"Snapshots only count their own space":               {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234@snap1", 10), newDS("rpool/ROOT/ubuntu_1234/var@snap1", 5)}, want: 15},
+		"Children are included in their parent":              {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234/var", 40), newDS("rpool/ROOT/ubuntu_1234", 100), newDS("rpool/ROOT/ubuntu_1234/var/lib", 20)}, want: 100},
+		"Snapshots are included in their filesystem dataset": {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234@snap1", 10), newDS("rpool/ROOT/ubuntu_1234", 100)}, want: 100},
+		"Snapshots of children are included in parent":       {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234/var@snap1", 10), newDS("rpool/ROOT/ubuntu_1234", 100)}, want: 100},
+		"Unrelated datasets are summed":                      {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234", 100), newDS("rpool/ROOT/ubuntu_12345", 50), newDS("rpool/USERDATA/user1_abcd", 25)}, want: 175},
+		"Duplicated datasets are counted once":               {datasets: []*zfs.Dataset{newDS("rpool/ROOT/ubuntu_1234", 100), newDS("rpool/ROOT/ubuntu_1234", 100)}, want: 100},
+
+		"No dataset": {want: 0},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, reclaimableSpace(tc.datasets), "didn't get expected reclaimable space")
+		})
+	}
+}
+
 func assertStatesToKeepMatch(t *testing.T, want []string, got []*State) {
 	var gotIDs []string
 
@@ -741,3 +855,114 @@ foundState:
 	}
 	return s
 }
+
+func TestStreamChunks(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		streams   []string
+		chunkSize int
+		// skipChunk removes a chunk entry from the container
+		skipChunk string
+
+		wantErr bool
+	}{
+		"One stream smaller than a chunk":   {streams: []string{"abc"}, chunkSize: 4},
+		"One stream of exactly one chunk":   {streams: []string{"abcd"}, chunkSize: 4},
+		"One stream over multiple chunks":   {streams: []string{"abcdefghij"}, chunkSize: 4},
+		"Multiple streams":                  {streams: []string{"abcdefghij", "klm", "nopqrstu"}, chunkSize: 4},
+		"Empty streams are kept":            {streams: []string{"", "abcde", ""}, chunkSize: 4},
+		"Chunk of one byte":                 {streams: []string{"abc", "de"}, chunkSize: 1},
+		"Chunk bigger than every stream":    {streams: []string{"abc", "de"}, chunkSize: 1024},
+		"Error on missing chunk of stream":  {streams: []string{"abcdefghij"}, chunkSize: 4, skipChunk: "streams/0/000001", wantErr: true},
+		"Error on missing stream":           {streams: []string{"abc", "def"}, chunkSize: 4, skipChunk: "streams/0/000000", wantErr: true},
+		"Error on missing last chunk entry": {streams: []string{"abcdefghij", "klm"}, chunkSize: 4, skipChunk: "streams/1/000000", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var container bytes.Buffer
+			tw := tar.NewWriter(&container)
+			buf := make([]byte, tc.chunkSize)
+			for i, s := range tc.streams {
+				w := &chunkWriter{tw: tw, prefix: fmt.Sprintf("streams/%d", i), buf: buf[:0]}
+				// Write byte by byte and all at once to exercise buffering
+				if _, err := w.Write([]byte(s[:len(s)/2])); err != nil {
+					t.Fatalf("setup failed: couldn't write stream: %v", err)
+				}
+				for _, c := range []byte(s[len(s)/2:]) {
+					if _, err := w.Write([]byte{c}); err != nil {
+						t.Fatalf("setup failed: couldn't write stream: %v", err)
+					}
+				}
+				if len(w.buf) > 0 || w.n == 0 {
+					if err := w.flush(); err != nil {
+						t.Fatalf("setup failed: couldn't flush stream: %v", err)
+					}
+				}
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatalf("setup failed: couldn't close container: %v", err)
+			}
+
+			if tc.skipChunk != "" {
+				container = withoutEntry(t, container, tc.skipChunk)
+			}
+
+			c := &containerReader{tr: tar.NewReader(&container)}
+			for i, want := range tc.streams {
+				r, err := c.stream(fmt.Sprintf("streams/%d", i))
+				var got []byte
+				if err == nil {
+					got, err = ioutil.ReadAll(r)
+				}
+				if err != nil {
+					if !tc.wantErr {
+						t.Fatalf("expected no error reading stream %d but got: %v", i, err)
+					}
+					return
+				}
+				assert.Equal(t, want, string(got), "stream %d content", i)
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error reading streams but got none")
+			}
+
+			_, err := c.next()
+			assert.Equal(t, io.EOF, err, "every entry is consumed")
+		})
+	}
+}
+
+// withoutEntry returns a copy of container without the entry name.
+func withoutEntry(t *testing.T, container bytes.Buffer, name string) bytes.Buffer {
+	t.Helper()
+
+	var b bytes.Buffer
+	tr := tar.NewReader(&container)
+	tw := tar.NewWriter(&b)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("setup failed: couldn't read container: %v", err)
+		}
+		if h.Name == name {
+			continue
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatalf("setup failed: couldn't write container: %v", err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			t.Fatalf("setup failed: couldn't write container: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("setup failed: couldn't close container: %v", err)
+	}
+	return b
+}
@@ -1,16 +1,13 @@
 package machines
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"path/filepath"
 	"sort"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	"github.com/ubuntu/zsys/internal/config"
@@ -21,7 +18,7 @@ import (
 )
 
 // Machines hold a zfs system states, with a map of main root system dataset name to a given Machine,
-// current machine and nextState if an upgrade has been proceeded.
+// current machine and nextState if a boot on a history state has been scheduled.
 type Machines struct {
 	all                   map[string]*Machine
 	cmdline               string
@@ -33,9 +30,10 @@ type Machines struct {
 	// cantmount noauto or off datasets, which are not system, users or persistent
 	unmanagedDatasets []*zfs.Dataset
 
-	z    *zfs.Zfs
-	conf config.ZConfig
-	time Nower
+	z        *zfs.Zfs
+	conf     config.ZConfig
+	time     Nower
+	hooksDir string
 }
 
 // Machine is a group of Main and its History children states
@@ -52,6 +50,8 @@ type Machine struct {
 	// PersistentDatasets are all datasets that are canmount=on and and not in ROOT, USERDATA or BOOT dataset containers.
 	// Those are common between all machines, as persistent (and detected without snapshot information)
 	PersistentDatasets []*zfs.Dataset `json:",omitempty"`
+	// Bookmarks are bookmarks of system and user filesystem datasets of this machine, kept from removed states.
+	Bookmarks []*zfs.Dataset `json:",omitempty"`
 }
 
 // State is a finite regroupement of multiple ID and elements corresponding to a bootable machine instance.
@@ -92,8 +92,20 @@ func WithConfig(path string) func(o *options) error {
 	}
 }
 
+// WithHooksDir allows overriding the default hooks directory
+func WithHooksDir(path string) func(o *options) error {
+	return func(o *options) error {
+		if path == "" {
+			return nil
+		}
+		o.hooksDir = path
+		return nil
+	}
+}
+
 type options struct {
 	configPath string
+	hooksDir   string
 	libzfs     libzfs.Interface
 	time       Nower
 }
@@ -116,6 +128,7 @@ func New(ctx context.Context, cmdline string, opts ...option) (Machines, error)
 	log.Info(ctx, i18n.G("Building new machines list"))
 	args := options{
 		configPath: config.DefaultPath,
+		hooksDir:   config.DefaultHooksDir,
 		libzfs:     &libzfs.Adapter{},
 		time:       timeAdapter{},
 	}
@@ -136,11 +149,12 @@ func New(ctx context.Context, cmdline string, opts ...option) (Machines, error)
 	}
 
 	machines := Machines{
-		all:     make(map[string]*Machine),
-		cmdline: cmdline,
-		z:       z,
-		conf:    conf,
-		time:    args.time,
+		all:      make(map[string]*Machine),
+		cmdline:  cmdline,
+		z:        z,
+		conf:     conf,
+		time:     args.time,
+		hooksDir: args.hooksDir,
 	}
 	machines.refresh(ctx)
 	return machines, nil
@@ -156,18 +170,168 @@ func (ms *Machines) Refresh(ctx context.Context) error {
 	return nil
 }
 
+// Fork returns machines from a new scan of zfs datasets, with the same configuration as ms.
+// Changes made on the fork don't affect ms and both can be used concurrently.
+func (ms *Machines) Fork(ctx context.Context) (*Machines, error) {
+	z, err := ms.z.Fork(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Machines{
+		cmdline:  ms.cmdline,
+		z:        z,
+		conf:     ms.conf,
+		time:     ms.time,
+		hooksDir: ms.hooksDir,
+	}
+	n.refresh(ctx)
+	return n, nil
+}
+
+// Copy returns a deep copy of the machines and their states, which isn't affected by further changes on ms.
+// Datasets are copied too, so that the copy can be read while ms is modified. Only read methods should be called
+// on the copy, as it shares the zfs handle with ms.
+func (ms *Machines) Copy() *Machines {
+	c := machinesCopier{
+		machines: make(map[*Machine]*Machine),
+		states:   make(map[*State]*State),
+		datasets: make(map[*zfs.Dataset]*zfs.Dataset),
+	}
+
+	n := *ms
+	n.all = make(map[string]*Machine, len(ms.all))
+	for id, m := range ms.all {
+		n.all[id] = c.machine(m)
+	}
+	n.current = c.machine(ms.current)
+	n.nextState = c.state(ms.nextState)
+	n.allSystemDatasets = c.datasetsList(ms.allSystemDatasets)
+	n.allUsersDatasets = c.datasetsList(ms.allUsersDatasets)
+	n.allPersistentDatasets = c.datasetsList(ms.allPersistentDatasets)
+	n.unmanagedDatasets = c.datasetsList(ms.unmanagedDatasets)
+
+	return &n
+}
+
+// machinesCopier deep copies machines elements, keeping elements referenced multiple times shared in the copy.
+type machinesCopier struct {
+	machines map[*Machine]*Machine
+	states   map[*State]*State
+	datasets map[*zfs.Dataset]*zfs.Dataset
+}
+
+func (c machinesCopier) machine(m *Machine) *Machine {
+	if m == nil {
+		return nil
+	}
+	if n, ok := c.machines[m]; ok {
+		return n
+	}
+
+	n := &Machine{
+		IsZsys:             m.IsZsys,
+		PersistentDatasets: c.datasetsList(m.PersistentDatasets),
+		Bookmarks:          c.datasetsList(m.Bookmarks),
+	}
+	c.machines[m] = n
+	c.states[&m.State] = &n.State
+	n.State = *c.stateContent(&m.State)
+
+	if m.AllUsersStates != nil {
+		n.AllUsersStates = make(map[string]map[string]*State, len(m.AllUsersStates))
+		for user, states := range m.AllUsersStates {
+			n.AllUsersStates[user] = c.statesMap(states)
+		}
+	}
+	n.History = c.statesMap(m.History)
+
+	return n
+}
+
+func (c machinesCopier) state(s *State) *State {
+	if s == nil {
+		return nil
+	}
+	if n, ok := c.states[s]; ok {
+		return n
+	}
+	n := &State{}
+	c.states[s] = n
+	*n = *c.stateContent(s)
+	return n
+}
+
+// stateContent copies s content, without registering s itself.
+func (c machinesCopier) stateContent(s *State) *State {
+	n := &State{
+		ID:       s.ID,
+		LastUsed: s.LastUsed,
+	}
+	if s.Datasets != nil {
+		n.Datasets = make(map[string][]*zfs.Dataset, len(s.Datasets))
+		for id, ds := range s.Datasets {
+			n.Datasets[id] = c.datasetsList(ds)
+		}
+	}
+	n.Users = c.statesMap(s.Users)
+	return n
+}
+
+func (c machinesCopier) statesMap(states map[string]*State) map[string]*State {
+	if states == nil {
+		return nil
+	}
+	n := make(map[string]*State, len(states))
+	for k, s := range states {
+		n[k] = c.state(s)
+	}
+	return n
+}
+
+func (c machinesCopier) datasetsList(ds []*zfs.Dataset) []*zfs.Dataset {
+	if ds == nil {
+		return nil
+	}
+	n := make([]*zfs.Dataset, 0, len(ds))
+	for _, d := range ds {
+		n = append(n, c.dataset(d))
+	}
+	return n
+}
+
+func (c machinesCopier) dataset(d *zfs.Dataset) *zfs.Dataset {
+	if d == nil {
+		return nil
+	}
+	if n, ok := c.datasets[d]; ok {
+		return n
+	}
+	n := *d
+	c.datasets[d] = &n
+	return &n
+}
+
 // refresh reloads the list of machines, based on already loaded zfs datasets state
 func (ms *Machines) refresh(ctx context.Context) {
 	machines := Machines{
-		all:     make(map[string]*Machine),
-		cmdline: ms.cmdline,
-		z:       ms.z,
-		conf:    ms.conf,
-		time:    ms.time,
+		all:      make(map[string]*Machine),
+		cmdline:  ms.cmdline,
+		z:        ms.z,
+		conf:     ms.conf,
+		time:     ms.time,
+		hooksDir: ms.hooksDir,
+	}
+
+	var datasets []*zfs.Dataset
+	for _, d := range machines.z.Datasets() {
+		// Replicated states are copies of existing machines and aren't managed as such.
+		if ms.conf.Replication.TargetPool != "" && strings.Split(d.Name, "/")[0] == ms.conf.Replication.TargetPool {
+			continue
+		}
+		datasets = append(datasets, d)
 	}
 
-	datasets := machines.z.Datasets()
-
 	// Sort datasets so that children datasets are after their parents.
 	sortedDataset := sortedDataset(datasets)
 	sort.Sort(sortedDataset)
@@ -390,6 +554,8 @@ func (ms *Machines) refresh(ctx context.Context) {
 		}
 	}
 
+	machines.attachBookmarks()
+
 	// Append unlinked boot datasets to ensure we will switch to noauto everything
 	machines.allSystemDatasets = appendDatasetIfNotPresent(machines.allSystemDatasets, boots, true)
 	machines.allPersistentDatasets = persistents
@@ -398,6 +564,9 @@ func (ms *Machines) refresh(ctx context.Context) {
 	root, _ := bootParametersFromCmdline(machines.cmdline)
 	m, _ := machines.findFromRoot(root)
 	machines.current = m
+	if id, _ := m.ScheduledRevert(); id != "" {
+		machines.nextState = m.History[id]
+	}
 
 	*ms = machines
 	l, err := log.LevelFromContext(ctx)
@@ -412,12 +581,51 @@ func (ms *Machines) refresh(ctx context.Context) {
 	}
 }
 
+// attachBookmarks attaches to each machine the bookmarks of its system and user filesystem datasets.
+func (ms *Machines) attachBookmarks() {
+	owners := make(map[string]*Machine)
+	for _, m := range ms.all {
+		datasets := append(m.getDatasets(), m.getUsersDatasets()...)
+		for _, h := range m.History {
+			datasets = append(datasets, h.getDatasets()...)
+		}
+		for _, states := range m.AllUsersStates {
+			for _, s := range states {
+				datasets = append(datasets, s.getDatasets()...)
+			}
+		}
+		for _, d := range datasets {
+			if !d.IsSnapshot {
+				owners[d.Name] = m
+			}
+		}
+	}
+
+	for _, b := range ms.z.Bookmarks() {
+		if fs, _ := splitBookmarkName(b.Name); owners[fs] != nil {
+			m := owners[fs]
+			m.Bookmarks = append(m.Bookmarks, b)
+		}
+	}
+}
+
 // populate attach main system datasets to machines and returns other types of datasets for later triage/attachment, alongside
 // a map to direct access to a given state and machine
 func (ms *Machines) populate(ctx context.Context, allDatasets []*zfs.Dataset, origins map[string]*string) (boots, userdatas, persistents, unmanagedDatasets []*zfs.Dataset) {
 	for _, d := range allDatasets {
 		// we are taking the d address. Ensure we have a local variable that isn’t going to be reused
 		d := d
+
+		// Explicitly excluded datasets are never part of a state, even in a system or user hierarchy.
+		if d.Excluded && !d.IsSnapshot {
+			if d.CanMount != "on" {
+				unmanagedDatasets = append(unmanagedDatasets, d)
+				continue
+			}
+			persistents = append(persistents, d)
+			continue
+		}
+
 		// Main active system dataset building up a machine
 		m := newMachineFromDataset(d, origins[d.Name])
 		if m != nil {
@@ -689,202 +897,56 @@ func (ms Machines) GetMachine(ID string) (*Machine, error) {
 	return machines[0], nil
 }
 
-// Info returns detailed machine informations.
-func (m Machine) Info(full bool) (string, error) {
-	var out bytes.Buffer
-	w := tabwriter.NewWriter(&out, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, i18n.G("Name:\t%s\n"), m.ID)
-	fmt.Fprintf(w, i18n.G("ZSys:\t%t\n"), m.isZsys())
-
-	// Main machine state
-	m.toWriter(w, false, full)
-
-	if full {
-		if len(m.PersistentDatasets) == 0 {
-			fmt.Fprintf(w, i18n.G("Persistent Datasets: None\n"))
-		} else {
-			fmt.Fprintf(w, i18n.G("Persistent Datasets:\n"))
-			for _, n := range m.PersistentDatasets {
-				fmt.Fprintf(w, i18n.G(" - %s\n"), n.Name)
-			}
-		}
-	}
-
-	// History
-	if len(m.History) > 0 {
-		fmt.Fprintf(w, i18n.G("History:\t\n"))
-	}
-	timeToState := make(map[string]*State)
-	for id, s := range m.History {
-		k := fmt.Sprintf("%010d_%s", s.LastUsed.Unix(), id)
-		timeToState[k] = s
-	}
+// List returns all the machines, starting with current one if any, then sorted by ID.
+func (ms Machines) List() []*Machine {
 	var keys []string
-	for k := range timeToState {
-		keys = append(keys, k)
-	}
-	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
-
-	for _, k := range keys {
-		timeToState[k].toWriter(w, true, full)
-	}
-
-	// Users
-	keys = nil
-	for k := range m.AllUsersStates {
+	for k := range ms.all {
 		keys = append(keys, k)
 	}
-	sort.Sort(sort.StringSlice(keys))
-
-	fmt.Fprintf(w, i18n.G("Users:\n"))
-
-	for _, user := range keys {
-		fmt.Fprintf(w, i18n.G("  - Name:\t%s\n"), user)
-
-		if len(m.AllUsersStates[user]) > 1 {
-			fmt.Fprintf(w, i18n.G("    History:\t\n"))
-		}
-
-		timeToState := make(map[string]*State)
-	nextUserState:
-		for id, s := range m.AllUsersStates[user] {
-			// exclude "current" user state fom history
-			for _, us := range m.State.Users {
-				if us == s {
-					continue nextUserState
-				}
-			}
-
-			k := fmt.Sprintf("%010d_%s", s.LastUsed.Unix(), id)
-			timeToState[k] = s
-		}
-		var keys []string
-		for k := range timeToState {
-			keys = append(keys, k)
-		}
-		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
-
-		for _, k := range keys {
-			s := timeToState[k]
-
-			// We can’t use s.ID here because some user states can be duplicated (user state attached to 2 system states)
-			// and we want to display the unique generated id to the user as it’s what should be used in RemoveState()
-			uid := strings.SplitN(k, "_", 2)[1]
-			if full {
-				var ud []string
-				for _, ds := range s.Datasets {
-					for _, d := range ds {
-						ud = append(ud, d.Name)
-					}
-				}
-				fmt.Fprintf(w, i18n.G("     - %s (%s): %s\n"), uid, s.LastUsed.Format("2006-01-02 15:04:05"), strings.Join(ud, ", "))
-				continue
-			}
-			fmt.Fprintf(w, i18n.G("     - %s (%s)\n"), uid, s.LastUsed.Format("2006-01-02 15:04:05"))
-		}
-	}
-	if err := w.Flush(); err != nil {
-		return "", err
-	}
-
-	return out.String(), nil
-}
+	sort.Strings(keys)
 
-// sortedDatasetNames returns a sorted dataset name list
-func sortedDatasetNames(datasets map[string][]*zfs.Dataset) (dNames []string) {
-	var keys []string
-	for k := range datasets {
-		keys = append(keys, k)
+	var r []*Machine
+	if ms.current != nil {
+		r = append(r, ms.current)
 	}
-	sort.Strings(keys)
 	for _, k := range keys {
-		for _, d := range datasets[k] {
-			dNames = append(dNames, d.Name)
+		if ms.all[k] == ms.current {
+			continue
 		}
+		r = append(r, ms.all[k])
 	}
-	return dNames
+	return r
 }
 
-// toWriter forwards dataset state to a writer
-func (s State) toWriter(w io.Writer, isHistory, full bool) {
-	var prefix string
-	if isHistory {
-		fmt.Fprintf(w, i18n.G("  - Name:\t%s\n"), s.ID)
-	}
-	lu := s.LastUsed.Format("2006-01-02 15:04:05")
-
-	if isHistory {
-		prefix = "    "
-	}
-	if !isHistory {
-		if s.Datasets[s.ID][0].Mounted {
-			lu = i18n.G("current")
-		}
-		fmt.Fprintf(w, i18n.G("%sLast Used:\t%s\n"), prefix, lu)
-	} else {
-		fmt.Fprintf(w, i18n.G("%sCreated on:\t%s\n"), prefix, lu)
-	}
-
-	if full {
-		fmt.Fprintf(w, i18n.G("%sLast Booted Kernel:\t%s\n"), prefix, s.Datasets[s.ID][0].LastBootedKernel)
-		fmt.Fprintf(w, i18n.G("%sSystem Datasets:\n"), prefix)
-
-		for _, n := range sortedDatasetNames(s.Datasets) {
-			fmt.Fprintf(w, i18n.G("%s\t- %s\n"), prefix, n)
-		}
-
-		if len(s.Users) > 0 {
-			fmt.Fprintf(w, i18n.G("%sUser Datasets:\n"), prefix)
-			for u, us := range s.Users {
-				fmt.Fprintf(w, i18n.G("%s\tUser: %s\n"), prefix, u)
-				for _, n := range sortedDatasetNames(us.Datasets) {
-					fmt.Fprintf(w, i18n.G("%s\t- %s\n"), prefix, n)
-				}
-			}
-		}
-	}
+// Config returns the configuration in use.
+func (ms Machines) Config() config.ZConfig {
+	return ms.conf
 }
 
-// List all the machines and a summary
-func (ms Machines) List() (string, error) {
-	var out bytes.Buffer
-	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
-
-	fmt.Fprint(w, i18n.G("ID\tZSys\tLast Used\n"))
-	fmt.Fprint(w, i18n.G("--\t----\t---------\n"))
-
-	var keys, presentationOrder []string
-	for k := range ms.all {
-		keys = append(keys, k)
-	}
-	sort.Sort(sort.StringSlice(keys))
-	var currentID string
-	if ms.current != nil {
-		currentID = ms.current.ID
-		presentationOrder = []string{currentID}
-	}
+// PoolSpace is the size and free space of a pool.
+type PoolSpace struct {
+	Pool string
+	// Size is the pool size in bytes.
+	Size uint64
+	// Free is the free space in percent of the pool size.
+	Free int
+}
 
-	for _, k := range keys {
-		if k == currentID {
-			continue
+// PoolsSpace returns the size and free space of pools containing system or user datasets, sorted by name.
+func (ms *Machines) PoolsSpace() ([]PoolSpace, error) {
+	var r []PoolSpace
+	for _, p := range ms.zsysPools() {
+		free, err := ms.z.GetPoolFreeSpace(p)
+		if err != nil {
+			return nil, err
 		}
-		presentationOrder = append(presentationOrder, k)
-	}
-
-	for _, id := range presentationOrder {
-		m := ms.all[id]
-		lu := m.LastUsed.Format("2006-01-02 15:04:05")
-		if id == currentID {
-			lu = i18n.G("current")
+		size, err := ms.z.GetPoolSize(p)
+		if err != nil {
+			return nil, err
 		}
-		fmt.Fprintf(w, i18n.G("%s\t%t\t%s\n"), m.ID, m.IsZsys, lu)
-	}
-
-	if err := w.Flush(); err != nil {
-		return "", err
+		r = append(r, PoolSpace{Pool: p, Size: size, Free: free})
 	}
-
-	return out.String(), nil
+	return r, nil
 }
 
 // Reload reloads the configuration from disk
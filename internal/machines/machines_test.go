@@ -1,9 +1,13 @@
 package machines_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -12,6 +16,7 @@ import (
 	"github.com/k0kubun/pp"
 	"github.com/stretchr/testify/assert"
 	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/machines"
 	"github.com/ubuntu/zsys/internal/testutils"
 	"github.com/ubuntu/zsys/internal/zfs"
@@ -176,6 +181,123 @@ func TestIdempotentNew(t *testing.T) {
 	assertMachinesEquals(t, got1, got2)
 }
 
+func TestCopy(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "m_with_userdata.yaml"), testutils.WithLibZFS(libzfs))
+	defer fPools.Create(dir)()
+
+	ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+	if err != nil {
+		t.Fatal("expected success but got an error scanning for machines", err)
+	}
+	initMachines := ms.CopyForTests(t)
+
+	got := ms.Copy()
+	assertMachinesEquals(t, initMachines, *got)
+
+	if _, err := ms.CreateSystemSnapshot(context.Background(), "snap1", "", ""); err != nil {
+		t.Fatal("expected success but got an error saving state", err)
+	}
+	if err := ms.UpdateLastUsed(context.Background()); err != nil {
+		t.Fatal("expected success but got an error updating last used", err)
+	}
+	assertMachinesNotEquals(t, initMachines, ms)
+
+	assertMachinesEquals(t, initMachines, *got)
+	m, err := got.GetMachine("")
+	if err != nil {
+		t.Fatal("expected current machine in copy but got an error", err)
+	}
+	assert.Same(t, m, got.List()[0], "current machine should point to the copied machine in list")
+}
+
+func TestFork(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "m_with_userdata.yaml"), testutils.WithLibZFS(libzfs))
+	defer fPools.Create(dir)()
+
+	ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+	if err != nil {
+		t.Fatal("expected success but got an error scanning for machines", err)
+	}
+	initMachines := ms.CopyForTests(t)
+
+	got, err := ms.Fork(context.Background())
+	if err != nil {
+		t.Fatal("expected success but got an error forking machines", err)
+	}
+	assertMachinesEquals(t, initMachines, *got)
+
+	if _, err := got.CreateSystemSnapshot(context.Background(), "snap1", "", ""); err != nil {
+		t.Fatal("expected success but got an error saving state on fork", err)
+	}
+	assertMachinesNotEquals(t, initMachines, *got)
+	assertMachinesEquals(t, initMachines, ms)
+
+	// Changes on the fork are seen by ms once rescanned
+	if err := ms.Refresh(context.Background()); err != nil {
+		t.Fatal("expected success but got an error refreshing machines", err)
+	}
+	assertMachinesEquals(t, *got, ms)
+}
+
+func TestPoolsSpace(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def          string
+		setCapOnPool string
+		capValue     string
+
+		want    []machines.PoolSpace
+		wantErr bool
+	}{
+		"One pool": {def: "m_with_userdata.yaml", setCapOnPool: "rpool", capValue: "80",
+			want: []machines.PoolSpace{{Pool: "rpool", Size: 107374182400, Free: 20}}},
+		"Separate boot pool": {def: "m_clone_with_separate_boot.yaml", setCapOnPool: "bpool", capValue: "90",
+			want: []machines.PoolSpace{{Pool: "bpool", Size: 107374182400, Free: 10}, {Pool: "rpool", Size: 107374182400, Free: 70}}},
+		"No zsys pool": {def: "d_no_machine.yaml", want: nil},
+
+		"Error on invalid pool capacity": {def: "m_with_userdata.yaml", setCapOnPool: "rpool", capValue: "NaN", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), "", machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatal("expected success but got an error scanning for machines", err)
+			}
+			if tc.setCapOnPool != "" {
+				libzfs.(*mock.LibZFS).SetPoolCapacity(tc.setCapOnPool, tc.capValue)
+			}
+
+			got, err := ms.PoolsSpace()
+			if tc.wantErr {
+				assert.Error(t, err, "PoolsSpace should fail")
+				return
+			}
+			assert.NoError(t, err, "PoolsSpace should succeed")
+			assert.Equal(t, tc.want, got, "pools space")
+		})
+	}
+}
+
 func TestBoot(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -226,6 +348,10 @@ func TestBoot(t *testing.T) {
 			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_1234@snap1"),
 			mountedDataset: "rpool/ROOT/ubuntu_4242"},
 
+		"Reverting userdata, scheduled from running system": {def: "m_clone_with_userdata_with_children_scheduled_revert.yaml",
+			cmdline:        generateCmdLine("rpool/ROOT/ubuntu_1234@snap1"),
+			mountedDataset: "rpool/ROOT/ubuntu_4242"},
+
 		// Booting on snapshot on real machines
 		"Desktop revert on snapshot": {def: "m_layout1_machines_with_snapshots_clones_reverting.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678@snap3"), mountedDataset: "rpool/ROOT/ubuntu_4242"},
 		"Desktop revert on snapshot with userdata revert": {def: "m_layout1_machines_with_snapshots_clones_reverting.yaml",
@@ -441,6 +567,9 @@ func TestCommit(t *testing.T) {
 		"Separate user dataset with children, user revert":                     {def: "m_clone_with_userdata_with_children_to_promote_user_revert.yaml", cmdline: generateCmdLineWithRevert("rpool/ROOT/ubuntu_5678")},
 		"Separate user dataset with children manually created, user revert":    {def: "m_clone_with_userdata_with_children_manually_created_to_promote_user_revert.yaml", cmdline: generateCmdLineWithRevert("rpool/ROOT/ubuntu_5678")},
 
+		"Separate user dataset, scheduled user revert": {def: "m_clone_with_userdata_to_promote_scheduled_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678")},
+		"Scheduled revert not booted is discarded":     {def: "m_clone_with_userdata_to_promote_scheduled_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+
 		"Separate boot":                                {def: "m_clone_with_separate_boot_to_promote.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678")},
 		"Separate boot with children":                  {def: "m_clone_with_separate_boot_with_children_to_promote.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678")},
 		"Separate boot with children manually created": {def: "m_clone_with_separate_boot_with_children_manually_created_to_promote.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678")},
@@ -543,6 +672,241 @@ func TestIdempotentCommit(t *testing.T) {
 	assertMachinesEquals(t, msAfterCommit, ms)
 }
 
+func TestRevertState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def            string
+		currentStateID string
+		state          string
+		revertUserData bool
+
+		setPropertyErr bool
+
+		wantErr bool
+	}{
+		"Revert to snapshot":                  {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "rpool/ROOT/ubuntu_1234@snap1"},
+		"Revert to snapshot with user data":   {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "rpool/ROOT/ubuntu_1234@snap1", revertUserData: true},
+		"Revert to clone":                     {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "rpool/ROOT/ubuntu_5678"},
+		"Revert to short state id":            {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "5678"},
+		"Revert overrides a scheduled revert": {def: "m_clone_with_userdata_with_children_scheduled_revert.yaml", state: "rpool/ROOT/ubuntu_5678"},
+
+		"Error on no state given":                  {def: "m_clone_with_userdata_with_children_reverting.yaml", wantErr: true},
+		"Error on unknown state":                   {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "rpool/ROOT/ubuntu_9999", wantErr: true},
+		"Error on reverting to current state":      {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "rpool/ROOT/ubuntu_1234", wantErr: true},
+		"Error on reverting to another machine":    {def: "m_two_machines_simple.yaml", state: "rpool/ROOT/ubuntu_5678", wantErr: true},
+		"Error on non zsys machine":                {def: "d_one_machine_one_dataset_non_zsys.yaml", currentStateID: "rpool", state: "rpool", wantErr: true},
+		"Error on setting scheduled boot property": {def: "m_clone_with_userdata_with_children_reverting.yaml", state: "rpool/ROOT/ubuntu_5678", setPropertyErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			currentStateID := getDefaultValue(tc.currentStateID, "rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), generateCmdLine(currentStateID), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+
+			err = ms.RevertState(context.Background(), tc.state, tc.revertUserData)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), generateCmdLine(currentStateID), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestCloneMachine(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state        string
+		withUserData bool
+		bootOnClone  bool
+
+		wantErr bool
+	}{
+		"Clone system state":                           {state: "rpool/ROOT/ubuntu_1234@snap1"},
+		"Clone system state with user data":            {state: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true},
+		"Clone on short state id":                      {state: "snap2", withUserData: true},
+		"Clone state of a non current machine":         {state: "rpool/ROOT/ubuntu_5678@snap3"},
+		"Booting on cloned machine keeps it separated": {state: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true, bootOnClone: true},
+
+		"Error on unknown state":         {state: "rpool/ROOT/ubuntu_1234@snap9", wantErr: true},
+		"Error on cloning current state": {state: "rpool/ROOT/ubuntu_1234", wantErr: true},
+		"Error on cloning a user state":  {state: "rpool/USERDATA/user1_abcd@snap1", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_export.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ForceLastUsedTime(true)
+
+			id, err := ms.CloneMachine(context.Background(), tc.state, tc.withUserData)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			if _, err := ms.GetMachine(id); err != nil {
+				t.Errorf("expected %s to be a new machine but got: %v", id, err)
+			}
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			if tc.bootOnClone {
+				cmdline = generateCmdLine(id)
+				ms, err = machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+				if err != nil {
+					t.Error("expected success but got an error scanning for machines", err)
+				}
+				if _, err := ms.Commit(context.Background()); err != nil {
+					t.Fatalf("expected no error on commit but got: %v", err)
+				}
+			}
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestRemoveMachine(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def            string
+		currentStateID string
+		machine        string
+		force          bool
+		dryrun         bool
+
+		destroyErrDS []string
+
+		isNoOp              bool
+		wantErr             bool
+		wantConfirmationErr bool
+	}{
+		"Remove machine without dependencies":                            {def: "m_two_machines_simple.yaml", machine: "rpool/ROOT/ubuntu_5678"},
+		"Removing machine with history needs confirmation":               {def: "machine_remove.yaml", machine: "rpool/ROOT/ubuntu_5678", wantErr: true, wantConfirmationErr: true, isNoOp: true},
+		"Remove machine with history, clones and user datasets, forced":  {def: "machine_remove.yaml", machine: "rpool/ROOT/ubuntu_5678", force: true},
+		"Remove machine on short ID":                                     {def: "machine_remove.yaml", machine: "5678", force: true},
+		"Remove machine only detaches user datasets shared with another": {def: "machine_remove.yaml", machine: "rpool/ROOT/ubuntu_def0", force: true},
+		"Dry run doesn't remove anything":                                {def: "machine_remove.yaml", machine: "rpool/ROOT/ubuntu_5678", force: true, dryrun: true, isNoOp: true},
+
+		"Error on no machine given":                        {def: "machine_remove.yaml", wantErr: true, isNoOp: true},
+		"Error on unknown machine":                         {def: "machine_remove.yaml", machine: "rpool/ROOT/ubuntu_9999", wantErr: true, isNoOp: true},
+		"Error on removing current machine":                {def: "machine_remove.yaml", currentStateID: "rpool/ROOT/ubuntu_5678", machine: "rpool/ROOT/ubuntu_5678", force: true, wantErr: true, isNoOp: true},
+		"Error on removing machine another one depends on": {def: "machine_remove.yaml", currentStateID: "rpool/ROOT/ubuntu_5678", machine: "rpool/ROOT/ubuntu_1234", force: true, wantErr: true, isNoOp: true},
+		"Error on destroy machine":                         {def: "machine_remove.yaml", machine: "rpool/ROOT/ubuntu_5678", force: true, destroyErrDS: []string{}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			currentStateID := tc.currentStateID
+			if currentStateID == "" {
+				currentStateID = "rpool/ROOT/ubuntu_1234"
+			}
+			ms, err := machines.New(context.Background(), generateCmdLine(currentStateID), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnDestroyDS(tc.destroyErrDS)
+
+			err = ms.RemoveMachine(context.Background(), tc.machine, tc.force, tc.dryrun)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				var e *machines.ErrStateRemovalNeedsConfirmation
+				assert.Equal(t, tc.wantConfirmationErr, errors.As(err, &e), "didn't get expected ErrStateRemovalNeedsConfirmation error type")
+				if tc.isNoOp {
+					assertMachinesEquals(t, initMachines, ms)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), generateCmdLine(currentStateID), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
 func TestUpdateLastUsed(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -617,10 +981,17 @@ func TestUpdateLastUsed(t *testing.T) {
 func TestCreateUserData(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
-		def      string
-		user     string
-		homePath string
-		cmdline  string
+		def        string
+		user       string
+		homePath   string
+		cmdline    string
+		passphrase string
+		keyFile    string
+		configPath string
+		container  string
+		properties map[string]string
+
+		wantProperties map[string]map[string]string
 
 		setPropertyErr bool
 		createErr      bool
@@ -641,6 +1012,28 @@ func TestCreateUserData(t *testing.T) {
 		"Prefer system pool (try other pool) for userdata": {def: "m_without_userdata_prefer_system_pool.yaml", cmdline: generateCmdLine("rpool2/ROOT/ubuntu_1234")},
 		"No attached userdata on second pool":              {def: "m_no_attached_userdata_second_pool.yaml"},
 
+		// Encryption
+		"Encrypt user dataset with passphrase":        {def: "m_with_userdata.yaml", passphrase: "secret passphrase"},
+		"Encrypt user dataset with key file":          {def: "m_with_userdata.yaml", keyFile: "/path/to/key"},
+		"Encryption ignored on existing user dataset": {def: "m_with_userdata.yaml", user: "user1", passphrase: "secret passphrase"},
+		"Error on both passphrase and key file":       {def: "m_with_userdata.yaml", passphrase: "secret passphrase", keyFile: "/path/to/key", wantErr: true, isNoOp: true},
+		"Error on key file path not absolute":         {def: "m_with_userdata.yaml", keyFile: "path/to/key", wantErr: true, isNoOp: true},
+		"Error on too short passphrase":               {def: "m_with_userdata.yaml", passphrase: "secret", wantErr: true, isNoOp: true},
+
+		// Target container and properties
+		"Configured container and properties": {def: "m_without_userdata_prefer_system_pool.yaml", configPath: "userdata.conf",
+			wantProperties: map[string]map[string]string{
+				"rpool2/USERDATA":                {"compression": "", "quota": ""},
+				"rpool2/USERDATA/userfoo_xxxxxx": {"compression": "lz4", "quota": "10G"}}},
+		"Container and properties override configured ones": {def: "m_without_userdata_prefer_system_pool.yaml", configPath: "userdata.conf", container: "rpool", properties: map[string]string{"compression": "zstd", "recordsize": "1M"},
+			wantProperties: map[string]map[string]string{
+				"rpool/USERDATA":                {"compression": "", "recordsize": "", "quota": ""},
+				"rpool/USERDATA/userfoo_xxxxxx": {"compression": "zstd", "recordsize": "1M", "quota": "10G"}}},
+		"Container is a USERDATA dataset":        {def: "m_without_userdata_prefer_system_pool.yaml", container: "rpool2/USERDATA"},
+		"Container overrides user datasets pool": {def: "m_with_userdata_on_other_pool.yaml", container: "rpool"},
+		"Error on unsupported property":          {def: "m_with_userdata.yaml", properties: map[string]string{"mountpoint": "/foo"}, wantErr: true, isNoOp: true},
+		"Error on missing container pool":        {def: "m_with_userdata.yaml", configPath: "userdata_missing_pool.conf", wantErr: true, isNoOp: true},
+
 		// User or home edge cases
 		"No user set":                                           {def: "m_with_userdata.yaml", user: "[empty]", wantErr: true, isNoOp: true},
 		"No home path set":                                      {def: "m_with_userdata.yaml", homePath: "[empty]", wantErr: true, isNoOp: true},
@@ -674,7 +1067,8 @@ func TestCreateUserData(t *testing.T) {
 			lzfs := libzfs.(*mock.LibZFS)
 			lzfs.ForceLastUsedTime(true)
 
-			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			configPath := filepath.Join("testdata", "confs", getDefaultValue(tc.configPath, "default.conf"))
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs), machines.WithConfig(configPath))
 			if err != nil {
 				t.Error("expected success but got an error scanning for machines", err)
 			}
@@ -685,7 +1079,9 @@ func TestCreateUserData(t *testing.T) {
 			lzfs.ErrOnScan(tc.scanErr)
 			lzfs.ErrOnSetProperty(tc.setPropertyErr)
 
-			err = ms.CreateUserData(context.Background(), getDefaultValue(tc.user, "userfoo"), getDefaultValue(tc.homePath, "/home/foo"))
+			err = ms.CreateUserData(context.Background(), getDefaultValue(tc.user, "userfoo"), getDefaultValue(tc.homePath, "/home/foo"),
+				machines.WithPassphrase(tc.passphrase), machines.WithKeyFile(tc.keyFile),
+				machines.WithUserDataContainer(tc.container), machines.WithUserDataProperties(tc.properties))
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
@@ -703,6 +1099,10 @@ func TestCreateUserData(t *testing.T) {
 				assertMachinesNotEquals(t, initMachines, ms)
 			}
 
+			for name, props := range tc.wantProperties {
+				assertDatasetProperties(t, libzfs, name, props)
+			}
+
 			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
 			if err != nil {
 				t.Error("expected success but got an error scanning for machines", err)
@@ -806,31 +1206,262 @@ func TestDissociateUser(t *testing.T) {
 	}
 }
 
-func TestCurrentIsZsys(t *testing.T) {
+func TestExcludePath(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
+		def     string
+		path    string
 		cmdline string
 
-		wantIsZsys bool
+		wantDataset string
+		wantErr     bool
+		isNoOp      bool
 	}{
-		"Zsys machine is current":         {cmdline: generateCmdLine("rpool"), wantIsZsys: true},
-		"Zfs non zsys machine is current": {cmdline: generateCmdLine("rpool2"), wantIsZsys: false},
-		"No current machine":              {cmdline: generateCmdLine("something that doesn’t match"), wantIsZsys: false},
+		"Move system dataset with children and snapshots": {def: "m_with_excluded_datasets.yaml", path: "/var/lib/docker", wantDataset: "rpool/var/lib/docker"},
+		"Create dataset on empty path":                    {def: "m_with_excluded_datasets.yaml", path: "/var/lib/zsys-tests-doesnt-exist", wantDataset: "rpool/var/lib/zsys-tests-doesnt-exist"},
+		"Mark persistent dataset":                         {def: "m_with_excluded_datasets.yaml", path: "/opt", wantDataset: "rpool/opt"},
+		"Path is cleaned":                                 {def: "m_with_excluded_datasets.yaml", path: "/opt/", wantDataset: "rpool/opt"},
+		"Already excluded dataset":                        {def: "m_with_excluded_datasets.yaml", path: "/srv", wantDataset: "rpool/ROOT/ubuntu_1234/srv", isNoOp: true},
+
+		"Error on relative path":           {def: "m_with_excluded_datasets.yaml", path: "var/lib/docker", wantErr: true, isNoOp: true},
+		"Error on root path":               {def: "m_with_excluded_datasets.yaml", path: "/", wantErr: true, isNoOp: true},
+		"Error on boot path":               {def: "m_with_excluded_datasets.yaml", path: "/boot/efi", wantErr: true, isNoOp: true},
+		"Error on user data":               {def: "m_with_excluded_datasets.yaml", path: "/home/user1/.cache", wantErr: true, isNoOp: true},
+		"Error on existing target dataset": {def: "m_with_excluded_datasets.yaml", path: "/ROOT", wantErr: true, isNoOp: true},
+		"Error on non empty directory":     {def: "m_with_excluded_datasets.yaml", path: "/etc", wantErr: true, isNoOp: true},
+		"Error on non zsys machine":        {def: "m_with_userdata_no_zsys.yaml", path: "/var/lib/docker", wantErr: true, isNoOp: true},
 	}
 
 	for name, tc := range tests {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
+			tc.cmdline = getDefaultValue(tc.cmdline, generateCmdLine("rpool/ROOT/ubuntu_1234"))
+
 			dir, cleanup := testutils.TempDir(t)
 			defer cleanup()
-
 			libzfs := testutils.GetMockZFS(t)
-			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "d_two_machines_one_zsys_one_non_zsys.yaml"), testutils.WithLibZFS(libzfs))
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
 			defer fPools.Create(dir)()
 
-			/*if tc.mountedDataset != "" {
-				lzfs := libzfs.(*mock.LibZFS)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ForceLastUsedTime(true)
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			got, err := ms.ExcludePath(context.Background(), tc.path)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+			assert.Equal(t, tc.wantDataset, got, "unexpected excluded dataset")
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestCreatePersistent(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def          string
+		path         string
+		followSystem bool
+		cmdline      string
+
+		wantDataset string
+		wantErr     bool
+		isNoOp      bool
+	}{
+		"Create dataset on empty path":                 {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/zsys-tests-doesnt-exist", wantDataset: "rpool/var/lib/zsys-tests-doesnt-exist"},
+		"Create dataset following system states":       {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/zsys-tests-doesnt-exist", followSystem: true, wantDataset: "rpool/var/lib/zsys-tests-doesnt-exist"},
+		"Create dataset in existing persistent parent": {def: "m_with_persistent_follow_system.yaml", path: "/srv/zsys-tests-doesnt-exist", wantDataset: "rpool/srv/zsys-tests-doesnt-exist"},
+		"Path is cleaned":                              {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/zsys-tests-doesnt-exist/", wantDataset: "rpool/var/lib/zsys-tests-doesnt-exist"},
+
+		"Error on relative path":              {def: "m_with_persistent_follow_system.yaml", path: "var/lib/zsys-tests-doesnt-exist", wantErr: true, isNoOp: true},
+		"Error on root path":                  {def: "m_with_persistent_follow_system.yaml", path: "/", wantErr: true, isNoOp: true},
+		"Error on boot path":                  {def: "m_with_persistent_follow_system.yaml", path: "/boot/efi", wantErr: true, isNoOp: true},
+		"Error on user data":                  {def: "m_with_persistent_follow_system.yaml", path: "/home/user1/.cache", wantErr: true, isNoOp: true},
+		"Error on already persistent dataset": {def: "m_with_persistent_follow_system.yaml", path: "/opt", wantErr: true, isNoOp: true},
+		"Error on system dataset":             {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/docker", wantErr: true, isNoOp: true},
+		"Error on existing target dataset":    {def: "m_with_persistent_follow_system.yaml", path: "/ROOT", wantErr: true, isNoOp: true},
+		"Error on non empty directory":        {def: "m_with_persistent_follow_system.yaml", path: "/etc", wantErr: true, isNoOp: true},
+		"Error on non zsys machine":           {def: "m_with_userdata_no_zsys.yaml", path: "/var/lib/zsys-tests-doesnt-exist", wantErr: true, isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.cmdline = getDefaultValue(tc.cmdline, generateCmdLine("rpool/ROOT/ubuntu_1234"))
+
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ForceLastUsedTime(true)
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			got, err := ms.CreatePersistent(context.Background(), tc.path, tc.followSystem)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+			assert.Equal(t, tc.wantDataset, got, "unexpected persistent dataset")
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestMakePersistent(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def          string
+		path         string
+		followSystem bool
+		cmdline      string
+
+		setUserPropertyErr bool
+
+		wantDataset string
+		wantErr     bool
+		isNoOp      bool
+	}{
+		"Move system dataset with children and snapshots":     {def: "m_with_persistent_follow_system.yaml", path: "/var/lib", wantDataset: "rpool/var/lib"},
+		"Move system dataset following system states":         {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/docker", followSystem: true, wantDataset: "rpool/var/lib/docker"},
+		"Persistent dataset starts following system states":   {def: "m_with_persistent_follow_system.yaml", path: "/opt", followSystem: true, wantDataset: "rpool/opt"},
+		"Persistent dataset stops following system states":    {def: "m_with_persistent_follow_system.yaml", path: "/srv", wantDataset: "rpool/srv"},
+		"Child persistent dataset stops following its parent": {def: "m_with_persistent_follow_system.yaml", path: "/srv/www", wantDataset: "rpool/srv/www"},
+		"Persistent dataset already following system states":  {def: "m_with_persistent_follow_system.yaml", path: "/srv", followSystem: true, wantDataset: "rpool/srv", isNoOp: true},
+		"Persistent dataset already not following":            {def: "m_with_persistent_follow_system.yaml", path: "/opt", wantDataset: "rpool/opt", isNoOp: true},
+		"Path is cleaned": {def: "m_with_persistent_follow_system.yaml", path: "/opt/", followSystem: true, wantDataset: "rpool/opt"},
+
+		"Error on excluded dataset following system states": {def: "m_with_persistent_follow_system.yaml", path: "/var/cache", followSystem: true, wantErr: true, isNoOp: true},
+		"Error on path without system dataset":              {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/zsys-tests-doesnt-exist", wantErr: true, isNoOp: true},
+		"Error on relative path":                            {def: "m_with_persistent_follow_system.yaml", path: "var/lib", wantErr: true, isNoOp: true},
+		"Error on root path":                                {def: "m_with_persistent_follow_system.yaml", path: "/", wantErr: true, isNoOp: true},
+		"Error on user data":                                {def: "m_with_persistent_follow_system.yaml", path: "/home/user1", wantErr: true, isNoOp: true},
+		"Error on non zsys machine":                         {def: "m_with_userdata_no_zsys.yaml", path: "/var/lib", wantErr: true, isNoOp: true},
+		"Error after moving dataset reverts the move":       {def: "m_with_persistent_follow_system.yaml", path: "/var/lib/docker", followSystem: true, setUserPropertyErr: true, wantErr: true, isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.cmdline = getDefaultValue(tc.cmdline, generateCmdLine("rpool/ROOT/ubuntu_1234"))
+
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ForceLastUsedTime(true)
+			lzfs.ErrOnSetUserProperty(tc.setUserPropertyErr)
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			got, err := ms.MakePersistent(context.Background(), tc.path, tc.followSystem)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+			assert.Equal(t, tc.wantDataset, got, "unexpected persistent dataset")
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestCurrentIsZsys(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		cmdline string
+
+		wantIsZsys bool
+	}{
+		"Zsys machine is current":         {cmdline: generateCmdLine("rpool"), wantIsZsys: true},
+		"Zfs non zsys machine is current": {cmdline: generateCmdLine("rpool2"), wantIsZsys: false},
+		"No current machine":              {cmdline: generateCmdLine("something that doesn’t match"), wantIsZsys: false},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "d_two_machines_one_zsys_one_non_zsys.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			/*if tc.mountedDataset != "" {
+				lzfs := libzfs.(*mock.LibZFS)
 				lzfs.SetDatasetAsMounted(tc.mountedDataset, true)
 			}*/
 
@@ -927,21 +1558,35 @@ func TestCreateSystemSnapshot(t *testing.T) {
 		def          string
 		cmdline      string
 		snapshotName string
+		description  string
+		label        string
 
 		setCapOnPool string
 		capValue     string
 
+		cancelled bool
+
 		wantErr bool
 		isNoOp  bool
 	}{
 		"Take one snapshot":       {def: "m_with_userdata.yaml"},
 		"Give a name to snapshot": {def: "m_with_userdata.yaml", snapshotName: "my_snapshot"},
 
+		// Annotations
+		"Take snapshot with description and label":      {def: "m_with_userdata.yaml", description: "before nvidia driver", label: "pre-upgrade"},
+		"Take snapshot with description only":           {def: "m_with_userdata.yaml", description: "before nvidia driver"},
+		"Error on multiline description":                {def: "m_with_userdata.yaml", description: "before\nnvidia driver", wantErr: true, isNoOp: true},
+		"Error on label with unsupported characters":    {def: "m_with_userdata.yaml", label: "pre upgrade", wantErr: true, isNoOp: true},
+		"Error on label starting with unsupported char": {def: "m_with_userdata.yaml", label: "-pre-upgrade", wantErr: true, isNoOp: true},
+
 		"Children on system datasets": {def: "m_with_userdata_children_on_system.yaml"},
 		"Children on user datasets":   {def: "m_with_userdata_children_on_user.yaml"},
 		"Children on user datasets with one child non associated with current machine": {def: "m_with_userdata_child_associated_one_state.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_9999")},
 
-		"No associated userdata": {def: "d_one_machine_with_children.yaml", cmdline: generateCmdLine("rpool")},
+		"No associated userdata":                      {def: "d_one_machine_with_children.yaml", cmdline: generateCmdLine("rpool")},
+		"Excluded datasets":                           {def: "m_with_excluded_datasets.yaml"},
+		"Persistent datasets following system states": {def: "m_with_persistent_follow_system.yaml"},
+		"Locked user dataset":                         {def: "m_with_userdata_locked.yaml"},
 
 		// Free space handling
 		"Not enough free space on system pool":                {def: "m_with_userdata_on_other_pool.yaml", setCapOnPool: "rpool", capValue: "99", wantErr: true},
@@ -958,6 +1603,8 @@ func TestCreateSystemSnapshot(t *testing.T) {
 		"Error when name starts with dash":            {def: "m_with_userdata.yaml", snapshotName: "-my_snapshot", wantErr: true, isNoOp: true},
 		"Error when name contains invalid characters": {def: "m_with_userdata.yaml", snapshotName: "my, snäpshôt, is beautiful,", wantErr: true, isNoOp: true},
 
+		"Cancelled request": {def: "m_with_userdata.yaml", cancelled: true, wantErr: true, isNoOp: true},
+
 		"Non zsys":   {def: "m_with_userdata_no_zsys.yaml", wantErr: true, isNoOp: true},
 		"No machine": {def: "m_with_userdata_no_zsys.yaml", cmdline: generateCmdLine("rpool/ROOT/nomachine"), wantErr: true, isNoOp: true},
 	}
@@ -989,11 +1636,26 @@ func TestCreateSystemSnapshot(t *testing.T) {
 
 			initMachines := ms.CopyForTests(t)
 
-			snapshotName, err := ms.CreateSystemSnapshot(context.Background(), tc.snapshotName)
+			ctx := context.Background()
+			if tc.cancelled {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				cancel()
+			}
+
+			snapshotName, err := ms.CreateSystemSnapshot(ctx, tc.snapshotName, tc.description, tc.label)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
 				}
+				if tc.cancelled {
+					// Nothing is left behind by the reverted transaction
+					machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+					if err != nil {
+						t.Error("expected success but got an error scanning for machines", err)
+					}
+					assertMachinesEquals(t, initMachines, machinesAfterRescan)
+				}
 				return
 			}
 			if err == nil && tc.wantErr {
@@ -1027,6 +1689,143 @@ func TestCreateSystemSnapshot(t *testing.T) {
 	}
 }
 
+func TestSaveHooks(t *testing.T) {
+	t.Parallel()
+
+	const recordHook = "#!/bin/sh\necho \"running $(basename $0)\"\nenv | grep ^ZSYS_ | sort > \"$(dirname $0)/../$(basename $(dirname $0)).out\"\n"
+	const failingHook = "#!/bin/sh\necho \"something went wrong\" >&2\nexit 1\n"
+
+	tests := map[string]struct {
+		user         string
+		preHooks     map[string]string
+		postHooks    map[string]string
+		nonExecHook  bool
+		writableHook bool
+		writableDir  bool
+
+		wantPre  []string
+		wantPost []string
+		wantLogs []string
+		wantErr  bool
+	}{
+		"System save runs pre and post hooks": {
+			preHooks: map[string]string{"10-record": recordHook}, postHooks: map[string]string{"10-record": recordHook},
+			wantPre:  []string{"ZSYS_DATASETS=rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd", "ZSYS_STATE_NAME=my_state", "ZSYS_USER="},
+			wantPost: []string{"ZSYS_DATASETS=rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd", "ZSYS_RESULT=success", "ZSYS_STATE_NAME=my_state", "ZSYS_USER="},
+			wantLogs: []string{"10-record: running 10-record"}},
+		"User save runs pre and post hooks": {user: "user1",
+			preHooks: map[string]string{"10-record": recordHook}, postHooks: map[string]string{"10-record": recordHook},
+			wantPre:  []string{"ZSYS_DATASETS=rpool/USERDATA/user1_abcd", "ZSYS_STATE_NAME=my_state", "ZSYS_USER=user1"},
+			wantPost: []string{"ZSYS_DATASETS=rpool/USERDATA/user1_abcd", "ZSYS_RESULT=success", "ZSYS_STATE_NAME=my_state", "ZSYS_USER=user1"}},
+		"No hooks directory":                          {},
+		"Hidden and non executable hooks are ignored": {preHooks: map[string]string{".10-hidden": failingHook, "20-nonexec": failingHook}, nonExecHook: true},
+		"Hooks writable by others are ignored": {preHooks: map[string]string{"10-fail": failingHook}, writableHook: true,
+			wantLogs: []string{"can be modified by other users than root"}},
+		"Hooks in a directory writable by others are ignored": {preHooks: map[string]string{"10-fail": failingHook}, writableDir: true,
+			wantLogs: []string{"can be modified by other users than root"}},
+
+		"Failing post hook doesn't fail save": {postHooks: map[string]string{"10-fail": failingHook},
+			wantLogs: []string{"10-fail: something went wrong"}},
+		"Failing pre hook aborts save and runs post hooks": {
+			preHooks: map[string]string{"10-record": recordHook, "20-fail": failingHook}, postHooks: map[string]string{"10-record": recordHook},
+			wantPre:  []string{"ZSYS_DATASETS=rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd", "ZSYS_STATE_NAME=my_state", "ZSYS_USER="},
+			wantPost: []string{"ZSYS_DATASETS=rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd", "ZSYS_RESULT=failure", "ZSYS_STATE_NAME=my_state", "ZSYS_USER="},
+			wantLogs: []string{"20-fail: something went wrong"},
+			wantErr:  true},
+		"Pre hooks run in lexical order and stop on first failure": {
+			preHooks: map[string]string{"10-fail": failingHook, "20-record": recordHook},
+			wantErr:  true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "m_with_userdata.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			hooksDir := filepath.Join(dir, "hooks.d")
+			for subdir, hooks := range map[string]map[string]string{"pre-save": tc.preHooks, "post-save": tc.postHooks} {
+				if hooks == nil {
+					continue
+				}
+				if err := os.MkdirAll(filepath.Join(hooksDir, subdir), 0755); err != nil {
+					t.Fatalf("couldn't create hooks directory: %v", err)
+				}
+				for n, content := range hooks {
+					mode := os.FileMode(0755)
+					if tc.nonExecHook && !strings.HasPrefix(n, ".") {
+						mode = 0644
+					}
+					if tc.writableHook {
+						mode = 0777
+					}
+					p := filepath.Join(hooksDir, subdir, n)
+					if err := ioutil.WriteFile(p, []byte(content), mode); err != nil {
+						t.Fatalf("couldn't write hook: %v", err)
+					}
+					// Bypass umask
+					if err := os.Chmod(p, mode); err != nil {
+						t.Fatalf("couldn't change hook mode: %v", err)
+					}
+				}
+				if tc.writableDir {
+					if err := os.Chmod(filepath.Join(hooksDir, subdir), 0777); err != nil {
+						t.Fatalf("couldn't change hooks directory mode: %v", err)
+					}
+				}
+			}
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs), machines.WithHooksDir(hooksDir))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			var out bytes.Buffer
+			ctx, err := log.ContextWithLogger(context.Background(), "test", "info", &out)
+			if err != nil {
+				t.Fatalf("couldn't create logger context: %v", err)
+			}
+
+			if tc.user == "" {
+				_, err = ms.CreateSystemSnapshot(ctx, "my_state", "", "")
+			} else {
+				_, err = ms.CreateUserSnapshot(ctx, tc.user, "my_state", "", "")
+			}
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+			} else if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			} else {
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			for f, want := range map[string][]string{"pre-save.out": tc.wantPre, "post-save.out": tc.wantPost} {
+				got, err := ioutil.ReadFile(filepath.Join(hooksDir, f))
+				if want == nil {
+					assert.True(t, os.IsNotExist(err), "%s hook shouldn't have been recorded", f)
+					continue
+				}
+				if err != nil {
+					t.Fatalf("couldn't read %s: %v", f, err)
+				}
+				assert.Equal(t, want, strings.Split(strings.TrimSpace(string(got)), "\n"), "didn't get expected environment in %s", f)
+			}
+			for _, l := range tc.wantLogs {
+				assert.Contains(t, out.String(), l, "hook output should be logged")
+			}
+		})
+	}
+}
+
 func TestCreateUserSnapshot(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -1034,6 +1833,8 @@ func TestCreateUserSnapshot(t *testing.T) {
 		cmdline      string
 		snapshotName string
 		userName     string
+		description  string
+		label        string
 
 		setCapOnPool string
 		capValue     string
@@ -1043,6 +1844,8 @@ func TestCreateUserSnapshot(t *testing.T) {
 	}{
 		"Take one snapshot":                                   {def: "m_with_userdata.yaml"},
 		"Give a name to snapshot":                             {def: "m_with_userdata.yaml", snapshotName: "my_snapshot"},
+		"Take snapshot with description and label":            {def: "m_with_userdata.yaml", description: "before cleaning home", label: "cleanup"},
+		"Error on invalid label":                              {def: "m_with_userdata.yaml", label: "clean up", wantErr: true, isNoOp: true},
 		"Take one snapshot on a machine with other snapshots": {def: "m_snapshot_with_userdata.yaml"},
 
 		"Children on user datasets": {def: "m_with_userdata_children_on_user.yaml"},
@@ -1098,7 +1901,7 @@ func TestCreateUserSnapshot(t *testing.T) {
 
 			initMachines := ms.CopyForTests(t)
 
-			snapshotName, err := ms.CreateUserSnapshot(context.Background(), tc.userName, tc.snapshotName)
+			snapshotName, err := ms.CreateUserSnapshot(context.Background(), tc.userName, tc.snapshotName, tc.description, tc.label)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
@@ -1187,6 +1990,14 @@ func TestRemoveState(t *testing.T) {
 		"Remove shared user state as a dependency of other state. Deps are removed":                        {def: "m_shared_userstate_on_clones.yaml", state: "rpool/USERDATA/user_abcd", user: "user", force: true},
 		"Remove shared user state on different matchines as a dependency of other state. Deps are removed": {def: "m_shared_userstate_on_two_machines.yaml", state: "rpool/USERDATA/user_abcd", user: "user", force: true},
 
+		// Persistent datasets following system states
+		"Remove system snapshot removes following persistent snapshots": {def: "m_with_persistent_follow_system.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap1", force: true},
+
+		// Pinned states
+		"Removing pinned system state needs confirmation": {def: "gc_system_only_with_pinned_snapshots.yaml", state: "rpool/ROOT/ubuntu_1234@autozsys_20191229-1800", wantErr: true, wantConfirmationErr: true, isNoOp: true},
+		"Remove pinned system state, forced":              {def: "gc_system_only_with_pinned_snapshots.yaml", state: "rpool/ROOT/ubuntu_1234@autozsys_20191229-1800", force: true},
+		"Removing pinned user state needs confirmation":   {def: "gc_system_with_users_pinned_clone.yaml", state: "rpool/USERDATA/user1_clone", user: "user1", wantErr: true, wantConfirmationErr: true, isNoOp: true},
+
 		"No state given": {def: "m_with_userdata.yaml", wantErr: true, isNoOp: true},
 		"Error on trying to remove current state":    {def: "m_with_userdata.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234", wantErr: true, isNoOp: true},
 		"Error on destroy state, one dataset":        {def: "m_with_userdata.yaml", state: "rpool/ROOT/ubuntu_1234", destroyErrDS: []string{}, wantErr: true, isNoOp: true},
@@ -1247,23 +2058,169 @@ func TestRemoveState(t *testing.T) {
 	}
 }
 
-func TestIDToState(t *testing.T) {
+func TestAnnotateState(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
-		name string
-		user string
+		def         string
+		state       string
+		user        string
+		description *string
+		label       *string
 
-		wantState string
-		wantErr   bool
-	}{
-		"Match full system path ID": {name: "rpool/ROOT/ubuntu_1234", wantState: "rpool/ROOT/ubuntu_1234"},
-		"Match full user path ID":   {name: "rpool/USERDATA/user1_abcd", user: "user1", wantState: "rpool/USERDATA/user1_abcd"},
+		setPropertyErr bool
 
-		"Match suffix system ID":       {name: "5678", wantState: "rpool/ROOT/ubuntu_5678"},
-		"Match dataset path system ID": {name: "ubuntu_5678", wantState: "rpool/ROOT/ubuntu_5678"},
-		"Match unique system snapshot": {name: "snap1", wantState: "rpool/ROOT/ubuntu_1234@snap1"},
+		wantErr bool
+	}{
+		"Annotate system snapshot":                  {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2", description: stringPtr("after kernel update"), label: stringPtr("post-upgrade")},
+		"Annotate system clone":                     {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_5678", description: stringPtr("testing clone")},
+		"Annotate current system state":             {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234", label: stringPtr("stable")},
+		"Annotate with short state id":              {def: "state_annotate.yaml", state: "snap2", label: stringPtr("post-upgrade")},
+		"Change description only keeps label":       {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap1", description: stringPtr("before nouveau removal")},
+		"Clear label":                               {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap1", label: stringPtr("")},
+		"Annotate user snapshot":                    {def: "state_annotate.yaml", state: "rpool/USERDATA/user1_abcd@snap2", user: "user1", description: stringPtr("before cleaning home")},
+		"Annotate user clone":                       {def: "state_annotate.yaml", state: "rpool/USERDATA/user1_efgh", user: "user1", label: stringPtr("old-home")},
+		"Annotate system state doesn't touch users": {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap1", label: stringPtr("system-only")},
+
+		"Error on no annotation":               {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2", wantErr: true},
+		"Error on no state given":              {def: "state_annotate.yaml", label: stringPtr("stable"), wantErr: true},
+		"Error on unknown state":               {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_9999", label: stringPtr("stable"), wantErr: true},
+		"Error on user state without user":     {def: "state_annotate.yaml", state: "rpool/USERDATA/user1_efgh", label: stringPtr("old-home"), wantErr: true},
+		"Error on invalid label":               {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2", label: stringPtr("post upgrade"), wantErr: true},
+		"Error on multiline description":       {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2", description: stringPtr("a\nb"), wantErr: true},
+		"Error on setting annotation property": {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2", label: stringPtr("post-upgrade"), setPropertyErr: true, wantErr: true},
+	}
 
-		"Limit search on duplicated snapshot name to a single user": {name: "snap1", user: "user1", wantState: "rpool/USERDATA/user1_abcd@snap1"},
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+
+			err = ms.AnnotateState(context.Background(), tc.state, tc.user, tc.description, tc.label)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestPinState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def   string
+		state string
+		user  string
+		unpin bool
+
+		setPropertyErr bool
+
+		wantErr bool
+	}{
+		"Pin system snapshot":  {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2"},
+		"Pin system clone":     {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_5678"},
+		"Pin user snapshot":    {def: "state_annotate.yaml", state: "rpool/USERDATA/user1_abcd@snap2", user: "user1"},
+		"Pin user clone":       {def: "state_annotate.yaml", state: "rpool/USERDATA/user1_efgh", user: "user1"},
+		"Unpin system state":   {def: "gc_system_only_with_pinned_snapshots.yaml", state: "rpool/ROOT/ubuntu_1234@autozsys_20191229-1800", unpin: true},
+		"Unpin user clone":     {def: "gc_system_with_users_pinned_clone.yaml", state: "rpool/USERDATA/user1_clone", user: "user1", unpin: true},
+		"Pin with short state": {def: "state_annotate.yaml", state: "snap2"},
+
+		"Error on no state given":       {def: "state_annotate.yaml", wantErr: true},
+		"Error on unknown state":        {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_9999", wantErr: true},
+		"Error on user state as system": {def: "state_annotate.yaml", state: "rpool/USERDATA/user1_efgh", wantErr: true},
+		"Error on setting pin property": {def: "state_annotate.yaml", state: "rpool/ROOT/ubuntu_1234@snap2", setPropertyErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+
+			err = ms.PinState(context.Background(), tc.state, tc.user, !tc.unpin)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestIDToState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		name string
+		user string
+
+		wantState string
+		wantErr   bool
+	}{
+		"Match full system path ID": {name: "rpool/ROOT/ubuntu_1234", wantState: "rpool/ROOT/ubuntu_1234"},
+		"Match full user path ID":   {name: "rpool/USERDATA/user1_abcd", user: "user1", wantState: "rpool/USERDATA/user1_abcd"},
+
+		"Match suffix system ID":       {name: "5678", wantState: "rpool/ROOT/ubuntu_5678"},
+		"Match dataset path system ID": {name: "ubuntu_5678", wantState: "rpool/ROOT/ubuntu_5678"},
+		"Match unique system snapshot": {name: "snap1", wantState: "rpool/ROOT/ubuntu_1234@snap1"},
+
+		"Limit search on duplicated snapshot name to a single user": {name: "snap1", user: "user1", wantState: "rpool/USERDATA/user1_abcd@snap1"},
 
 		// User datasets shared between machines
 		"Match on user generated ID":                                                         {name: "jklm-rpool.ROOT.ubuntu-1234", user: "user2", wantState: "rpool/USERDATA/user2_jklm"},
@@ -1318,6 +2275,421 @@ func TestIDToState(t *testing.T) {
 	}
 }
 
+func TestStateSpace(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state string
+		user  string
+
+		wantUsed       uint64
+		wantReferenced uint64
+		wantWritten    uint64
+	}{
+		"System state with children and user states": {state: "rpool/ROOT/ubuntu_1234", wantUsed: 5368709120, wantReferenced: 3221225472, wantWritten: 1060864},
+		"System snapshot state":                      {state: "rpool/ROOT/ubuntu_1234@snap1", wantUsed: 786433024, wantReferenced: 2621440000, wantWritten: 2621440000},
+		"System clone state":                         {state: "rpool/ROOT/ubuntu_5678", wantUsed: 26214400, wantReferenced: 2647654400, wantWritten: 36700160},
+		"User state":                                 {state: "rpool/USERDATA/user1_abcd", user: "user1", wantUsed: 2147483648, wantReferenced: 1610612736, wantWritten: 8192},
+		"User snapshot state":                        {state: "rpool/USERDATA/user1_abcd@snap2", user: "user1", wantUsed: 52428800, wantReferenced: 1572864000, wantWritten: 524288000},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_space.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			s, err := ms.IDToState(context.Background(), tc.state, tc.user)
+			if err != nil {
+				t.Fatalf("couldn't find state %q: %v", tc.state, err)
+			}
+
+			used, referenced, written := s.Space()
+			assert.Equal(t, tc.wantUsed, used, "didn't get expected used space")
+			assert.Equal(t, tc.wantReferenced, referenced, "didn't get expected referenced space")
+			assert.Equal(t, tc.wantWritten, written, "didn't get expected written space")
+		})
+	}
+}
+
+func TestRemoveStateDryRunSpace(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state string
+		user  string
+
+		wantFreed string
+	}{
+		"System clone, children are not counted twice": {state: "rpool/ROOT/ubuntu_5678", wantFreed: "20.0M"},
+		"System snapshot, linked user states are kept": {state: "rpool/ROOT/ubuntu_1234@snap2", wantFreed: "100.0M"},
+		"User snapshot": {state: "rpool/USERDATA/user1_abcd@snap2", user: "user1", wantFreed: "50.0M"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_space.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			var out bytes.Buffer
+			ctx, err := log.ContextWithLogger(context.Background(), "test", "warning", &out)
+			if err != nil {
+				t.Fatalf("couldn't create logger context: %v", err)
+			}
+
+			if err := ms.RemoveState(ctx, tc.state, tc.user, true, true); err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			assert.Contains(t, out.String(), "Space freed: "+tc.wantFreed+"\n", "didn't get expected freed space")
+			assertMachinesEquals(t, initMachines, ms)
+		})
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def            string
+		currentStateID string
+		from           string
+		to             string
+		withUserData   bool
+		unmounted      []string
+
+		wantErr bool
+	}{
+		"Diff snapshot with current state":                {from: "rpool/ROOT/ubuntu_1234@snap1"},
+		"Diff snapshot with current state with user data": {from: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true},
+		"Diff snapshot with explicit current state":       {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_1234"},
+		"Diff between snapshots":                          {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_1234@snap2"},
+		"Diff between snapshots with user data":           {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_1234@snap2", withUserData: true},
+		"Diff on short state ids":                         {from: "snap2", to: "1234"},
+		"Unchanged datasets are not listed":               {from: "rpool/ROOT/ubuntu_1234@snap2"},
+		"Dataset only in from state is listed as removed": {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_5678"},
+
+		"Error on no from state":                        {wantErr: true},
+		"Error on unknown from state":                   {from: "rpool/ROOT/ubuntu_9999@snap1", wantErr: true},
+		"Error on unknown to state":                     {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_9999", wantErr: true},
+		"Error on from state not a snapshot":            {from: "rpool/ROOT/ubuntu_5678", wantErr: true},
+		"Error on comparing state with itself":          {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true},
+		"Error on no current machine":                   {currentStateID: "rpool/ROOT/ubuntu_9999", from: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true},
+		"Error on from state newer than to state":       {from: "rpool/ROOT/ubuntu_1234@snap2", to: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true},
+		"Error on from state taken after to was cloned": {from: "rpool/ROOT/ubuntu_1234@snap2", to: "rpool/ROOT/ubuntu_5678", wantErr: true},
+		"Error on unrelated states":                     {from: "rpool/ROOT/ubuntu_4242@snap1", to: "rpool/ROOT/ubuntu_1234", wantErr: true},
+		"Error on unmounted from dataset":               {from: "rpool/ROOT/ubuntu_1234@snap1", unmounted: []string{"rpool/ROOT/ubuntu_1234/var"}, wantErr: true},
+		"Error on unmounted to dataset":                 {from: "rpool/ROOT/ubuntu_1234@snap1", to: "rpool/ROOT/ubuntu_5678", unmounted: []string{"rpool/ROOT/ubuntu_5678"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", getDefaultValue(tc.def, "state_diff.yaml")), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+			lzfs := libzfs.(*mock.LibZFS)
+			for _, d := range []string{"rpool/ROOT/ubuntu_1234", "rpool/ROOT/ubuntu_1234/var", "rpool/ROOT/ubuntu_1234/opt",
+				"rpool/ROOT/ubuntu_5678", "rpool/ROOT/ubuntu_4242", "rpool/USERDATA/user1_abcd", "rpool/USERDATA/root_bcde"} {
+				lzfs.SetDatasetAsMounted(d, true)
+			}
+			for _, d := range tc.unmounted {
+				lzfs.SetDatasetAsMounted(d, false)
+			}
+
+			currentStateID := getDefaultValue(tc.currentStateID, "rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), generateCmdLine(currentStateID), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			got, err := ms.StateDiff(context.Background(), tc.from, tc.to, tc.withUserData)
+			assertMachinesEquals(t, initMachines, ms)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			var want []machines.DatasetDiff
+			testutils.LoadFromGoldenFile(t, got, &want)
+			assert.Equal(t, want, got, "didn't get expected state differences")
+		})
+	}
+}
+
+func TestExportImportState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state        string
+		from         string
+		withUserData bool
+		pool         string
+
+		// importedFirst is fully exported and imported before the tested state
+		importedFirst string
+		// importOnSource imports back on the machine the state was exported from
+		importOnSource bool
+		// fromBookmarked replaces the from state by bookmarks before exporting
+		fromBookmarked bool
+		invalidExport  bool
+
+		wantExportErr bool
+		wantImportErr bool
+	}{
+		"Export and import system state":               {state: "rpool/ROOT/ubuntu_1234@snap1"},
+		"Export and import with user data":             {state: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true},
+		"Export and import on short state id":          {state: "snap2"},
+		"Import in another pool":                       {state: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true, pool: "tank"},
+		"Incremental import on already imported state": {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true, importedFirst: "rpool/ROOT/ubuntu_1234@snap1"},
+		"Incremental export from a bookmarked state":   {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_1234@snap1", withUserData: true, importedFirst: "rpool/ROOT/ubuntu_1234@snap1", fromBookmarked: true},
+		"Incremental export from a bookmark":           {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_1234#snap1", withUserData: true, importedFirst: "rpool/ROOT/ubuntu_1234@snap1", fromBookmarked: true},
+
+		"Error on exporting unknown state":                  {state: "rpool/ROOT/ubuntu_9999@snap1", wantExportErr: true},
+		"Error on exporting non snapshot state":             {state: "rpool/ROOT/ubuntu_1234", wantExportErr: true},
+		"Error on incremental export from unknown state":    {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_1234@snap9", wantExportErr: true},
+		"Error on incremental export from later state":      {state: "rpool/ROOT/ubuntu_1234@snap1", from: "rpool/ROOT/ubuntu_1234@snap2", wantExportErr: true},
+		"Error on incremental export from another machine":  {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_5678@snap3", wantExportErr: true},
+		"Error on importing full state on existing machine": {state: "rpool/ROOT/ubuntu_1234@snap1", importOnSource: true, wantImportErr: true},
+		"Error on importing already existing state":         {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_1234@snap1", importOnSource: true, wantImportErr: true},
+		"Error on importing incremental state without base": {state: "rpool/ROOT/ubuntu_1234@snap2", from: "rpool/ROOT/ubuntu_1234@snap1", importedFirst: "rpool/ROOT/ubuntu_5678@snap3", wantImportErr: true},
+		"Error on importing in non existing pool":           {state: "rpool/ROOT/ubuntu_1234@snap1", pool: "doesntexist", wantImportErr: true},
+		"Error on importing invalid export":                 {invalidExport: true, wantImportErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_export.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			targetDir, targetCleanup := testutils.TempDir(t)
+			defer targetCleanup()
+			targetLibzfs := testutils.GetMockZFS(t)
+			targetPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_import_empty_pools.yaml"), testutils.WithLibZFS(targetLibzfs))
+			defer targetPools.Create(targetDir)()
+			target, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(targetLibzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			if tc.importOnSource {
+				target = ms
+			}
+
+			if tc.importedFirst != "" {
+				var export bytes.Buffer
+				if err := ms.ExportState(context.Background(), tc.importedFirst, "", tc.withUserData, &export); err != nil {
+					t.Fatalf("setup failed: couldn't export %s: %v", tc.importedFirst, err)
+				}
+				if _, err := target.ImportState(context.Background(), &export, tc.pool); err != nil {
+					t.Fatalf("setup failed: couldn't import %s: %v", tc.importedFirst, err)
+				}
+			}
+			if tc.fromBookmarked {
+				z, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
+				if err != nil {
+					t.Fatalf("setup failed: couldn't scan zfs datasets: %v", err)
+				}
+				nt := z.NewNoTransaction(context.Background())
+				snap := "@" + strings.Split(tc.importedFirst, "@")[1]
+				var toDestroy []string
+				for _, d := range z.Datasets() {
+					if !strings.HasSuffix(d.Name, snap) {
+						continue
+					}
+					if err := nt.Bookmark(d.Name); err != nil {
+						t.Fatalf("setup failed: couldn't bookmark %s: %v", d.Name, err)
+					}
+					// Destroying a snapshot destroys it on children too
+					if len(toDestroy) > 0 && strings.HasPrefix(d.Name, strings.TrimSuffix(toDestroy[len(toDestroy)-1], snap)+"/") {
+						continue
+					}
+					toDestroy = append(toDestroy, d.Name)
+				}
+				for _, n := range toDestroy {
+					if err := nt.Destroy(n); err != nil {
+						t.Fatalf("setup failed: couldn't destroy %s: %v", n, err)
+					}
+				}
+				if err := ms.Refresh(context.Background()); err != nil {
+					t.Fatalf("setup failed: couldn't refresh machines: %v", err)
+				}
+			}
+
+			var export bytes.Buffer
+			if tc.invalidExport {
+				export.WriteString("not an export")
+			} else {
+				initMachines := ms.CopyForTests(t)
+				err = ms.ExportState(context.Background(), tc.state, tc.from, tc.withUserData, &export)
+				assertMachinesEquals(t, initMachines, ms)
+				if err != nil {
+					if !tc.wantExportErr {
+						t.Fatalf("expected no error on export but got: %v", err)
+					}
+					return
+				}
+				if tc.wantExportErr {
+					t.Fatal("expected an error on export but got none")
+				}
+			}
+
+			initTarget := target.CopyForTests(t)
+			got, err := target.ImportState(context.Background(), &export, tc.pool)
+			if err != nil {
+				if !tc.wantImportErr {
+					t.Fatalf("expected no error on import but got: %v", err)
+				}
+				assertMachinesEquals(t, initTarget, target)
+				return
+			}
+			if tc.wantImportErr {
+				t.Fatal("expected an error on import but got none")
+			}
+
+			wantID := getDefaultValue(tc.pool, "rpool") + "/ROOT/ubuntu_1234@" + strings.Split(tc.state, "@")[len(strings.Split(tc.state, "@"))-1]
+			assert.Equal(t, wantID, got, "didn't get expected imported state id")
+			assertMachinesToGolden(t, target)
+		})
+	}
+}
+
+func TestReplicate(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def            string
+		configPath     string
+		cmdline        string
+		replicateTwice bool
+
+		// wantSystemSnapshots are the snapshots on every replicated system dataset.
+		wantSystemSnapshots []string
+		// wantUserSnapshots are the snapshots on the replicated user dataset. Default to wantSystemSnapshots.
+		wantUserSnapshots []string
+		wantErr           bool
+	}{
+		"Replicate and prune all states":          {def: "state_replicate.yaml", wantSystemSnapshots: []string{"autozsys_p1", "manual1", "autozsys_a3", "autozsys_a4"}},
+		"Replicating twice keeps the same states": {def: "state_replicate.yaml", replicateTwice: true, wantSystemSnapshots: []string{"autozsys_p1", "manual1", "autozsys_a3", "autozsys_a4"}},
+		"Resume interrupted replication":          {def: "state_replicate_interrupted.yaml", wantSystemSnapshots: []string{"autozsys_p1", "manual1", "autozsys_a3", "autozsys_a4"}},
+		"Resume replication from a bookmark":      {def: "state_replicate_from_bookmark.yaml", wantSystemSnapshots: []string{"autozsys_p1", "manual1", "autozsys_a3", "autozsys_a4"}},
+		"Skip diverged datasets": {def: "state_replicate_diverged.yaml",
+			wantSystemSnapshots: []string{"autozsys_p1", "manual1", "autozsys_a3", "autozsys_a4"}, wantUserSnapshots: []string{"other"}},
+
+		"Error on no target pool configured": {def: "state_replicate.yaml", configPath: "default.conf", wantErr: true},
+		"Error on non existing target pool":  {def: "state_replicate.yaml", configPath: "replication_missing_pool.conf", wantErr: true},
+		"Error on non zsys current machine":  {def: "state_replicate.yaml", cmdline: "-", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			configPath := filepath.Join("testdata", "confs", getDefaultValue(tc.configPath, "replication.conf"))
+			cmdline := generateCmdLine(getDefaultValue(tc.cmdline, "rpool/ROOT/ubuntu_1234"))
+			if tc.cmdline == "-" {
+				cmdline = ""
+			}
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs),
+				machines.WithTime(testutils.FixedTime{}), machines.WithConfig(configPath))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			err = ms.Replicate(context.Background())
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+			if tc.replicateTwice {
+				if err := ms.Replicate(context.Background()); err != nil {
+					t.Fatalf("expected no error on second replication but got: %v", err)
+				}
+			}
+
+			assertMachinesToGolden(t, ms)
+
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatalf("couldn’t rescan zfs datasets: %v", err)
+			}
+			snapshots := make(map[string][]string)
+			for _, d := range z.Datasets() {
+				if !d.IsSnapshot || !strings.HasPrefix(d.Name, "backup/") {
+					continue
+				}
+				n := strings.Split(d.Name, "@")
+				snapshots[n[0]] = append(snapshots[n[0]], n[1])
+			}
+			for _, n := range []string{"backup/rpool/ROOT/ubuntu_1234", "backup/rpool/ROOT/ubuntu_1234/var", "backup/bpool/BOOT/ubuntu_1234"} {
+				assert.ElementsMatch(t, tc.wantSystemSnapshots, snapshots[n], "unexpected replicated snapshots on %s", n)
+			}
+			wantUserSnapshots := tc.wantUserSnapshots
+			if wantUserSnapshots == nil {
+				wantUserSnapshots = tc.wantSystemSnapshots
+			}
+			assert.ElementsMatch(t, wantUserSnapshots, snapshots["backup/rpool/USERDATA/user1_abcd"], "unexpected replicated user snapshots")
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs),
+				machines.WithTime(testutils.FixedTime{}), machines.WithConfig(configPath))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
 func TestGC(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -1326,6 +2698,9 @@ func TestGC(t *testing.T) {
 		configPath string
 
 		destroyErrDS []string
+		setCapOnPool string
+		capValue     string
+		cancelled    bool
 
 		isNoOp  bool
 		wantErr bool
@@ -1343,6 +2718,8 @@ func TestGC(t *testing.T) {
 
 		"Manual snapshot which should be deleted is kept":     {def: "gc_system_only_with_manual_snapshot.yaml"},
 		"Manual snapshot which should be deleted isnt't kept": {def: "gc_system_only_with_manual_snapshot.yaml", all: true},
+		"Pinned snapshots which should be deleted are kept":   {def: "gc_system_only_with_pinned_snapshots.yaml"},
+		"Pinned snapshots are kept even with all":             {def: "gc_system_only_with_pinned_snapshots.yaml", all: true},
 
 		"Clone and dependencies are collected within the same bucket":                  {def: "gc_system_only_with_clone_same_bucket.yaml"},
 		"Manual clone without last used is collected":                                  {def: "gc_system_only_with_manual_clone.yaml"},
@@ -1362,10 +2739,13 @@ func TestGC(t *testing.T) {
 		"Follow bucket policy with users":                      {def: "gc_system_with_users.yaml"},
 		"Follow bucket policy with users and one empty bucket": {def: "gc_system_with_users_one_empty_bucket.yaml", configPath: "one_empty_bucket.conf", isNoOp: true},
 		"Keep more user snapshots than simply last day has":    {def: "gc_system_with_users.yaml", configPath: "keep_many_snapshots.conf"},
+		"User history rules apply on user states only":         {def: "gc_system_with_users.yaml", configPath: "user_history.conf"},
+		"Per user history rules apply on this user only":       {def: "gc_system_with_users.yaml", configPath: "user_history_override.conf"},
 
 		// User clones
 		"Remove user clone state":                                                                     {def: "gc_system_with_users_clone.yaml"},
 		"Remove user clone state with subdataset":                                                     {def: "gc_system_with_users_clone_subdataset.yaml"},
+		"Don't remove pinned user clone state":                                                        {def: "gc_system_with_users_pinned_clone.yaml", isNoOp: true},
 		"Don't remove user clone state with snapshot on it kept":                                      {def: "gc_system_with_users_clone_with_manual_snapshot.yaml", isNoOp: true},
 		"Don't remove user clone state with snapshot on subdataset":                                   {def: "gc_system_with_users_clone_subdataset_with_manual_snapshot.yaml", isNoOp: true},
 		"Don't remove user clone state linked to a system state":                                      {def: "gc_system_with_users_clone_linked_to_system_state.yaml", isNoOp: true},
@@ -1397,8 +2777,23 @@ func TestGC(t *testing.T) {
 		"Destroy failed on user dataset":                       {def: "gc_system_with_users_clone.yaml", destroyErrDS: []string{"rpool/USERDATA/user1_clone"}, isNoOp: true},
 		"Destroy failed on unlinked user dataset":              {def: "gc_system_with_unlinked_users_unmanaged_clone_bootfs_on_clone.yaml", destroyErrDS: []string{"rpool/USERDATA/user2_clone"}, isNoOp: true},
 
+		// Free space pressure
+		"Free space pressure removes oldest automatic states until target is reached":   {def: "gc_free_space.yaml", configPath: "free_space_pressure.conf", setCapOnPool: "rpool", capValue: "80"},
+		"Free space pressure removes all automatic states it can if target is too high": {def: "gc_free_space.yaml", configPath: "free_space_pressure.conf", setCapOnPool: "rpool", capValue: "99"},
+		"No free space pressure when pool is above target":                              {def: "gc_free_space.yaml", configPath: "free_space_pressure.conf", setCapOnPool: "rpool", capValue: "60", isNoOp: true},
+		"Free space pressure doesn't count states it failed to remove": {def: "gc_free_space.yaml", configPath: "free_space_pressure.conf", setCapOnPool: "rpool", capValue: "80",
+			destroyErrDS: []string{"rpool/ROOT/ubuntu_1234@autozsys_20191227-1000"}},
+		"No free space pressure without target": {def: "gc_free_space.yaml", configPath: "keep_many_snapshots.conf", setCapOnPool: "rpool", capValue: "99", isNoOp: true},
+
+		// Bookmarks
+		"Removed states are kept as bookmarks":            {def: "gc_system_with_users.yaml", configPath: "bookmarks.conf"},
+		"Bookmarks older than retention are removed":      {def: "gc_bookmarks.yaml", configPath: "bookmarks_retention.conf"},
+		"Bookmarks are kept without retention configured": {def: "gc_bookmarks.yaml", isNoOp: true},
+
 		// Error cases
 		"Error fails to destroy state are kept": {def: "gc_system_with_users.yaml", destroyErrDS: []string{}, isNoOp: true},
+		"Error on invalid pool capacity":        {def: "gc_free_space.yaml", configPath: "free_space_pressure.conf", setCapOnPool: "rpool", capValue: "NaN", wantErr: true},
+		"Error on cancelled request":            {def: "gc_system_only.yaml", cancelled: true, wantErr: true},
 	}
 
 	for name, tc := range tests {
@@ -1440,12 +2835,39 @@ func TestGC(t *testing.T) {
 			initMachines := ms.CopyForTests(t)
 			lzfs := libzfs.(*mock.LibZFS)
 			lzfs.ErrOnDestroyDS(tc.destroyErrDS)
+			if tc.setCapOnPool != "" {
+				lzfs.SetPoolCapacity(tc.setCapOnPool, tc.capValue)
+			}
+
+			// A dry run doesn’t change anything and announces the datasets that a real run destroys
+			var dryrunOut bytes.Buffer
+			dryrunCtx, err := log.ContextWithLogger(context.Background(), "test", "warning", &dryrunOut)
+			if err != nil {
+				t.Fatalf("couldn’t create logger context: %v", err)
+			}
+			if err := ms.GC(dryrunCtx, tc.all, true, true); err != nil && !tc.wantErr {
+				t.Fatalf("expected no error on dry run but got: %v", err)
+			}
+			assertMachinesEquals(t, initMachines, ms)
+			var wantDestroyed []string
+			for _, m := range regexp.MustCompile(`Deleting (?:dataset|bookmark) (\S+)`).FindAllStringSubmatch(dryrunOut.String(), -1) {
+				wantDestroyed = append(wantDestroyed, m[1])
+			}
 
-			err = ms.GC(context.Background(), tc.all)
+			ctx := context.Background()
+			if tc.cancelled {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				cancel()
+			}
+			err = ms.GC(ctx, tc.all, false, false)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
 				}
+				if tc.cancelled {
+					assertMachinesEquals(t, initMachines, ms)
+				}
 				return
 			}
 			if err == nil && tc.wantErr {
@@ -1464,6 +2886,91 @@ func TestGC(t *testing.T) {
 				t.Error("expected success but got an error scanning for machines", err)
 			}
 			assertMachinesEquals(t, machinesAfterRescan, ms)
+
+			// Destruction errors are only known on a real run
+			if tc.destroyErrDS != nil {
+				return
+			}
+			zAfter, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatalf("couldn’t rescan zfs datasets: %v", err)
+			}
+			remaining := make(map[string]bool)
+			for _, d := range append(zAfter.Datasets(), zAfter.Bookmarks()...) {
+				remaining[d.Name] = true
+			}
+			var destroyed []string
+			for _, d := range append(z.Datasets(), z.Bookmarks()...) {
+				if !remaining[d.Name] {
+					destroyed = append(destroyed, d.Name)
+				}
+			}
+			assert.ElementsMatch(t, wantDestroyed, destroyed, "dry run should announce the datasets a real run destroys")
+		})
+	}
+}
+
+func TestGCExplain(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def        string
+		configPath string
+		capValue   string
+	}{
+		"Explain system states":               {def: "gc_system_only_with_pinned_snapshots.yaml"},
+		"Explain system states with keep all": {def: "gc_system_only.yaml", configPath: "keep_many_snapshots.conf"},
+		"Explain system and user states":      {def: "gc_system_with_users.yaml", configPath: "user_history.conf"},
+		"Explain free space pressure":         {def: "gc_free_space.yaml", configPath: "free_space_pressure.conf", capValue: "80"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.configPath == "" {
+				tc.configPath = "default.conf"
+			}
+			tc.configPath = filepath.Join("testdata", "confs", tc.configPath)
+
+			ms, err := machines.New(context.Background(), "", machines.WithLibZFS(libzfs),
+				machines.WithTime(testutils.FixedTime{}), machines.WithConfig(tc.configPath))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+			if tc.capValue != "" {
+				libzfs.(*mock.LibZFS).SetPoolCapacity("rpool", tc.capValue)
+			}
+
+			var out bytes.Buffer
+			ctx, err := log.ContextWithLogger(context.Background(), "test", "warning", &out)
+			if err != nil {
+				t.Fatalf("couldn’t create logger context: %v", err)
+			}
+			if err := ms.GC(ctx, false, true, true); err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			assertMachinesEquals(t, initMachines, ms)
+
+			// Remove pings sent to the client
+			var got []string
+			for _, l := range strings.Split(out.String(), "\n") {
+				l = strings.TrimLeft(l, ".")
+				if l == "" {
+					continue
+				}
+				got = append(got, l)
+			}
+			var want []string
+			testutils.LoadFromGoldenFile(t, got, &want)
+			assert.Equal(t, want, got, "explain output should match")
 		})
 	}
 }
@@ -1539,6 +3046,27 @@ func assertMachinesNotEquals(t *testing.T, m1, m2 machines.Machines) {
 	}
 }
 
+// assertDatasetProperties checks native properties of dataset name. An empty value means the property isn't set.
+func assertDatasetProperties(t *testing.T, lzfs libzfsadapter.Interface, name string, want map[string]string) {
+	t.Helper()
+
+	props := map[string]libzfsadapter.Prop{
+		"compression": libzfsadapter.DatasetPropCompression,
+		"quota":       libzfsadapter.DatasetPropQuota,
+		"recordsize":  libzfsadapter.DatasetPropRecordsize,
+	}
+
+	d, err := lzfs.DatasetOpen(name)
+	if err != nil {
+		t.Fatalf("couldn't open %q: %v", name, err)
+	}
+	defer d.Close()
+	got := *d.Properties()
+	for k, v := range want {
+		assert.Equal(t, v, got[props[k]].Value, "unexpected %s for %s", k, name)
+	}
+}
+
 // generateCmdLine returns a command line with fake boot arguments
 func generateCmdLine(datasetAndBoot string) string {
 	return "aaaaa bbbbb root=ZFS=" + datasetAndBoot + " ccccc"
@@ -1560,3 +3088,8 @@ func getDefaultValue(v, defaultVal string) string {
 
 	return v
 }
+
+// stringPtr returns a pointer to a copy of s.
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,345 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// persistentFlags are the zsys properties to set on a new persistent dataset.
+type persistentFlags struct {
+	excluded     bool
+	followSystem bool
+}
+
+// ListPersistentDatasets returns all persistent datasets, which are shared between machines.
+func (ms *Machines) ListPersistentDatasets() []*zfs.Dataset {
+	return ms.allPersistentDatasets
+}
+
+// ExcludePath keeps path out of system states of the current machine, by moving it to a persistent dataset outside
+// of the system hierarchy and marking it as excluded.
+// If path is a system dataset, it is moved with its children and snapshots. Otherwise, a new dataset is created and
+// mounted on path, which needs to be empty.
+// It returns the name of the excluded dataset.
+func (ms *Machines) ExcludePath(ctx context.Context, path string) (string, error) {
+	path, err := ms.checkPersistentPath(path)
+	if err != nil {
+		return "", err
+	}
+	m := ms.current
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	// Already persistent: only mark it explicitly
+	if d := m.persistentDatasetOn(path); d != nil {
+		if d.Excluded {
+			log.Infof(ctx, i18n.G("%q is already excluded from system states"), d.Name)
+			return d.Name, nil
+		}
+		log.Infof(ctx, i18n.G("Marking %q as excluded from system states"), d.Name)
+		if err := t.SetProperty(libzfs.ExcludedProp, "yes", d.Name, true); err != nil {
+			cancel()
+			return "", fmt.Errorf(i18n.G("couldn't mark %q as excluded: ")+config.ErrorFormat, d.Name, err)
+		}
+		ms.refresh(ctx)
+		return d.Name, nil
+	}
+
+	name := m.persistentDatasetName(path)
+	if ms.datasetExists(name) {
+		return "", fmt.Errorf(i18n.G("%q already exists and isn't mounted on %q"), name, path)
+	}
+
+	source := m.systemDatasetOn(path)
+	if source == nil {
+		if err := checkEmptyDir(path); err != nil {
+			return "", err
+		}
+		log.Infof(ctx, i18n.G("Creating %q for %q, excluded from system states"), name, path)
+		if err := ms.createPersistentDataset(ctx, t, name, path, persistentFlags{excluded: true}); err != nil {
+			cancel()
+			return "", err
+		}
+		return name, ms.Refresh(ctx)
+	}
+
+	log.Infof(ctx, i18n.G("Moving %q to %q, excluded from system states"), source.Name, name)
+	if err := ms.movePersistentDataset(t, source, name, persistentFlags{excluded: true}); err != nil {
+		cancel()
+		return "", err
+	}
+
+	ms.refresh(ctx)
+	return name, nil
+}
+
+// CreatePersistent creates a new persistent dataset mounted on path, which needs to be empty and not a system dataset.
+// If followSystem is true, the new dataset is snapshotted with each saved system state.
+// It returns the name of the new dataset.
+func (ms *Machines) CreatePersistent(ctx context.Context, path string, followSystem bool) (string, error) {
+	path, err := ms.checkPersistentPath(path)
+	if err != nil {
+		return "", err
+	}
+	m := ms.current
+
+	if d := m.persistentDatasetOn(path); d != nil {
+		return "", fmt.Errorf(i18n.G("%q is already persistent, in %s"), path, d.Name)
+	}
+	if d := m.systemDatasetOn(path); d != nil {
+		return "", fmt.Errorf(i18n.G("%q is the system dataset %s: make it persistent instead"), path, d.Name)
+	}
+	name := m.persistentDatasetName(path)
+	if ms.datasetExists(name) {
+		return "", fmt.Errorf(i18n.G("%q already exists and isn't mounted on %q"), name, path)
+	}
+	if err := checkEmptyDir(path); err != nil {
+		return "", err
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Creating persistent dataset %q for %q"), name, path)
+	if err := ms.createPersistentDataset(ctx, t, name, path, persistentFlags{followSystem: followSystem}); err != nil {
+		cancel()
+		return "", err
+	}
+	return name, ms.Refresh(ctx)
+}
+
+// MakePersistent moves the system dataset mounted on path, with its children and snapshots, outside of the system
+// hierarchy so that it's shared between all states.
+// followSystem sets if the dataset is snapshotted with each saved system state. On an already persistent dataset,
+// only this setting is changed.
+// It returns the name of the persistent dataset.
+func (ms *Machines) MakePersistent(ctx context.Context, path string, followSystem bool) (string, error) {
+	path, err := ms.checkPersistentPath(path)
+	if err != nil {
+		return "", err
+	}
+	m := ms.current
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	if d := m.persistentDatasetOn(path); d != nil {
+		if followSystem && d.Excluded {
+			return "", fmt.Errorf(i18n.G("%q is excluded from system states and can't follow them"), d.Name)
+		}
+		if d.FollowSystem == followSystem {
+			log.Infof(ctx, i18n.G("%q is already persistent"), d.Name)
+			return d.Name, nil
+		}
+		value := "no"
+		if followSystem {
+			value = "yes"
+		}
+		log.Infof(ctx, i18n.G("Setting %q to follow system states: %s"), d.Name, value)
+		if err := t.SetProperty(libzfs.FollowSystemProp, value, d.Name, true); err != nil {
+			cancel()
+			return "", fmt.Errorf(i18n.G("couldn't set %q to follow system states: ")+config.ErrorFormat, d.Name, err)
+		}
+		ms.refresh(ctx)
+		return d.Name, nil
+	}
+
+	source := m.systemDatasetOn(path)
+	if source == nil {
+		return "", fmt.Errorf(i18n.G("no system dataset is mounted on %q: create a persistent dataset instead"), path)
+	}
+	name := m.persistentDatasetName(path)
+	if ms.datasetExists(name) {
+		return "", fmt.Errorf(i18n.G("%q already exists and isn't mounted on %q"), name, path)
+	}
+
+	log.Infof(ctx, i18n.G("Moving %q to persistent dataset %q"), source.Name, name)
+	if err := ms.movePersistentDataset(t, source, name, persistentFlags{followSystem: followSystem}); err != nil {
+		cancel()
+		return "", err
+	}
+
+	ms.refresh(ctx)
+	return name, nil
+}
+
+// checkPersistentPath ensures that path can be moved out of the system states of the current machine.
+// It returns path cleaned.
+func (ms *Machines) checkPersistentPath(path string) (string, error) {
+	m := ms.current
+	if !m.isZsys() {
+		return "", errors.New(i18n.G("Current machine isn't Zsys, nothing to move out of system states"))
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf(i18n.G("%q needs to be an absolute path"), path)
+	}
+	path = filepath.Clean(path)
+	if path == "/" || path == "/boot" || strings.HasPrefix(path, "/boot/") {
+		return "", fmt.Errorf(i18n.G("%q can't be moved out of system states"), path)
+	}
+
+	for _, us := range m.Users {
+		for _, d := range us.getDatasets() {
+			if d.Mountpoint == path || strings.HasPrefix(path, d.Mountpoint+"/") {
+				return "", fmt.Errorf(i18n.G("%q is part of user data %s and can't be moved out of system states"), path, us.ID)
+			}
+		}
+	}
+
+	return path, nil
+}
+
+// persistentDatasetOn returns the persistent dataset mounted on path, if any.
+func (m *Machine) persistentDatasetOn(path string) *zfs.Dataset {
+	for _, d := range m.PersistentDatasets {
+		if d.Mountpoint == path {
+			return d
+		}
+	}
+	return nil
+}
+
+// systemDatasetOn returns the system dataset, other than the root one, mounted on path, if any.
+func (m *Machine) systemDatasetOn(path string) *zfs.Dataset {
+	for _, d := range m.Datasets[m.ID] {
+		if d.Name != m.ID && d.Mountpoint == path {
+			return d
+		}
+	}
+	return nil
+}
+
+// persistentDatasetName returns the name of the persistent dataset for path, on the pool of the machine.
+func (m *Machine) persistentDatasetName(path string) string {
+	return strings.Split(m.ID, "/")[0] + path
+}
+
+// checkEmptyDir returns an error if path is a directory with some content.
+func checkEmptyDir(path string) error {
+	entries, err := os.ReadDir(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf(i18n.G("couldn't read %q: %v"), path, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf(i18n.G("%q isn't empty: move its content away first"), path)
+	}
+	return nil
+}
+
+// createPersistentDataset creates name, mounted on path, with flags set.
+func (ms *Machines) createPersistentDataset(ctx context.Context, t *zfs.Transaction, name, path string, flags persistentFlags) error {
+	if err := ms.createPersistentParents(t, name); err != nil {
+		return err
+	}
+	if err := t.Create(name, path, "on"); err != nil {
+		return err
+	}
+	if err := flags.set(t, name); err != nil {
+		return err
+	}
+	// FIXME: mount the dataset here, as we do for user datasets until Create() handles it
+	if err := syscall.Mount(name, path, "zfs", 0, "zfsutil"); err != nil {
+		log.Warningf(ctx, i18n.G("Couldn't mount %s: %v"), path, err)
+	}
+	return nil
+}
+
+// movePersistentDataset moves the system dataset source to name, keeping its mountpoint, with flags set.
+func (ms *Machines) movePersistentDataset(t *zfs.Transaction, source *zfs.Dataset, name string, flags persistentFlags) error {
+	if err := ms.createPersistentParents(t, name); err != nil {
+		return err
+	}
+	// The mountpoint needs to be kept once moved outside of the system hierarchy.
+	if err := t.SetProperty(libzfs.MountPointProp, source.Mountpoint, source.Name, true); err != nil {
+		return fmt.Errorf(i18n.G("couldn't set mountpoint of %q: ")+config.ErrorFormat, source.Name, err)
+	}
+	if err := t.Rename(source.Name, name); err != nil {
+		return err
+	}
+	return flags.set(t, name)
+}
+
+// createPersistentParents creates the missing parents of name, which are only containers.
+func (ms *Machines) createPersistentParents(t *zfs.Transaction, name string) error {
+	pool := strings.Split(name, "/")[0]
+	var parents []string
+	for p := filepath.Dir(name); p != pool && !ms.datasetExists(p); p = filepath.Dir(p) {
+		parents = append([]string{p}, parents...)
+	}
+	for _, p := range parents {
+		if err := t.Create(p, strings.TrimPrefix(p, pool), "off"); err != nil {
+			return fmt.Errorf(i18n.G("couldn't create container dataset %q: ")+config.ErrorFormat, p, err)
+		}
+	}
+	return nil
+}
+
+// set stores the flags on the dataset name.
+func (f persistentFlags) set(t *zfs.Transaction, name string) error {
+	if f.excluded {
+		if err := t.SetProperty(libzfs.ExcludedProp, "yes", name, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't mark %q as excluded: ")+config.ErrorFormat, name, err)
+		}
+	}
+	if f.followSystem {
+		if err := t.SetProperty(libzfs.FollowSystemProp, "yes", name, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set %q to follow system states: ")+config.ErrorFormat, name, err)
+		}
+	}
+	return nil
+}
+
+// followingDatasets returns the persistent datasets which are snapshotted with each saved system state.
+func (ms *Machines) followingDatasets() []*zfs.Dataset {
+	var r []*zfs.Dataset
+	for _, d := range ms.allPersistentDatasets {
+		if d.FollowSystem && !d.Excluded {
+			r = append(r, d)
+		}
+	}
+	return r
+}
+
+// removeFollowingSnapshots destroys the snapshots of persistent datasets following system states, saved with the
+// system state s, unless another existing system state shares the same snapshot name.
+func (ms *Machines) removeFollowingSnapshots(nt *zfs.NoTransaction, s *State) error {
+	_, snapshot := splitSnapshotName(s.ID)
+	if snapshot == "" {
+		return nil
+	}
+
+	for _, m := range ms.all {
+		for id, h := range m.History {
+			if h == s || !strings.HasSuffix(id, "@"+snapshot) {
+				continue
+			}
+			if ms.datasetExists(id) {
+				return nil
+			}
+		}
+	}
+
+	for _, d := range ms.allPersistentDatasets {
+		// Children snapshots are destroyed with their parent
+		name := d.Name + "@" + snapshot
+		if !d.FollowSystem || !ms.datasetExists(name) {
+			continue
+		}
+		if err := nt.Destroy(name); err != nil {
+			return fmt.Errorf(i18n.G("Couldn't destroy %s: %v"), name, err)
+		}
+	}
+	return nil
+}
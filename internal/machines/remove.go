@@ -0,0 +1,161 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// RemoveMachine removes the machine matching id with its whole history, its clones and the user datasets only
+// attached to it. User datasets shared with other machines are only detached from this machine.
+// The current machine, or any machine another one depends on, can't be removed.
+func (ms *Machines) RemoveMachine(ctx context.Context, id string, force, dryrun bool) error {
+	if id == "" {
+		return errors.New(i18n.G("a machine ID is required"))
+	}
+	m, err := ms.GetMachine(id)
+	if err != nil {
+		return err
+	}
+	if m == ms.current {
+		return errors.New(i18n.G("Removing current machine isn't allowed"))
+	}
+
+	// States and tags which belong to this machine
+	machineIDs := map[string]bool{m.ID: true}
+	for hID := range m.History {
+		machineIDs[hID] = true
+	}
+
+	nt := ms.z.NewNoTransaction(ctx)
+	allStates, datasetToState := ms.statesByDataset()
+	depsResolvedCache := make(map[stateWithLinkedState]stateToDeps)
+	systemStates, datasets := m.State.getDependenciesWithCache(nt, ms, "", allStates, datasetToState, depsResolvedCache)
+
+	// User datasets only tagged for this machine are removed with all their dependencies.
+	var userStates []stateWithLinkedState
+	destroyedUserStates := make(map[*State]bool)
+	users := make([]string, 0, len(m.AllUsersStates))
+	for user := range m.AllUsersStates {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	for _, user := range users {
+		for _, us := range sortedUserStates(m.AllUsersStates[user]) {
+			if us.isSnapshot() || !taggedOnlyFor(us, machineIDs) {
+				continue
+			}
+			// Clones of user datasets can already be listed as a dependency of another one
+			if destroyedUserStates[us] {
+				continue
+			}
+			uStates, uDatasets := us.getDependenciesWithCache(nt, ms, "", allStates, datasetToState, depsResolvedCache)
+			for _, s := range uStates {
+				if destroyedUserStates[s.State] {
+					continue
+				}
+				destroyedUserStates[s.State] = true
+				userStates = append(userStates, s)
+			}
+			datasets = append(datasets, uDatasets...)
+		}
+	}
+
+	statesOnMachines := ms.getAllStatesOnMachines()
+	var states []stateWithLinkedState
+	for _, s := range append(systemStates, userStates...) {
+		if s.linkedStateID != "" {
+			// No need to untag user states which are going to be destroyed
+			if destroyedUserStates[s.State] {
+				continue
+			}
+		} else if owner, ok := statesOnMachines[s.State]; ok && owner != m {
+			return fmt.Errorf(i18n.G("machine %s depends on %s: remove it first"), owner.ID, s.ID)
+		} else if !ok && !s.isSnapshot() && !taggedOnlyFor(s.State, machineIDs) {
+			return fmt.Errorf(i18n.G("%s is shared with other machines and can't be removed"), s.ID)
+		}
+		states = append(states, s)
+	}
+	datasets = uniqDatasets(datasets)
+
+	log.Debug(ctx, "Depending states found:")
+	for _, s := range states {
+		log.Debugf(ctx, "    - %s", s.ID)
+	}
+	log.Debug(ctx, "Depending datasets found:")
+	for _, d := range datasets {
+		log.Debugf(ctx, "    - %s", d.Name)
+	}
+
+	if !force {
+		var errmsg string
+		for _, curr := range states {
+			if curr.linkedStateID == "" && curr.Pinned() {
+				errmsg += fmt.Sprintf(i18n.G("%s is pinned\n"), curr.ID)
+			}
+		}
+		if len(states) > 1 {
+			errmsg += fmt.Sprintf(i18n.G("machine %s will be removed with the following states:\n"), m.ID)
+			for i := len(states) - 1; i >= 0; i-- {
+				if states[i].State == &m.State {
+					continue
+				}
+				errmsg += describeStateDependency(states[i])
+			}
+		}
+		if len(datasets) > 0 {
+			errmsg += fmt.Sprintf(i18n.G("%s has a dependency on some datasets:\n"), m.ID)
+			for i := len(datasets) - 1; i >= 0; i-- {
+				errmsg += fmt.Sprintf(i18n.G("  - %s\n"), datasets[i].Name)
+			}
+		}
+		if errmsg != "" {
+			return &ErrStateRemovalNeedsConfirmation{s: errmsg}
+		}
+	}
+
+	log.Infof(ctx, i18n.G("Removing machine %s"), m.ID)
+	return ms.removeDependencies(ctx, states, datasets, dryrun)
+}
+
+// taggedOnlyFor returns true if all datasets of state s are only tagged for ids.
+func taggedOnlyFor(s *State, ids map[string]bool) bool {
+	for _, ds := range s.Datasets {
+		for _, tag := range strings.Split(ds[0].BootfsDatasets, bootfsdatasetsSeparator) {
+			tag = strings.TrimSpace(tag)
+			if tag != "" && !ids[tag] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sortedUserStates returns states of a user, ordered by their ID.
+func sortedUserStates(states map[string]*State) []*State {
+	r := make([]*State, 0, len(states))
+	for _, k := range sortedStateKeys(states) {
+		r = append(r, states[k])
+	}
+	return r
+}
+
+// uniqDatasets deduplicates datasets by name, keeping first occurrence order.
+func uniqDatasets(datasets []*zfs.Dataset) []*zfs.Dataset {
+	keys := make(map[string]bool)
+	var r []*zfs.Dataset
+	for _, d := range datasets {
+		if keys[d.Name] {
+			continue
+		}
+		keys[d.Name] = true
+		r = append(r, d)
+	}
+	return r
+}
@@ -0,0 +1,722 @@
+package machines
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+const (
+	// exportVersion is the version of the container format of exported states.
+	exportVersion = 1
+	// exportManifestName is the name of the first entry of the container, describing its content.
+	exportManifestName = "manifest.json"
+	// streamChunkSize is the maximum size of a container entry holding part of a replication stream.
+	streamChunkSize = 4 * 1024 * 1024
+)
+
+// exportManifest describes an exported state and the replication streams following it in the container.
+type exportManifest struct {
+	Version int
+	// State is the ID of the exported system state.
+	State string
+	// IncrementalFrom is the snapshot name of the state the export is incremental from, if any.
+	IncrementalFrom string `json:",omitempty"`
+	// Datasets are ordered so that parents are received before their children.
+	Datasets []exportedDataset
+}
+
+// exportedDataset is a snapshot sent in a replication stream of the container.
+type exportedDataset struct {
+	// Name of the snapshot.
+	Name string
+	// From is the snapshot or bookmark this stream is incremental from. A full stream is sent if empty.
+	From string `json:",omitempty"`
+	// User is the user owning the dataset, empty for system and boot datasets.
+	User string `json:",omitempty"`
+	// BootfsDatasets is the list of system datasets a user dataset is attached to.
+	BootfsDatasets string `json:",omitempty"`
+	// Stream is the prefix of the consecutive container entries holding the replication stream.
+	Stream string
+}
+
+// ExportState writes to w a container with a manifest and the replication streams of every dataset of system state name.
+// name needs to be a snapshot. If from is not empty, streams are incremental from this earlier state of the same machine,
+// or its bookmarks if it was removed, for datasets existing in both states.
+// If withUserData is true, user datasets attached to the state are exported as well.
+func (ms *Machines) ExportState(ctx context.Context, name, from string, withUserData bool, w io.Writer) error {
+	s, err := ms.IDToState(ctx, name, "")
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+	if !s.isSnapshot() {
+		return fmt.Errorf(i18n.G("%s isn't a snapshot: only saved states can be exported"), s.ID)
+	}
+	base, snapshot := splitSnapshotName(s.ID)
+
+	var fromSnapshot string
+	if from != "" {
+		fromID, fromTime, err := ms.exportBase(ctx, from)
+		if err != nil {
+			return err
+		}
+		fromBase, fromSnap := splitSnapshotOrBookmarkName(fromID)
+		if fromBase != base || !fromTime.Before(s.LastUsed) {
+			return fmt.Errorf(i18n.G("%s isn't an earlier state of the same machine than %s"), fromID, s.ID)
+		}
+		fromSnapshot = fromSnap
+	}
+
+	existing := ms.datasetsByName()
+	for _, b := range ms.z.Bookmarks() {
+		existing[b.Name] = b
+	}
+
+	datasets := s.getDatasets()
+	owners := make(map[string]string)
+	if withUserData {
+		for user, us := range s.Users {
+			for _, d := range us.getDatasets() {
+				owners[d.Name] = user
+				datasets = append(datasets, d)
+			}
+		}
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		bi, _ := splitSnapshotName(datasets[i].Name)
+		bj, _ := splitSnapshotName(datasets[j].Name)
+		return bi < bj
+	})
+
+	manifest := exportManifest{
+		Version:         exportVersion,
+		State:           s.ID,
+		IncrementalFrom: fromSnapshot,
+	}
+	for i, d := range datasets {
+		// Only datasets of the state snapshot are exported: user states can be linked with a different name.
+		dBase, dSnap := splitSnapshotName(d.Name)
+		if dSnap != snapshot {
+			log.Warningf(ctx, i18n.G("Skipping %s which isn't a snapshot of state %s"), d.Name, s.ID)
+			continue
+		}
+		e := exportedDataset{
+			Name:   d.Name,
+			User:   owners[d.Name],
+			Stream: fmt.Sprintf("streams/%d", i),
+		}
+		// User snapshots don't store to which system datasets they are attached, but their filesystem does.
+		if fs, ok := existing[dBase]; ok && e.User != "" {
+			e.BootfsDatasets = fs.BootfsDatasets
+		}
+		// Removed states can still be a base through their bookmarks.
+		if _, ok := existing[dBase+"@"+fromSnapshot]; ok && fromSnapshot != "" {
+			e.From = dBase + "@" + fromSnapshot
+		} else if _, ok := existing[dBase+"#"+fromSnapshot]; ok && fromSnapshot != "" {
+			e.From = dBase + "#" + fromSnapshot
+		}
+		manifest.Datasets = append(manifest.Datasets, e)
+	}
+
+	tw := tar.NewWriter(w)
+	m, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't encode manifest: %v"), err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: exportManifestName, Mode: 0600, Size: int64(len(m))}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write manifest: %v"), err)
+	}
+	if _, err := tw.Write(m); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write manifest: %v"), err)
+	}
+
+	buf := make([]byte, streamChunkSize)
+	for _, e := range manifest.Datasets {
+		if e.From != "" {
+			log.Infof(ctx, i18n.G("Exporting %s incrementally from %s"), e.Name, e.From)
+		} else {
+			log.Infof(ctx, i18n.G("Exporting %s"), e.Name)
+		}
+		if err := ms.writeStream(ctx, tw, e, buf); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf(i18n.G("couldn't finalize export: %v"), err)
+	}
+	return nil
+}
+
+// exportBase returns the ID and creation time of from, the base of an incremental export. from is a saved state or,
+// once removed, its bookmark, as <root dataset>#<snapshot name> or under its former state ID.
+func (ms *Machines) exportBase(ctx context.Context, from string) (string, time.Time, error) {
+	f, err := ms.IDToState(ctx, from, "")
+	if err == nil {
+		if !f.isSnapshot() {
+			return "", time.Time{}, fmt.Errorf(i18n.G("%s isn't a snapshot: exports can only be incremental from a saved state"), f.ID)
+		}
+		return f.ID, f.LastUsed, nil
+	}
+
+	name := from
+	if base, snapshot := splitSnapshotName(from); snapshot != "" {
+		name = base + "#" + snapshot
+	}
+	for _, b := range ms.z.Bookmarks() {
+		if b.Name == name {
+			return b.Name, time.Unix(int64(b.LastUsed), 0), nil
+		}
+	}
+	return "", time.Time{}, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+}
+
+// writeStream appends to tw the replication stream of e, as consecutive entries of at most len(buf) bytes.
+// Each entry size is thus known before writing it, without buffering the whole stream.
+func (ms *Machines) writeStream(ctx context.Context, tw *tar.Writer, e exportedDataset, buf []byte) error {
+	w := &chunkWriter{tw: tw, prefix: e.Stream, buf: buf[:0]}
+	if err := ms.z.Send(ctx, e.Name, e.From, w); err != nil {
+		return err
+	}
+	// An empty stream still has one entry, so that import can check it.
+	if len(w.buf) > 0 || w.n == 0 {
+		if err := w.flush(); err != nil {
+			return fmt.Errorf(i18n.G("couldn't write stream of %s: %v"), e.Name, err)
+		}
+	}
+	return nil
+}
+
+// chunkName returns the name of the nth container entry of stream.
+func chunkName(stream string, n int) string {
+	return fmt.Sprintf("%s/%06d", stream, n)
+}
+
+// chunkWriter writes a stream as container entries of at most cap(buf) bytes.
+type chunkWriter struct {
+	tw     *tar.Writer
+	prefix string
+	buf    []byte
+	// n is the number of entries already written.
+	n int
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		l := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+l]
+		p = p[l:]
+		written += l
+		if len(w.buf) < cap(w.buf) {
+			continue
+		}
+		if err := w.flush(); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// flush writes the buffered content as the next entry of the stream.
+func (w *chunkWriter) flush() error {
+	if err := w.tw.WriteHeader(&tar.Header{Name: chunkName(w.prefix, w.n), Mode: 0600, Size: int64(len(w.buf))}); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(w.buf); err != nil {
+		return err
+	}
+	w.n++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// containerReader reads entries of a container, allowing to put back the last read header.
+type containerReader struct {
+	tr      *tar.Reader
+	pending *tar.Header
+}
+
+func (c *containerReader) next() (*tar.Header, error) {
+	if h := c.pending; h != nil {
+		c.pending = nil
+		return h, nil
+	}
+	return c.tr.Next()
+}
+
+// stream returns a reader on the stream written by writeStream as the next entries of the container.
+func (c *containerReader) stream(prefix string) (io.Reader, error) {
+	h, err := c.next()
+	if err != nil {
+		return nil, err
+	}
+	if h.Name != chunkName(prefix, 0) {
+		return nil, fmt.Errorf(i18n.G("invalid export: expected stream %s, got %s"), prefix, h.Name)
+	}
+	return &streamReader{c: c, prefix: prefix, n: 1}, nil
+}
+
+// streamReader concatenates the content of the consecutive entries of a stream.
+type streamReader struct {
+	c      *containerReader
+	prefix string
+	// n is the index of the next expected entry.
+	n    int
+	done bool
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for !r.done {
+		n, err := r.c.tr.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+		if n > 0 {
+			return n, nil
+		}
+
+		// Current entry is consumed: continue with the next one if it belongs to this stream.
+		h, err := r.c.next()
+		if err == io.EOF {
+			r.done = true
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		if !strings.HasPrefix(h.Name, r.prefix+"/") {
+			r.c.pending = h
+			r.done = true
+			break
+		}
+		if h.Name != chunkName(r.prefix, r.n) {
+			return 0, fmt.Errorf(i18n.G("invalid export: expected %s, got %s"), chunkName(r.prefix, r.n), h.Name)
+		}
+		r.n++
+	}
+	return 0, io.EOF
+}
+
+// ImportState receives every dataset of a container written by ExportState and returns the ID of the imported system state.
+// If pool is not empty, system and user datasets are received in this pool instead of the one they were exported from.
+// Boot datasets are always received in their original pool.
+// Full streams create new datasets, which aren't mounted automatically, and the state is then a history state of
+// the imported machine. Incremental streams add the state to an existing machine.
+func (ms *Machines) ImportState(ctx context.Context, r io.Reader, pool string) (string, error) {
+	tr := tar.NewReader(r)
+	h, err := tr.Next()
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't read export: %v"), err)
+	}
+	if h.Name != exportManifestName {
+		return "", fmt.Errorf(i18n.G("invalid export: expected %s first, got %s"), exportManifestName, h.Name)
+	}
+	var manifest exportManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return "", fmt.Errorf(i18n.G("invalid export manifest: %v"), err)
+	}
+	if manifest.Version != exportVersion {
+		return "", fmt.Errorf(i18n.G("unsupported export version %d"), manifest.Version)
+	}
+	if len(manifest.Datasets) == 0 {
+		return "", errors.New(i18n.G("invalid export: no dataset to import"))
+	}
+
+	srcPool := strings.Split(manifest.State, "/")[0]
+	rename := func(name string) string {
+		if pool == "" || strings.Split(name, "/")[0] != srcPool {
+			return name
+		}
+		return pool + strings.TrimPrefix(name, srcPool)
+	}
+
+	existing := make(map[string]bool)
+	for _, d := range ms.z.Datasets() {
+		existing[d.Name] = true
+	}
+
+	// Check every dataset before receiving anything to not import a partial state.
+	received := make(map[string]bool)
+	for _, e := range manifest.Datasets {
+		target := rename(e.Name)
+		base, snapshot := splitSnapshotName(target)
+		if existing[target] {
+			return "", fmt.Errorf(i18n.G("%s already exists"), target)
+		}
+		if e.From == "" && existing[base] {
+			return "", fmt.Errorf(i18n.G("%s already exists: only incremental exports can be imported on an existing machine"), base)
+		}
+		if e.From != "" {
+			_, fromSnapshot := splitSnapshotOrBookmarkName(e.From)
+			if !existing[base+"@"+fromSnapshot] {
+				return "", fmt.Errorf(i18n.G("%s needs %s to be imported incrementally"), target, base+"@"+fromSnapshot)
+			}
+		}
+		if snapshot == "" {
+			return "", fmt.Errorf(i18n.G("invalid export: %s isn't a snapshot"), e.Name)
+		}
+		received[base] = true
+	}
+
+	// Create missing containers, like <pool>/ROOT, which aren't part of the export.
+	var bases []string
+	for _, e := range manifest.Datasets {
+		base, _ := splitSnapshotName(rename(e.Name))
+		bases = append(bases, base)
+	}
+	if err := ms.createContainers(ctx, bases, func(n string) bool { return existing[n] || received[n] }); err != nil {
+		return "", err
+	}
+
+	c := &containerReader{tr: tr}
+	nt := ms.z.NewNoTransaction(ctx)
+	defer ms.refresh(ctx)
+	for _, e := range manifest.Datasets {
+		sr, err := c.stream(e.Stream)
+		if err != nil {
+			return "", fmt.Errorf(i18n.G("couldn't read stream of %s: %v"), e.Name, err)
+		}
+
+		base, _ := splitSnapshotName(rename(e.Name))
+		props := make(map[string]string)
+		// Imported machines are never mounted automatically, as their mountpoints collide with the current machine.
+		if e.From == "" {
+			props[libzfs.CanmountProp] = "noauto"
+		}
+		if e.BootfsDatasets != "" {
+			var bootfsDatasets []string
+			for _, n := range strings.Split(e.BootfsDatasets, bootfsdatasetsSeparator) {
+				bootfsDatasets = append(bootfsDatasets, rename(n))
+			}
+			props[libzfs.BootfsDatasetsProp] = strings.Join(bootfsDatasets, bootfsdatasetsSeparator)
+		}
+
+		log.Infof(ctx, i18n.G("Importing %s"), rename(e.Name))
+		if err := nt.Receive(base, sr, props); err != nil {
+			return "", err
+		}
+		// Skip any trailing content zfs didn't read to get to the next stream.
+		if _, err := io.Copy(ioutil.Discard, sr); err != nil {
+			return "", fmt.Errorf(i18n.G("couldn't read stream of %s: %v"), e.Name, err)
+		}
+	}
+
+	return rename(manifest.State), nil
+}
+
+// createContainers creates, without mounting them, every missing parent of datasets. exists reports if a dataset
+// already exists or will be created by other means.
+func (ms *Machines) createContainers(ctx context.Context, datasets []string, exists func(string) bool) error {
+	created := make(map[string]bool)
+	var containers []string
+	for _, n := range datasets {
+		for p := filepath.Dir(n); strings.Contains(p, "/"); p = filepath.Dir(p) {
+			if exists(p) || created[p] {
+				continue
+			}
+			created[p] = true
+			containers = append(containers, p)
+		}
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+
+	sort.Strings(containers)
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+	for _, c := range containers {
+		log.Debugf(ctx, "creating container %s", c)
+		if err := t.Create(c, "", "off"); err != nil {
+			cancel()
+			return err
+		}
+	}
+	return nil
+}
+
+// replicationGroup is a set of filesystem datasets replicated and pruned together, like the datasets of a system
+// or user state.
+type replicationGroup struct {
+	// name of the group for logging purposes.
+	name string
+	// id is the root dataset of the group, from which states are listed on the target pool.
+	id string
+	// roots are the root datasets of the group. They include id.
+	roots []string
+	// datasets are all filesystem datasets of the group, parents first.
+	datasets []*zfs.Dataset
+	rules    config.HistoryRules
+}
+
+// Replicate copies, incrementally, every system and user states of the current machine to the configured target pool
+// and prunes the replicated states with the history rules.
+// Each dataset records its last replicated snapshot, so that an interrupted replication resumes from it.
+func (ms *Machines) Replicate(ctx context.Context) error {
+	target := ms.conf.Replication.TargetPool
+	if target == "" {
+		return errors.New(i18n.G("no replication target pool configured"))
+	}
+	if !ms.current.isZsys() {
+		return errors.New(i18n.G("current machine isn't Zsys, nothing to replicate"))
+	}
+
+	existing := ms.datasetsByName()
+	if _, ok := existing[target]; !ok {
+		return fmt.Errorf(i18n.G("replication target pool %q doesn't exist"), target)
+	}
+	defer ms.refresh(ctx)
+
+	m := ms.current
+	groups := []replicationGroup{newReplicationGroup(i18n.G("system"), &m.State, ms.conf.History)}
+	for _, user := range sortedUsers(m.State.Users) {
+		groups = append(groups, newReplicationGroup(fmt.Sprintf(i18n.G("user %s"), user), m.State.Users[user], ms.conf.UserHistoryFor(user)))
+	}
+
+	var toReceive []string
+	received := make(map[string]bool)
+	for _, g := range groups {
+		for _, d := range g.datasets {
+			toReceive = append(toReceive, target+"/"+d.Name)
+			received[target+"/"+d.Name] = true
+		}
+	}
+	if err := ms.createContainers(ctx, toReceive, func(n string) bool {
+		_, ok := existing[n]
+		return ok || received[n]
+	}); err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		log.Infof(ctx, i18n.G("Replicating %s states"), g.name)
+		for _, d := range g.datasets {
+			if err := ms.replicateDataset(ctx, d, target); err != nil {
+				return err
+			}
+		}
+		if err := ms.pruneReplicated(ctx, g, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newReplicationGroup returns the replication group of all filesystem datasets of state s.
+func newReplicationGroup(name string, s *State, rules config.HistoryRules) replicationGroup {
+	g := replicationGroup{
+		name:  name,
+		id:    s.ID,
+		rules: rules,
+	}
+	for root, ds := range s.Datasets {
+		g.roots = append(g.roots, root)
+		for _, d := range ds {
+			if d.IsSnapshot {
+				continue
+			}
+			g.datasets = append(g.datasets, d)
+		}
+	}
+	sort.Strings(g.roots)
+	sort.Slice(g.datasets, func(i, j int) bool { return g.datasets[i].Name < g.datasets[j].Name })
+	return g
+}
+
+// replicateDataset sends to the target pool every snapshot of filesystem dataset d more recent than the last replicated one.
+// Datasets whose copy diverged from the source are skipped with a warning.
+func (ms *Machines) replicateDataset(ctx context.Context, d *zfs.Dataset, target string) error {
+	snapshots := ms.snapshotsOf(d.Name)
+	if len(snapshots) == 0 {
+		log.Debugf(ctx, "%s has no snapshot to replicate", d.Name)
+		return nil
+	}
+	dst := target + "/" + d.Name
+
+	var from string
+	if _, ok := ms.datasetsByName()[dst]; ok {
+		targetSnapshots := ms.snapshotsOf(dst)
+		if len(targetSnapshots) == 0 {
+			log.Warningf(ctx, i18n.G("Skipping %s: %s exists and has no snapshot in common"), d.Name, dst)
+			return nil
+		}
+		_, latest := splitSnapshotName(targetSnapshots[len(targetSnapshots)-1].Name)
+		if d.LastReplicated != "" && d.LastReplicated != latest {
+			log.Infof(ctx, i18n.G("Last replicated snapshot of %s is %s on %s, resuming from it"), d.Name, latest, target)
+		}
+		from = latest
+	}
+
+	var start int
+	// fromName is the snapshot, or its bookmark if it was removed, the next stream is incremental from.
+	var fromName string
+	if from != "" {
+		start = -1
+		for i, s := range snapshots {
+			if _, snap := splitSnapshotName(s.Name); snap == from {
+				start, fromName = i+1, s.Name
+				break
+			}
+		}
+		if start == -1 {
+			for _, b := range ms.z.Bookmarks() {
+				if b.Name != d.Name+"#"+from {
+					continue
+				}
+				start, fromName = len(snapshots), b.Name
+				for i, s := range snapshots {
+					if s.LastUsed > b.LastUsed {
+						start = i
+						break
+					}
+				}
+			}
+		}
+		if start == -1 {
+			log.Warningf(ctx, i18n.G("Skipping %s: %s diverged as its latest snapshot %s has no snapshot or bookmark on the source"), d.Name, dst, from)
+			return nil
+		}
+	}
+
+	nt := ms.z.NewNoTransaction(ctx)
+	for _, s := range snapshots[start:] {
+		_, snap := splitSnapshotName(s.Name)
+		props := make(map[string]string)
+		if from != "" {
+			log.Infof(ctx, i18n.G("Replicating %s incrementally from %s"), s.Name, from)
+		} else {
+			// Replicated datasets are never mounted automatically, as their mountpoints collide with the source ones.
+			props[libzfs.CanmountProp] = "noauto"
+			log.Infof(ctx, i18n.G("Replicating %s"), s.Name)
+		}
+
+		pr, pw := io.Pipe()
+		errSend := make(chan error)
+		go func() {
+			err := ms.z.Send(ctx, s.Name, fromName, pw)
+			pw.CloseWithError(err)
+			errSend <- err
+		}()
+		err := nt.Receive(dst, pr, props)
+		pr.Close()
+		if errS := <-errSend; errS != nil {
+			return errS
+		}
+		if err != nil {
+			return err
+		}
+
+		t, cancel := ms.z.NewTransaction(ctx)
+		if err := t.SetProperty(libzfs.LastReplicatedProp, snap, d.Name, true); err != nil {
+			cancel()
+			t.Done()
+			return err
+		}
+		t.Done()
+		from, fromName = snap, s.Name
+	}
+
+	return nil
+}
+
+// pruneReplicated removes replicated states of g on the target pool which aren't kept by the history rules of g.
+// The most recent state is always kept as the next replication is incremental from it.
+func (ms *Machines) pruneReplicated(ctx context.Context, g replicationGroup, target string) error {
+	var states sortedReverseByTimeStates
+	for _, d := range ms.snapshotsOf(target + "/" + g.id) {
+		states = append(states, &State{
+			ID:       d.Name,
+			LastUsed: time.Unix(int64(d.LastUsed), 0),
+			Datasets: map[string][]*zfs.Dataset{d.Name: {d}},
+		})
+	}
+	sort.Sort(states)
+
+	var statesToRemove []*State
+	var newestStateIndex int
+	for _, bucket := range computeBuckets(ctx, ms.time.Now(), g.rules) {
+		if newestStateIndex >= len(states) {
+			break
+		}
+		if states[newestStateIndex].LastUsed.Before(bucket.start) {
+			continue
+		}
+		var i int
+		for i = newestStateIndex; i < len(states) && !states[i].LastUsed.Before(bucket.start); i++ {
+		}
+		if bucket.samples == -1 {
+			newestStateIndex = i
+			continue
+		}
+
+		bucketStates := make([]stateWithKeep, 0, i-newestStateIndex)
+		for j := newestStateIndex; j < i; j++ {
+			s := states[j]
+			keep := keepUnknown
+			if j == 0 || j < g.rules.KeepLast || s.Pinned() || !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
+				keep = keepYes
+			}
+			bucketStates = append(bucketStates, stateWithKeep{State: s, keep: keep})
+		}
+		newestStateIndex = i
+
+		statesToRemove = append(statesToRemove, selectStatesToRemove(ctx, bucket.samples, bucketStates)...)
+	}
+
+	nt := ms.z.NewNoTransaction(ctx)
+	for _, s := range statesToRemove {
+		_, snap := splitSnapshotName(s.ID)
+		for _, root := range g.roots {
+			n := target + "/" + root + "@" + snap
+			if _, ok := ms.datasetsByName()[n]; !ok {
+				continue
+			}
+			log.Infof(ctx, i18n.G("Removing replicated state %s"), n)
+			if err := nt.Destroy(n); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// datasetsByName returns all datasets, indexed by name.
+func (ms *Machines) datasetsByName() map[string]*zfs.Dataset {
+	r := make(map[string]*zfs.Dataset)
+	for _, d := range ms.z.Datasets() {
+		r[d.Name] = d
+	}
+	return r
+}
+
+// snapshotsOf returns the snapshots of filesystem dataset name, oldest first.
+func (ms *Machines) snapshotsOf(name string) (snapshots []*zfs.Dataset) {
+	for _, d := range ms.z.Datasets() {
+		if base, _ := splitSnapshotName(d.Name); d.IsSnapshot && base == name {
+			snapshots = append(snapshots, d)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].LastUsed == snapshots[j].LastUsed {
+			return snapshots[i].Name < snapshots[j].Name
+		}
+		return snapshots[i].LastUsed < snapshots[j].LastUsed
+	})
+	return snapshots
+}
@@ -4,30 +4,50 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/zfs"
 )
 
 const automatedSnapshotPrefix = "autozsys_"
 
+// ErrNotEnoughFreeSpace is returned when a state can't be saved because a pool is below the minimum free space
+type ErrNotEnoughFreeSpace struct {
+	// Pool is the name of the pool below the minimum free space
+	Pool string
+	// Free is the free space on the pool, in percent
+	Free int
+	// Min is the minimum free space to keep on pools, in percent
+	Min int
+}
+
+func (e *ErrNotEnoughFreeSpace) Error() string {
+	return fmt.Sprintf(i18n.G(`Minimum free space to take a snapshot and preserve ZFS performance is %d%%.
+Free space on pool %q is %d%%.
+Please remove some states manually to free up space.`), e.Min, e.Pool, e.Free)
+}
+
 // CreateSystemSnapshot creates a snapshot of a system and all users datasets.
 // If snapshotname is not empty, it is used as the id of the snapshot otherwise an id
 // is generated with a random string.
-func (ms *Machines) CreateSystemSnapshot(ctx context.Context, snapshotname string) (string, error) {
-	return ms.createSnapshot(ctx, snapshotname, "")
+// description and label, if not empty, are attached to every snapshot of the new state.
+func (ms *Machines) CreateSystemSnapshot(ctx context.Context, snapshotname, description, label string) (string, error) {
+	return ms.createSnapshot(ctx, snapshotname, "", description, label)
 }
 
 // CreateUserSnapshot creates a snapshot for the provided user.
 // If snapshotName is not empty, it is used as the id of the snapshot otherwise an id
 // is generated with a random string.
 // userName is the name of the user to snapshot the datasets from.
-func (ms *Machines) CreateUserSnapshot(ctx context.Context, userName, snapshotName string) (string, error) {
+// description and label, if not empty, are attached to every snapshot of the new state.
+func (ms *Machines) CreateUserSnapshot(ctx context.Context, userName, snapshotName, description, label string) (string, error) {
 	if userName == "" {
 		return "", errors.New(i18n.G("Needs a valid user name, got nothing"))
 	}
-	return ms.createSnapshot(ctx, snapshotName, userName)
+	return ms.createSnapshot(ctx, snapshotName, userName, description, label)
 }
 
 // createSnapshot creates a snapshot of a system and all users datasets.
@@ -35,7 +55,7 @@ func (ms *Machines) CreateUserSnapshot(ctx context.Context, userName, snapshotNa
 // is generated with a random string.
 // If onlyUser is empty a snapshot of all the system datasets is taken,
 // otherwise only a snapshot of the given username is done
-func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser string) (string, error) {
+func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser string, description, label string) (string, error) {
 	m := ms.current
 	if !m.isZsys() {
 		return "", errors.New(i18n.G("Current machine isn't Zsys, nothing to create"))
@@ -47,6 +67,9 @@ func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser st
 	if err := validateStateName(name); err != nil {
 		return "", err
 	}
+	if err := validateAnnotations(description, label); err != nil {
+		return "", err
+	}
 
 	t, cancel := ms.z.NewTransaction(ctx)
 	defer t.Done()
@@ -66,7 +89,20 @@ func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser st
 		toSnapshot = userState.getDatasets()
 	} else {
 		toSnapshot = append(m.State.getDatasets(), m.State.getUsersDatasets()...)
+		// Persistent datasets can opt in to follow system history.
+		toSnapshot = append(toSnapshot, ms.followingDatasets()...)
+	}
+
+	// Excluded datasets are never saved, even if they are still attached to a state.
+	var saved []*zfs.Dataset
+	for _, d := range toSnapshot {
+		if d.Excluded {
+			log.Debugf(ctx, i18n.G("Ignoring %q: excluded from states"), d.Name)
+			continue
+		}
+		saved = append(saved, d)
 	}
+	toSnapshot = saved
 
 	// check pool capacity before saving state
 	pools := make(map[string]bool)
@@ -81,32 +117,89 @@ func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser st
 		}
 
 		if free <= ms.conf.General.MinFreePoolSpace {
-			return "", fmt.Errorf(i18n.G(`Minimum free space to take a snapshot and preserve ZFS performance is %d%%.
-Free space on pool %q is %d%%.
-Please remove some states manually to free up space.`), ms.conf.General.MinFreePoolSpace, p, free)
+			return "", &ErrNotEnoughFreeSpace{Pool: p, Free: free, Min: ms.conf.General.MinFreePoolSpace}
 		}
 	}
 
+	// Only store annotations which were requested
+	var desc, lab *string
+	if description != "" {
+		desc = &description
+	}
+	if label != "" {
+		lab = &label
+	}
+
+	if err := runHooks(ctx, filepath.Join(ms.hooksDir, preSaveHooksDir), saveHooksEnv(name, onlyUser, toSnapshot, "")); err != nil {
+		cancel()
+		ms.runPostSaveHooks(ctx, name, onlyUser, toSnapshot, err)
+		return "", err
+	}
+
 	for _, d := range toSnapshot {
 		if err := t.Snapshot(name, d.Name, false); err != nil {
 			cancel()
+			ms.runPostSaveHooks(ctx, name, onlyUser, toSnapshot, err)
+			return "", err
+		}
+		if err := annotateDataset(t, d.Name+"@"+name, desc, lab); err != nil {
+			cancel()
+			ms.runPostSaveHooks(ctx, name, onlyUser, toSnapshot, err)
 			return "", err
 		}
 	}
 
 	ms.refresh(ctx)
+	ms.runPostSaveHooks(ctx, name, onlyUser, toSnapshot, nil)
 	return name, nil
 }
 
+// runPostSaveHooks runs post-save hooks once pre-save ones were started, even if saving failed, so that they can
+// undo what pre-save hooks did. Their failure doesn't fail the state save.
+func (ms *Machines) runPostSaveHooks(ctx context.Context, name, user string, datasets []*zfs.Dataset, saveErr error) {
+	result := "success"
+	if saveErr != nil {
+		result = "failure"
+	}
+	if err := runHooks(ctx, filepath.Join(ms.hooksDir, postSaveHooksDir), saveHooksEnv(name, user, datasets, result)); err != nil {
+		log.Warningf(ctx, i18n.G("Post-save hooks failed: %v"), err)
+	}
+}
+
 func validateStateName(stateName string) error {
 	if strings.HasPrefix(stateName, "-") {
 		return errors.New(i18n.G("state name cannot start with '-'"))
 	}
 
+	if uniqueChars := unsupportedChars(stateName); uniqueChars != nil {
+		return fmt.Errorf(i18n.G("the following characters are not supported in state name: '%s'"), strings.Join(uniqueChars, "','"))
+	}
+
+	return nil
+}
+
+// validateAnnotations checks that description and label can be stored on datasets and displayed in the boot menu.
+func validateAnnotations(description, label string) error {
+	if strings.ContainsAny(description, "\n\r") {
+		return errors.New(i18n.G("state description must be a single line"))
+	}
+
+	if strings.HasPrefix(label, "-") {
+		return errors.New(i18n.G("state label cannot start with '-'"))
+	}
+	if uniqueChars := unsupportedChars(label); uniqueChars != nil {
+		return fmt.Errorf(i18n.G("the following characters are not supported in state label: '%s'"), strings.Join(uniqueChars, "','"))
+	}
+
+	return nil
+}
+
+// unsupportedChars returns the deduplicated list of characters in s that we don't support in state names.
+func unsupportedChars(s string) []string {
 	// List of valid characters from zcommon->zfs_namecheck->valid_char()
 	// Space is also valid but not supported by grub and init so booting from a snapshot with a space in the name fails
 	var invalidChars []string
-	for _, c := range stateName {
+	for _, c := range s {
 		if !((c >= 'a' && c <= 'z') ||
 			(c >= 'A' && c <= 'Z') ||
 			(c >= '0' && c <= '9') ||
@@ -125,7 +218,7 @@ func validateStateName(stateName string) error {
 				uniqueChars = append(uniqueChars, c)
 			}
 		}
-		return fmt.Errorf(i18n.G("the following characters are not supported in state name: '%s'"), strings.Join(uniqueChars, "','"))
+		return uniqueChars
 	}
 
 	return nil
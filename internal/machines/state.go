@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/units"
 	"github.com/ubuntu/zsys/internal/zfs"
 	"github.com/ubuntu/zsys/internal/zfs/libzfs"
 )
@@ -38,27 +40,7 @@ type stateWithLinkedState struct {
 func (s *State) getDependencies(ctx context.Context, ms *Machines) (stateDeps []stateWithLinkedState, datasetDeps []*zfs.Dataset) {
 	nt := ms.z.NewNoTransaction(ctx)
 
-	// build cache and lookup for all states
-	var allStates []*State
-	for _, m := range ms.all {
-		allStates = append(allStates, &m.State)
-		for _, h := range m.History {
-			allStates = append(allStates, h)
-		}
-		for _, ustates := range m.AllUsersStates {
-			for _, us := range ustates {
-				allStates = append(allStates, us)
-			}
-		}
-	}
-	datasetToState := make(map[*zfs.Dataset]*State)
-	for _, s := range allStates {
-		for _, ds := range s.Datasets {
-			for _, d := range ds {
-				datasetToState[d] = s
-			}
-		}
-	}
+	allStates, datasetToState := ms.statesByDataset()
 
 	var reason string
 	// Direct call on user datasets linked to multiple states: only unlink from attached states and don’t list any dep
@@ -103,6 +85,31 @@ func (s *State) getDependencies(ctx context.Context, ms *Machines) (stateDeps []
 	return s.getDependenciesWithCache(nt, ms, reason, allStates, datasetToState, make(map[stateWithLinkedState]stateToDeps))
 }
 
+// statesByDataset returns all states of every machines and a lookup of which state each dataset belongs to.
+func (ms *Machines) statesByDataset() (allStates []*State, datasetToState map[*zfs.Dataset]*State) {
+	for _, m := range ms.all {
+		allStates = append(allStates, &m.State)
+		for _, h := range m.History {
+			allStates = append(allStates, h)
+		}
+		for _, ustates := range m.AllUsersStates {
+			for _, us := range ustates {
+				allStates = append(allStates, us)
+			}
+		}
+	}
+	datasetToState = make(map[*zfs.Dataset]*State)
+	for _, s := range allStates {
+		for _, ds := range s.Datasets {
+			for _, d := range ds {
+				datasetToState[d] = s
+			}
+		}
+	}
+
+	return allStates, datasetToState
+}
+
 type stateToDeps struct {
 	stateDeps   []stateWithLinkedState
 	datasetDeps []*zfs.Dataset
@@ -224,36 +231,18 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 			}
 		}
 
+		// Pinned states, which are going to be removed and not only unlinked
+		for _, curr := range states {
+			if curr.linkedStateID == "" && curr.Pinned() {
+				errmsg += fmt.Sprintf(i18n.G("%s is pinned\n"), curr.ID)
+			}
+		}
+
 		// we always added us as a system state
 		if len(states) > len(s.Users)+1 {
 			errmsg += fmt.Sprintf(i18n.G("%s has a dependency linked to some states:\n"), s.ID)
 			for i := len(states) - 2; i >= 0; i-- {
-				curr := states[i]
-				lu := i18n.G("No timestamp")
-				if !curr.LastUsed.Equal(time.Time{}) {
-					lu = curr.LastUsed.Format("2006-01-02 15:04:05")
-				}
-				var additionalInfo string
-				if curr.linkedStateID != "" {
-					additionalInfo = fmt.Sprintf(" "+i18n.G("to unlink from %s"), curr.linkedStateID)
-				} else {
-					bmap := make(map[string]bool)
-					for _, d := range curr.Datasets {
-						for _, b := range strings.Split(d[0].BootfsDatasets, bootfsdatasetsSeparator) {
-							bmap[b] = true
-						}
-					}
-					var keys []string
-					for k := range bmap {
-						if strings.TrimSpace(k) != "" {
-							keys = append(keys, k)
-						}
-					}
-					if len(keys) > 0 {
-						additionalInfo = fmt.Sprintf(" "+i18n.G("to remove. Currently linked to %s"), strings.Join(keys, ", "))
-					}
-				}
-				errmsg += fmt.Sprintf(i18n.G("  - %s (%s)%s\n"), curr.ID, lu, additionalInfo)
+				errmsg += describeStateDependency(states[i])
 			}
 		}
 		if len(datasets) > 0 {
@@ -267,6 +256,53 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 		}
 	}
 
+	return ms.removeDependencies(ctx, states, datasets, dryrun)
+}
+
+// describeStateDependency returns a line describing what will happen to state curr when removing its dependencies.
+func describeStateDependency(curr stateWithLinkedState) string {
+	lu := i18n.G("No timestamp")
+	if !curr.LastUsed.Equal(time.Time{}) {
+		lu = curr.LastUsed.Format("2006-01-02 15:04:05")
+	}
+	var additionalInfo string
+	if curr.linkedStateID != "" {
+		additionalInfo = fmt.Sprintf(" "+i18n.G("to unlink from %s"), curr.linkedStateID)
+	} else {
+		bmap := make(map[string]bool)
+		for _, d := range curr.Datasets {
+			for _, b := range strings.Split(d[0].BootfsDatasets, bootfsdatasetsSeparator) {
+				bmap[b] = true
+			}
+		}
+		var keys []string
+		for k := range bmap {
+			if strings.TrimSpace(k) != "" {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) > 0 {
+			additionalInfo = fmt.Sprintf(" "+i18n.G("to remove. Currently linked to %s"), strings.Join(keys, ", "))
+		}
+	}
+	return fmt.Sprintf(i18n.G("  - %s (%s)%s\n"), curr.ID, lu, additionalInfo)
+}
+
+// removeDependencies destroys datasets, then removes or unlinks states in order, as returned by getDependencies.
+// On dryrun, it only prints what would be removed and the space freed.
+func (ms *Machines) removeDependencies(ctx context.Context, states []stateWithLinkedState, datasets []*zfs.Dataset, dryrun bool) error {
+	if dryrun {
+		// User states linked to a removed system state are only untagged
+		toDestroy := datasets
+		for _, state := range states {
+			if state.linkedStateID != "" {
+				continue
+			}
+			toDestroy = append(toDestroy, state.getDatasets()...)
+		}
+		log.RemotePrintf(ctx, i18n.G("Space freed: %s\n"), units.HumanSize(reclaimableSpace(toDestroy)))
+	}
+
 	// Remove datasets
 	nt := ms.z.NewNoTransaction(ctx)
 	for _, d := range datasets {
@@ -274,6 +310,10 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 			log.RemotePrintf(ctx, i18n.G("Deleting dataset %s\n"), d.Name)
 			continue
 		}
+		if err := checkCancelled(ctx); err != nil {
+			ms.refresh(ctx)
+			return err
+		}
 		if err := nt.Destroy(d.Name); err != nil {
 			return fmt.Errorf(i18n.G("Couldn't remove dataset %s: %v"), d.Name, err)
 		}
@@ -285,6 +325,10 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 			log.RemotePrintf(ctx, i18n.G("Deleting state %s\n"), state.ID)
 			continue
 		}
+		if err := checkCancelled(ctx); err != nil {
+			ms.refresh(ctx)
+			return err
+		}
 		if err := state.remove(ctx, ms, state.linkedStateID); err != nil {
 			return fmt.Errorf(i18n.G("Couldn't remove state %s: %v"), state.ID, err)
 		}
@@ -294,6 +338,105 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 	return nil
 }
 
+// DatasetDiff lists changed paths of a dataset between two states.
+// From or To is empty if the dataset only exists in one of the states: the whole dataset is then reported
+// as added or removed.
+type DatasetDiff struct {
+	From    string
+	To      string
+	Changes []libzfs.DiffEntry
+}
+
+// StateDiff returns changed paths between system state from and to, per dataset. Paths are absolute on the system.
+// from needs to be a snapshot. If to is empty, the current system state is used.
+// Both states need to share their lineage: from must be an earlier snapshot of to, or of the state to was cloned from.
+// Their filesystem datasets need to be mounted.
+// If withUserData is true, user datasets attached to those states are compared as well.
+func (ms *Machines) StateDiff(ctx context.Context, from, to string, withUserData bool) ([]DatasetDiff, error) {
+	fromState, err := ms.IDToState(ctx, from, "")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+	if !fromState.isSnapshot() {
+		return nil, fmt.Errorf(i18n.G("%s isn't a snapshot: differences can only be computed from a snapshot state"), fromState.ID)
+	}
+
+	var toState *State
+	if to == "" {
+		if ms.current == nil || !ms.current.isZsys() {
+			return nil, errors.New(i18n.G("no current zsys system to compare with"))
+		}
+		toState = &ms.current.State
+	} else if toState, err = ms.IDToState(ctx, to, ""); err != nil {
+		return nil, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+	if fromState == toState {
+		return nil, fmt.Errorf(i18n.G("can't compare %s with itself"), fromState.ID)
+	}
+	if toState.isSnapshot() && fromState.LastUsed.After(toState.LastUsed) {
+		return nil, fmt.Errorf(i18n.G("%s is newer than %s: differences can only be computed from an earlier state"), fromState.ID, toState.ID)
+	}
+
+	log.Debugf(ctx, "computing differences between %s and %s", fromState.ID, toState.ID)
+
+	// Datasets are paired between both states on their mountpoints
+	byMountpoint := func(s *State) map[string]*zfs.Dataset {
+		ds := s.getDatasets()
+		if withUserData {
+			ds = append(ds, s.getUsersDatasets()...)
+		}
+		r := make(map[string]*zfs.Dataset)
+		for _, d := range ds {
+			if d.Mountpoint == "" {
+				log.Debugf(ctx, "ignoring %s without mountpoint", d.Name)
+				continue
+			}
+			r[d.Mountpoint] = d
+		}
+		return r
+	}
+	fromDatasets, toDatasets := byMountpoint(fromState), byMountpoint(toState)
+
+	var mountpoints []string
+	for mp := range fromDatasets {
+		mountpoints = append(mountpoints, mp)
+	}
+	for mp := range toDatasets {
+		if _, ok := fromDatasets[mp]; !ok {
+			mountpoints = append(mountpoints, mp)
+		}
+	}
+	sort.Strings(mountpoints)
+
+	var diffs []DatasetDiff
+	for _, mp := range mountpoints {
+		fd, td := fromDatasets[mp], toDatasets[mp]
+		switch {
+		case td == nil:
+			diffs = append(diffs, DatasetDiff{From: fd.Name, Changes: []libzfs.DiffEntry{{Change: libzfs.DiffRemoved, Path: mp}}})
+		case fd == nil:
+			diffs = append(diffs, DatasetDiff{To: td.Name, Changes: []libzfs.DiffEntry{{Change: libzfs.DiffAdded, Path: mp}}})
+		default:
+			changes, err := ms.z.Diff(ctx, fd.Name, td.Name)
+			if err != nil {
+				return nil, err
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			for i := range changes {
+				changes[i].Path = filepath.Join(mp, changes[i].Path)
+				if changes[i].NewPath != "" {
+					changes[i].NewPath = filepath.Join(mp, changes[i].NewPath)
+				}
+			}
+			diffs = append(diffs, DatasetDiff{From: fd.Name, To: td.Name, Changes: changes})
+		}
+	}
+
+	return diffs, nil
+}
+
 // Remove removes a given state by deleting all of its system datasets and unlink user states
 // If called on system states: always try to destroy this state. all user states will be unlinked.
 // If called on user states:
@@ -371,9 +514,157 @@ func (s *State) remove(ctx context.Context, ms *Machines, linkedStateID string)
 		}
 	}
 
+	// Persistent datasets following system states were saved with it
+	if s.isSnapshot() && !isUserDataset(s.ID) {
+		if err := ms.removeFollowingSnapshots(nt, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AnnotateState sets the description and label of a system state or, if user is not empty, of a user state.
+// A nil description or label leaves the existing value untouched, while an empty one clears it.
+// Annotations are stored on every dataset of the state, being snapshots or clones.
+func (ms *Machines) AnnotateState(ctx context.Context, name, user string, description, label *string) error {
+	if description == nil && label == nil {
+		return errors.New(i18n.G("nothing to annotate: a description or a label is required"))
+	}
+	var desc, lab string
+	if description != nil {
+		desc = *description
+	}
+	if label != nil {
+		lab = *label
+	}
+	if err := validateAnnotations(desc, lab); err != nil {
+		return err
+	}
+
+	s, err := ms.IDToState(ctx, name, user)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Annotating state %q"), s.ID)
+	for _, d := range s.getDatasets() {
+		if err := annotateDataset(t, d.Name, description, label); err != nil {
+			cancel()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// annotateDataset stores description and label, when not nil, on the dataset datasetName.
+func annotateDataset(t *zfs.Transaction, datasetName string, description, label *string) error {
+	if description != nil {
+		if err := t.SetProperty(libzfs.DescriptionProp, *description, datasetName, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set description on %q: ")+config.ErrorFormat, datasetName, err)
+		}
+	}
+	if label != nil {
+		if err := t.SetProperty(libzfs.LabelProp, *label, datasetName, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set label on %q: ")+config.ErrorFormat, datasetName, err)
+		}
+	}
+	return nil
+}
+
+// PinState pins or unpins a system state or, if user is not empty, a user state.
+// Pinned states are never removed by garbage collection and need to be forced for manual removal.
+func (ms *Machines) PinState(ctx context.Context, name, user string, pin bool) error {
+	s, err := ms.IDToState(ctx, name, user)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	value := "no"
+	if pin {
+		value = "yes"
+		log.Infof(ctx, i18n.G("Pinning state %q"), s.ID)
+	} else {
+		log.Infof(ctx, i18n.G("Unpinning state %q"), s.ID)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	for _, d := range s.getDatasets() {
+		if err := t.SetProperty(libzfs.PinnedProp, value, d.Name, true); err != nil {
+			cancel()
+			return fmt.Errorf(i18n.G("couldn't change pinned property on %q: ")+config.ErrorFormat, d.Name, err)
+		}
+	}
+
 	return nil
 }
 
+// Pinned returns if this state is protected from garbage collection, as stored on its main dataset.
+func (s State) Pinned() bool {
+	ds := s.Datasets[s.ID]
+	if len(ds) == 0 {
+		return false
+	}
+	return ds[0].Pinned
+}
+
+// Automatic returns if this state was saved automatically, and so can be garbage collected under free space pressure.
+func (s State) Automatic() bool {
+	return strings.Contains(s.ID, "@"+automatedSnapshotPrefix)
+}
+
+// Encryption returns if any dataset of this state is encrypted and if any of them is locked, its key not being loaded.
+func (s State) Encryption() (encrypted, locked bool) {
+	for _, ds := range s.Datasets {
+		for _, d := range ds {
+			if d.Encryption == "" {
+				continue
+			}
+			encrypted = true
+			if d.IsLocked() {
+				locked = true
+			}
+		}
+	}
+	return encrypted, locked
+}
+
+// Space returns the space accounting of this state in bytes, including its user states.
+// Used space of a filesystem dataset already includes its children, so only the one of each route root is counted.
+func (s State) Space() (used, referenced, written uint64) {
+	for _, ds := range s.Datasets {
+		for i, d := range ds {
+			if i == 0 || d.IsSnapshot {
+				used += d.Used
+			}
+			referenced += d.Referenced
+			written += d.Written
+		}
+	}
+	for _, us := range s.Users {
+		u, r, w := us.Space()
+		used += u
+		referenced += r
+		written += w
+	}
+	return used, referenced, written
+}
+
+// Annotations returns the description and label of this state, as stored on its main dataset.
+func (s State) Annotations() (description, label string) {
+	ds := s.Datasets[s.ID]
+	if len(ds) == 0 {
+		return "", ""
+	}
+	return ds[0].Description, ds[0].Label
+}
+
 // getDatasets returns all Datasets from this given state.
 func (s State) getDatasets() []*zfs.Dataset {
 	var r []*zfs.Dataset
@@ -495,3 +786,40 @@ func idMatches(candidate, name string) bool {
 	}
 	return false
 }
+
+// reclaimableSpace returns the space in bytes freed by destroying all datasets.
+// Used space of a filesystem dataset includes its children and snapshots, which are then not counted twice.
+// Snapshots alone only account for their unique space, so this is an estimate when destroying consecutive ones.
+func reclaimableSpace(datasets []*zfs.Dataset) (size uint64) {
+	filesystems := make(map[string]bool)
+	for _, d := range datasets {
+		if !d.IsSnapshot {
+			filesystems[d.Name] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+nextDataset:
+	for _, d := range datasets {
+		if seen[d.Name] {
+			continue
+		}
+		seen[d.Name] = true
+
+		name := d.Name
+		if d.IsSnapshot {
+			name, _ = splitSnapshotName(name)
+			if filesystems[name] {
+				continue
+			}
+		}
+		for i := strings.LastIndex(name, "/"); i > 0; i = strings.LastIndex(name, "/") {
+			name = name[:i]
+			if filesystems[name] {
+				continue nextDataset
+			}
+		}
+		size += d.Used
+	}
+	return size
+}
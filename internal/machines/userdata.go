@@ -20,9 +20,75 @@ import (
 	"github.com/ubuntu/zsys/internal/zfs/libzfs"
 )
 
+// WithPassphrase encrypts the new user dataset with its own passphrase, asked for when loading its key.
+func WithPassphrase(passphrase string) func(o *userDataOptions) error {
+	return func(o *userDataOptions) error {
+		if passphrase == "" {
+			return nil
+		}
+		if o.key != nil {
+			return errors.New(i18n.G("only one encryption key can be set"))
+		}
+		if err := zfs.ValidatePassphrase(passphrase); err != nil {
+			return err
+		}
+		o.key = &zfs.EncryptionKey{Format: "passphrase", Location: "prompt", Passphrase: passphrase}
+		return nil
+	}
+}
+
+// WithKeyFile encrypts the new user dataset with the raw 32 bytes key stored in path, loaded from there.
+func WithKeyFile(path string) func(o *userDataOptions) error {
+	return func(o *userDataOptions) error {
+		if path == "" {
+			return nil
+		}
+		if o.key != nil {
+			return errors.New(i18n.G("only one encryption key can be set"))
+		}
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf(i18n.G("key file %q needs to be an absolute path"), path)
+		}
+		o.key = &zfs.EncryptionKey{Format: "raw", Location: "file://" + path}
+		return nil
+	}
+}
+
+// WithUserDataContainer creates the new user dataset under container, a pool or a dataset, instead of the configured one.
+// A USERDATA container is used under it, and created if needed.
+func WithUserDataContainer(container string) func(o *userDataOptions) error {
+	return func(o *userDataOptions) error {
+		if container == "" {
+			return nil
+		}
+		o.container = container
+		return nil
+	}
+}
+
+// WithUserDataProperties sets native zfs properties on the new user dataset, on top of the configured ones.
+func WithUserDataProperties(properties map[string]string) func(o *userDataOptions) error {
+	return func(o *userDataOptions) error {
+		for k, v := range properties {
+			o.properties[k] = v
+		}
+		return nil
+	}
+}
+
+type userDataOptions struct {
+	key        *zfs.EncryptionKey
+	container  string
+	properties map[string]string
+}
+
+type userDataOption func(*userDataOptions) error
+
 // CreateUserData creates a new dataset for homepath and attach to current system.
 // It creates intermediates user datasets if needed.
-func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string) error {
+// With an encryption key option, the new user dataset is its own encryption root instead of inheriting encryption
+// from its parent.
+func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string, opts ...userDataOption) error {
 	if !ms.current.isZsys() {
 		return errors.New(i18n.G("Current machine isn't Zsys, nothing to create"))
 	}
@@ -32,6 +98,23 @@ func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string) e
 	if homepath == "" {
 		return errors.New(i18n.G("Needs a valid home path, got nothing"))
 	}
+	args := userDataOptions{
+		container:  ms.conf.UserData.Container,
+		properties: make(map[string]string),
+	}
+	for k, v := range ms.conf.UserData.Properties {
+		args.properties[k] = v
+	}
+	for _, o := range opts {
+		if err := o(&args); err != nil {
+			return fmt.Errorf(i18n.G("couldn't apply option: %v"), err)
+		}
+	}
+	for k := range args.properties {
+		if !zfs.IsCreationProperty(k) {
+			return fmt.Errorf(i18n.G("property %q can't be set on user datasets"), k)
+		}
+	}
 
 	t, cancel := ms.z.NewTransaction(ctx)
 	defer t.Done()
@@ -42,45 +125,24 @@ func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string) e
 		cancel()
 		return err
 	} else if reused {
+		if args.key != nil {
+			log.Warningf(ctx, i18n.G("Existing user dataset reused for %q: encryption key is ignored"), homepath)
+		}
 		return ms.Refresh(ctx)
 	}
 
 	log.Infof(ctx, i18n.G("Create user dataset for %q"), homepath)
 
-	// Take same pool as existing userdatasets for current system
 	var userdatasetRoot string
-selectUserDataset:
-	for _, us := range ms.current.Users {
-		for p := range us.Datasets {
-			userdatasetRoot = getUserDatasetRoot(p)
-			break selectUserDataset
-		}
-	}
-	// If there is none attached to the current system, try to take first existing userdataset detected pool
-	if userdatasetRoot == "" && len(ms.allUsersDatasets) > 0 {
-		userdatasetRoot = getUserDatasetRoot(ms.allUsersDatasets[0].Name)
+	if args.container != "" {
+		userdatasetRoot = userDataContainer(args.container)
+	} else {
+		userdatasetRoot = ms.findUserDatasetRoot()
 	}
 
-	// If there is still none found, check if there is only USERDATA with no user under it as it won't shows up in machines
-	if userdatasetRoot == "" {
-		for _, d := range ms.z.Datasets() {
-			if strings.HasSuffix(strings.ToLower(d.Name)+"/", userdatasetsContainerName) {
-				userdatasetRoot = d.Name
-				break
-			}
-		}
-	}
-
-	// If there is still none found, take the current system pool and create one
-	if userdatasetRoot == "" {
-		p := ms.current.ID
-		i := strings.Index(p, "/")
-		if i != -1 {
-			p = p[:i]
-		}
-		userdatasetRoot = filepath.Join(p, zfs.UserdataPrefix)
-
-		// Create parent USERDATA
+	// Create parent USERDATA if needed, with default properties: requested ones are only for this user dataset.
+	if !ms.datasetExists(strings.TrimSuffix(userdatasetRoot, "/")) {
+		log.Infof(ctx, i18n.G("Create user data container %q"), userdatasetRoot)
 		if err := t.Create(userdatasetRoot, "/", "off"); err != nil {
 			cancel()
 			return fmt.Errorf(i18n.G("couldn't create user data embedder dataset: ")+config.ErrorFormat, err)
@@ -88,7 +150,10 @@ selectUserDataset:
 	}
 
 	userdataset := filepath.Join(userdatasetRoot, fmt.Sprintf("%s_%s", user, t.Zfs.GenerateID(6)))
-	if err := t.Create(userdataset, homepath, "on"); err != nil {
+	if args.key != nil {
+		log.Infof(ctx, i18n.G("Encrypting %q with a %s key"), userdataset, args.key.Format)
+	}
+	if err := t.CreateWithProperties(userdataset, homepath, "on", args.properties, args.key); err != nil {
 		cancel()
 		return err
 	}
@@ -221,6 +286,55 @@ func (ms *Machines) DissociateUser(ctx context.Context, username string, removeH
 	return ms.Refresh(ctx)
 }
 
+// findUserDatasetRoot returns the USERDATA container to use for new user datasets, when none is configured.
+// It takes the one of existing user datasets, preferring the current system ones, and fallbacks to the current
+// system pool.
+func (ms *Machines) findUserDatasetRoot() string {
+	// Take same pool as existing userdatasets for current system
+	for _, us := range ms.current.Users {
+		for p := range us.Datasets {
+			return getUserDatasetRoot(p)
+		}
+	}
+	// If there is none attached to the current system, try to take first existing userdataset detected pool
+	if len(ms.allUsersDatasets) > 0 {
+		return getUserDatasetRoot(ms.allUsersDatasets[0].Name)
+	}
+
+	// If there is still none found, check if there is only USERDATA with no user under it as it won't shows up in machines
+	for _, d := range ms.z.Datasets() {
+		if strings.HasSuffix(strings.ToLower(d.Name)+"/", userdatasetsContainerName) {
+			return d.Name
+		}
+	}
+
+	// If there is still none found, take the current system pool
+	p := ms.current.ID
+	if i := strings.Index(p, "/"); i != -1 {
+		p = p[:i]
+	}
+	return filepath.Join(p, zfs.UserdataPrefix)
+}
+
+// userDataContainer returns the USERDATA container dataset to use for target, a pool or a dataset.
+func userDataContainer(target string) string {
+	target = strings.TrimSuffix(target, "/")
+	if strings.EqualFold(filepath.Base(target), zfs.UserdataPrefix) {
+		return target
+	}
+	return filepath.Join(target, zfs.UserdataPrefix)
+}
+
+// datasetExists returns if a dataset named name is known.
+func (ms *Machines) datasetExists(name string) bool {
+	for _, d := range ms.z.Datasets() {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func getUserDatasetRoot(path string) string {
 	lpath := strings.ToLower(path)
 	i := strings.Index(lpath, userdatasetsContainerName)
@@ -0,0 +1,465 @@
+// Package metrics records daemon activity and exposes it, with the state of machines, in the Prometheus text format.
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+// TextfileName is the name of the file written in a textfile collector directory.
+const TextfileName = "zsys.prom"
+
+// StateFileName is the name of the file persisting recorded metrics across daemon restarts.
+const StateFileName = "metrics.json"
+
+// Boot commit results.
+const (
+	CommitChanged   = "changed"
+	CommitUnchanged = "unchanged"
+	CommitFailed    = "failed"
+)
+
+var (
+	// requestBuckets are the upper bounds in seconds of request durations, from a listing to a large removal.
+	requestBuckets = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300}
+	// gcBuckets are the upper bounds in seconds of garbage collection durations.
+	gcBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900}
+)
+
+// Recorder accumulates requests, garbage collections and boot commits of the daemon.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu sync.Mutex
+
+	requests         map[requestKey]uint64
+	requestDurations map[string]*histogram
+
+	gcRuns      map[bool]uint64
+	gcDurations *histogram
+	gcRemoved   uint64
+	gcLast      time.Time
+
+	commits    map[string]uint64
+	commitLast time.Time
+}
+
+type requestKey struct {
+	method string
+	code   string
+}
+
+// New returns an empty recorder.
+func New() *Recorder {
+	return &Recorder{
+		requests:         make(map[requestKey]uint64),
+		requestDurations: make(map[string]*histogram),
+		gcRuns:           make(map[bool]uint64),
+		gcDurations:      newHistogram(gcBuckets),
+		commits:          make(map[string]uint64),
+	}
+}
+
+// state is the persisted form of a Recorder.
+type state struct {
+	Requests         []requestCount
+	RequestDurations map[string]histogramState
+	GCRuns           map[string]uint64
+	GCDurations      histogramState
+	GCRemoved        uint64
+	GCLast           time.Time
+	Commits          map[string]uint64
+	CommitLast       time.Time
+}
+
+type requestCount struct {
+	Method string
+	Code   string
+	Count  uint64
+}
+
+type histogramState struct {
+	Counts []uint64
+	Sum    float64
+	Count  uint64
+}
+
+// gcResults maps gc success to its persisted and exposed result name.
+var gcResults = map[bool]string{true: "success", false: "failure"}
+
+// Load returns a recorder with the metrics saved at path, as the daemon exits when idle and every boot commit
+// runs in a new daemon. The recorder is empty if nothing was saved yet.
+func Load(path string) (*Recorder, error) {
+	r := New()
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return r, fmt.Errorf(i18n.G("couldn't read recorded metrics: %v"), err)
+	}
+
+	var st state
+	if err := json.Unmarshal(b, &st); err != nil {
+		return r, fmt.Errorf(i18n.G("invalid recorded metrics in %q: %v"), path, err)
+	}
+
+	for _, c := range st.Requests {
+		r.requests[requestKey{method: c.Method, code: c.Code}] = c.Count
+	}
+	for m, h := range st.RequestDurations {
+		r.requestDurations[m] = h.histogram(requestBuckets)
+	}
+	for success, result := range gcResults {
+		r.gcRuns[success] = st.GCRuns[result]
+	}
+	r.gcDurations = st.GCDurations.histogram(gcBuckets)
+	r.gcRemoved = st.GCRemoved
+	r.gcLast = st.GCLast
+	for result, n := range st.Commits {
+		r.commits[result] = n
+	}
+	r.commitLast = st.CommitLast
+
+	return r, nil
+}
+
+// Save writes the recorded metrics to path, so that Load can restore them.
+// The file is replaced atomically so that an interrupted save doesn't lose previous metrics.
+func (r *Recorder) Save(path string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf(i18n.G("couldn't save recorded metrics to %q: %v"), path, err)
+		}
+	}()
+
+	r.mu.Lock()
+	st := state{
+		RequestDurations: make(map[string]histogramState),
+		GCRuns:           make(map[string]uint64),
+		GCDurations:      r.gcDurations.state(),
+		GCRemoved:        r.gcRemoved,
+		GCLast:           r.gcLast,
+		Commits:          make(map[string]uint64),
+		CommitLast:       r.commitLast,
+	}
+	for k, n := range r.requests {
+		st.Requests = append(st.Requests, requestCount{Method: k.method, Code: k.code, Count: n})
+	}
+	for m, h := range r.requestDurations {
+		st.RequestDurations[m] = h.state()
+	}
+	for success, result := range gcResults {
+		st.GCRuns[result] = r.gcRuns[success]
+	}
+	for result, n := range r.commits {
+		st.Commits[result] = n
+	}
+	r.mu.Unlock()
+
+	sort.Slice(st.Requests, func(i, j int) bool {
+		if st.Requests[i].Method != st.Requests[j].Method {
+			return st.Requests[i].Method < st.Requests[j].Method
+		}
+		return st.Requests[i].Code < st.Requests[j].Code
+	})
+
+	return writeAtomically(path, 0600, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(st)
+	})
+}
+
+// ObserveRequest records a request to method which took d and ended with code, the grpc status code name.
+func (r *Recorder) ObserveRequest(method, code string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[requestKey{method: method, code: code}]++
+	h, ok := r.requestDurations[method]
+	if !ok {
+		h = newHistogram(requestBuckets)
+		r.requestDurations[method] = h
+	}
+	h.observe(d.Seconds())
+}
+
+// ObserveGC records a garbage collection ending at end, which took d and removed that many states.
+func (r *Recorder) ObserveGC(end time.Time, d time.Duration, removed uint32, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gcRuns[err == nil]++
+	r.gcDurations.observe(d.Seconds())
+	r.gcRemoved += uint64(removed)
+	r.gcLast = end
+}
+
+// ObserveBootCommit records a boot commit at t, with one of the Commit* results.
+func (r *Recorder) ObserveBootCommit(t time.Time, result string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commits[result]++
+	r.commitLast = t
+}
+
+// Write writes in the Prometheus text format the metrics about ms and pools as of now, then the recorded ones.
+func (r *Recorder) Write(w io.Writer, ms *machines.Machines, pools []machines.PoolSpace, now time.Time) error {
+	e := &encoder{w: w}
+
+	writeMachines(e, ms, now)
+	writePools(e, pools)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e.header("zsys_gc_runs_total", "counter", "Number of garbage collections, by result.")
+	for _, success := range []bool{true, false} {
+		e.sample("zsys_gc_runs_total", float64(r.gcRuns[success]), "result", gcResults[success])
+	}
+	e.header("zsys_gc_duration_seconds", "histogram", "Duration of garbage collections.")
+	r.gcDurations.write(e, "zsys_gc_duration_seconds")
+	e.header("zsys_gc_removed_states_total", "counter", "Number of system and user states removed by garbage collections.")
+	e.sample("zsys_gc_removed_states_total", float64(r.gcRemoved))
+	if !r.gcLast.IsZero() {
+		e.header("zsys_gc_last_run_timestamp_seconds", "gauge", "Time of the end of the last garbage collection.")
+		e.sample("zsys_gc_last_run_timestamp_seconds", unixSeconds(r.gcLast))
+	}
+
+	e.header("zsys_boot_commits_total", "counter", "Number of boot commits, by result.")
+	for _, result := range []string{CommitChanged, CommitUnchanged, CommitFailed} {
+		e.sample("zsys_boot_commits_total", float64(r.commits[result]), "result", result)
+	}
+	if !r.commitLast.IsZero() {
+		e.header("zsys_boot_commit_last_timestamp_seconds", "gauge", "Time of the last boot commit.")
+		e.sample("zsys_boot_commit_last_timestamp_seconds", unixSeconds(r.commitLast))
+	}
+
+	var keys []requestKey
+	for k := range r.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+	e.header("zsys_requests_total", "counter", "Number of requests handled by the daemon, by method and status code.")
+	for _, k := range keys {
+		e.sample("zsys_requests_total", float64(r.requests[k]), "method", k.method, "code", k.code)
+	}
+	var methods []string
+	for m := range r.requestDurations {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	e.header("zsys_request_duration_seconds", "histogram", "Duration of requests handled by the daemon, by method.")
+	for _, m := range methods {
+		r.requestDurations[m].write(e, "zsys_request_duration_seconds", "method", m)
+	}
+
+	return e.err
+}
+
+// writeMachines writes the number of machines and their states, with the age of their automatic states.
+func writeMachines(e *encoder, ms *machines.Machines, now time.Time) {
+	type ages struct {
+		machine, user  string
+		newest, oldest time.Time
+	}
+	var automatic []ages
+	addAutomatic := func(machine, user string, states map[string]*machines.State) {
+		a := ages{machine: machine, user: user}
+		for _, s := range states {
+			if !s.Automatic() {
+				continue
+			}
+			if a.newest.IsZero() || s.LastUsed.After(a.newest) {
+				a.newest = s.LastUsed
+			}
+			if a.oldest.IsZero() || s.LastUsed.Before(a.oldest) {
+				a.oldest = s.LastUsed
+			}
+		}
+		if !a.newest.IsZero() {
+			automatic = append(automatic, a)
+		}
+	}
+
+	list := ms.List()
+	e.header("zsys_machines", "gauge", "Number of machines.")
+	e.sample("zsys_machines", float64(len(list)))
+
+	e.header("zsys_states", "gauge", "Number of states, by machine and user. System states have an empty user.")
+	for _, m := range list {
+		e.sample("zsys_states", float64(len(m.History)), "machine", m.ID, "user", "")
+		addAutomatic(m.ID, "", m.History)
+		for _, u := range sortedKeys(m.AllUsersStates) {
+			e.sample("zsys_states", float64(len(m.AllUsersStates[u])), "machine", m.ID, "user", u)
+			addAutomatic(m.ID, u, m.AllUsersStates[u])
+		}
+	}
+
+	e.header("zsys_newest_automatic_state_age_seconds", "gauge", "Age of the newest automatic state, by machine and user.")
+	for _, a := range automatic {
+		e.sample("zsys_newest_automatic_state_age_seconds", now.Sub(a.newest).Seconds(), "machine", a.machine, "user", a.user)
+	}
+	e.header("zsys_oldest_automatic_state_age_seconds", "gauge", "Age of the oldest automatic state, by machine and user.")
+	for _, a := range automatic {
+		e.sample("zsys_oldest_automatic_state_age_seconds", now.Sub(a.oldest).Seconds(), "machine", a.machine, "user", a.user)
+	}
+}
+
+// writePools writes the size and free space of pools.
+func writePools(e *encoder, pools []machines.PoolSpace) {
+	e.header("zsys_pool_size_bytes", "gauge", "Size of pools containing system or user datasets.")
+	for _, p := range pools {
+		e.sample("zsys_pool_size_bytes", float64(p.Size), "pool", p.Pool)
+	}
+	e.header("zsys_pool_free_ratio", "gauge", "Ratio of free space on pools containing system or user datasets.")
+	for _, p := range pools {
+		e.sample("zsys_pool_free_ratio", float64(p.Free)/100, "pool", p.Pool)
+	}
+	e.header("zsys_pool_free_bytes", "gauge", "Estimated free space on pools containing system or user datasets.")
+	for _, p := range pools {
+		e.sample("zsys_pool_free_bytes", float64(p.Size/100*uint64(p.Free)), "pool", p.Pool)
+	}
+}
+
+// WriteTextfile writes metrics to the textfile collector directory dir.
+// The file is replaced atomically so that the collector never reads a partial file.
+func WriteTextfile(dir string, write func(w io.Writer) error) error {
+	if err := writeAtomically(filepath.Join(dir, TextfileName), 0644, write); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write metrics to %q: %v"), dir, err)
+	}
+	return nil
+}
+
+// writeAtomically replaces path with the content written by write, with permissions perm.
+func writeAtomically(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// histogram counts observations in cumulative buckets.
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+// histogram returns the histogram saved in h. Counts are reset if bounds changed since it was saved.
+func (h histogramState) histogram(bounds []float64) *histogram {
+	n := newHistogram(bounds)
+	if len(h.Counts) != len(bounds) {
+		return n
+	}
+	copy(n.counts, h.Counts)
+	n.sum, n.count = h.Sum, h.Count
+	return n
+}
+
+func (h *histogram) state() histogramState {
+	return histogramState{Counts: append([]uint64(nil), h.counts...), Sum: h.sum, Count: h.count}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, b := range h.bounds {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func (h *histogram) write(e *encoder, name string, labels ...string) {
+	for i, b := range h.bounds {
+		e.sample(name+"_bucket", float64(h.counts[i]), append(labels, "le", formatFloat(b))...)
+	}
+	e.sample(name+"_bucket", float64(h.count), append(labels, "le", "+Inf")...)
+	e.sample(name+"_sum", h.sum, labels...)
+	e.sample(name+"_count", float64(h.count), labels...)
+}
+
+// encoder writes metrics in the Prometheus text format, keeping the first write error.
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *encoder) printf(format string, a ...interface{}) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = fmt.Fprintf(e.w, format, a...)
+}
+
+func (e *encoder) header(name, typ, help string) {
+	e.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes a value of metric name with labels, given as pairs of name and value.
+func (e *encoder) sample(name string, v float64, labels ...string) {
+	var l []string
+	for i := 0; i+1 < len(labels); i += 2 {
+		l = append(l, fmt.Sprintf(`%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1])))
+	}
+	if len(l) > 0 {
+		name = fmt.Sprintf("%s{%s}", name, strings.Join(l, ","))
+	}
+	e.printf("%s %s\n", name, formatFloat(v))
+}
+
+// labelEscaper escapes label values: only backslashes, quotes and newlines are escaped in the Prometheus text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+func sortedKeys(m map[string]map[string]*machines.State) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
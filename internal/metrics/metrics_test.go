@@ -0,0 +1,273 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/metrics"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+var refTime = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "machines.yaml"), testutils.WithLibZFS(libzfs))
+	defer fPools.Create(dir)()
+
+	ms, err := machines.New(context.Background(), "BOOT_IMAGE=vmlinuz-5.2.0-8-generic root=ZFS=rpool/ROOT/ubuntu_1234 ro quiet splash",
+		machines.WithLibZFS(libzfs))
+	if err != nil {
+		t.Fatal("expected success but got an error scanning for machines", err)
+	}
+	pools := []machines.PoolSpace{{Pool: "rpool", Size: 1000, Free: 30}}
+
+	r := metrics.New()
+	r.ObserveRequest("SaveSystemState", "OK", 200*time.Millisecond)
+	r.ObserveRequest("SaveSystemState", "OK", 2*time.Second)
+	r.ObserveRequest("SaveSystemState", "Unknown", 20*time.Millisecond)
+	r.ObserveRequest("MachineList", "OK", time.Millisecond)
+	r.ObserveGC(refTime.Add(-time.Hour), 3*time.Second, 4, nil)
+	r.ObserveGC(refTime, time.Second, 0, errors.New("GC failed"))
+	r.ObserveBootCommit(refTime.Add(-2*time.Hour), metrics.CommitChanged)
+
+	var out bytes.Buffer
+	if err := r.Write(&out, &ms, pools, refTime); err != nil {
+		t.Fatalf("expected no error writing metrics but got: %v", err)
+	}
+	got := out.String()
+
+	for _, want := range []string{
+		"# TYPE zsys_machines gauge\nzsys_machines 1\n",
+		`zsys_states{machine="rpool/ROOT/ubuntu_1234",user=""} 3`,
+		`zsys_states{machine="rpool/ROOT/ubuntu_1234",user="user1"} 2`,
+		`zsys_newest_automatic_state_age_seconds{machine="rpool/ROOT/ubuntu_1234",user=""} 3600`,
+		`zsys_oldest_automatic_state_age_seconds{machine="rpool/ROOT/ubuntu_1234",user=""} 86400`,
+		`zsys_newest_automatic_state_age_seconds{machine="rpool/ROOT/ubuntu_1234",user="user1"} 3600`,
+
+		`zsys_pool_size_bytes{pool="rpool"} 1000`,
+		`zsys_pool_free_ratio{pool="rpool"} 0.3`,
+		`zsys_pool_free_bytes{pool="rpool"} 300`,
+
+		`zsys_gc_runs_total{result="success"} 1`,
+		`zsys_gc_runs_total{result="failure"} 1`,
+		`zsys_gc_duration_seconds_bucket{le="1"} 1`,
+		`zsys_gc_duration_seconds_bucket{le="5"} 2`,
+		`zsys_gc_duration_seconds_bucket{le="+Inf"} 2`,
+		"zsys_gc_duration_seconds_sum 4\n",
+		"zsys_gc_duration_seconds_count 2\n",
+		"zsys_gc_removed_states_total 4\n",
+		"zsys_gc_last_run_timestamp_seconds 1577880000\n",
+
+		`zsys_boot_commits_total{result="changed"} 1`,
+		`zsys_boot_commits_total{result="unchanged"} 0`,
+		`zsys_boot_commits_total{result="failed"} 0`,
+		"zsys_boot_commit_last_timestamp_seconds 1577872800\n",
+
+		`zsys_requests_total{method="MachineList",code="OK"} 1`,
+		`zsys_requests_total{method="SaveSystemState",code="OK"} 2`,
+		`zsys_requests_total{method="SaveSystemState",code="Unknown"} 1`,
+		`zsys_request_duration_seconds_bucket{method="SaveSystemState",le="0.05"} 1`,
+		`zsys_request_duration_seconds_bucket{method="SaveSystemState",le="0.5"} 2`,
+		`zsys_request_duration_seconds_bucket{method="SaveSystemState",le="+Inf"} 3`,
+		`zsys_request_duration_seconds_count{method="SaveSystemState"} 3`,
+	} {
+		assert.Contains(t, got, want, "metrics output")
+	}
+
+	// Every metric is declared once, before its samples
+	var names []string
+	for _, l := range strings.Split(got, "\n") {
+		if strings.HasPrefix(l, "# TYPE ") {
+			names = append(names, strings.Fields(l)[2])
+		}
+	}
+	seen := make(map[string]bool)
+	for _, n := range names {
+		assert.False(t, seen[n], "metric %s is declared only once", n)
+		seen[n] = true
+	}
+}
+
+func TestWriteWithoutActivity(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	if err := metrics.New().Write(&out, &machines.Machines{}, nil, refTime); err != nil {
+		t.Fatalf("expected no error writing metrics but got: %v", err)
+	}
+	got := out.String()
+
+	assert.Contains(t, got, "zsys_machines 0\n", "no machines")
+	assert.Contains(t, got, `zsys_gc_runs_total{result="success"} 0`, "counters are set without activity")
+	assert.NotContains(t, got, "zsys_gc_last_run_timestamp_seconds", "no last garbage collection time without any")
+	assert.NotContains(t, got, "zsys_boot_commit_last_timestamp_seconds", "no last boot commit time without any")
+}
+
+func TestWriteEscapesLabels(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.New()
+	r.ObserveRequest("a\"b\\c\nd", "OK", time.Millisecond)
+
+	var out bytes.Buffer
+	if err := r.Write(&out, &machines.Machines{}, nil, refTime); err != nil {
+		t.Fatalf("expected no error writing metrics but got: %v", err)
+	}
+	assert.Contains(t, out.String(), `zsys_requests_total{method="a\"b\\c\nd",code="OK"} 1`, "label values are escaped")
+}
+
+func TestWriteTextfile(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		noDir    bool
+		writeErr bool
+
+		wantErr bool
+	}{
+		"Write metrics file": {},
+
+		"Error on missing directory": {noDir: true, wantErr: true},
+		"Error on failing write":     {writeErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			// An existing file is replaced
+			if err := os.WriteFile(filepath.Join(dir, metrics.TextfileName), []byte("old content"), 0644); err != nil {
+				t.Fatalf("Setup: couldn't write previous metrics file: %v", err)
+			}
+			if tc.noDir {
+				dir = filepath.Join(dir, "doesnotexist")
+			}
+
+			err := metrics.WriteTextfile(dir, func(w io.Writer) error {
+				if tc.writeErr {
+					return errors.New("write error")
+				}
+				_, err := w.Write([]byte("zsys_machines 1\n"))
+				return err
+			})
+
+			files, errList := os.ReadDir(dir)
+			if tc.wantErr {
+				assert.Error(t, err, "WriteTextfile should fail")
+				if errList == nil {
+					assert.Len(t, files, 1, "no temporary file is left behind")
+				}
+				return
+			}
+			assert.NoError(t, err, "WriteTextfile should succeed")
+
+			b, err := os.ReadFile(filepath.Join(dir, metrics.TextfileName))
+			if err != nil {
+				t.Fatalf("couldn't read metrics file: %v", err)
+			}
+			assert.Equal(t, "zsys_machines 1\n", string(b), "metrics file content")
+			assert.Len(t, files, 1, "no temporary file is left behind")
+			fi, err := os.Stat(filepath.Join(dir, metrics.TextfileName))
+			if err != nil {
+				t.Fatalf("couldn't stat metrics file: %v", err)
+			}
+			assert.Equal(t, os.FileMode(0644), fi.Mode().Perm(), "metrics file is readable by the node exporter")
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content  string
+		noFile   bool
+		noRecord bool
+
+		wantErr bool
+	}{
+		"Restore saved metrics":        {},
+		"Restore without any activity": {noRecord: true},
+		"No saved metrics":             {noFile: true},
+
+		"Error on invalid saved metrics": {content: "invalid", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+			path := filepath.Join(dir, metrics.StateFileName)
+
+			r := metrics.New()
+			if !tc.noRecord {
+				r.ObserveRequest("SaveSystemState", "OK", 200*time.Millisecond)
+				r.ObserveRequest("MachineList", "Unknown", time.Millisecond)
+				r.ObserveGC(refTime.Add(-time.Hour), 3*time.Second, 4, nil)
+				r.ObserveGC(refTime, time.Second, 0, errors.New("GC failed"))
+				r.ObserveBootCommit(refTime.Add(-2*time.Hour), metrics.CommitChanged)
+			}
+			if !tc.noFile {
+				if err := r.Save(path); err != nil {
+					t.Fatalf("Setup: couldn't save metrics: %v", err)
+				}
+			}
+			if tc.content != "" {
+				if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+					t.Fatalf("Setup: couldn't write saved metrics: %v", err)
+				}
+			}
+
+			got, err := metrics.Load(path)
+			if tc.wantErr {
+				assert.Error(t, err, "Load should fail")
+			} else {
+				assert.NoError(t, err, "Load should succeed")
+			}
+			if !assert.NotNil(t, got, "Load always returns a recorder") {
+				return
+			}
+
+			want := r
+			if tc.noFile || tc.wantErr {
+				want = metrics.New()
+			}
+			var wantOut, gotOut bytes.Buffer
+			if err := want.Write(&wantOut, &machines.Machines{}, nil, refTime); err != nil {
+				t.Fatalf("expected no error writing metrics but got: %v", err)
+			}
+			if err := got.Write(&gotOut, &machines.Machines{}, nil, refTime); err != nil {
+				t.Fatalf("expected no error writing metrics but got: %v", err)
+			}
+			assert.Equal(t, wantOut.String(), gotOut.String(), "restored metrics match recorded ones")
+
+			if tc.noFile || tc.wantErr {
+				return
+			}
+			fi, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("couldn't stat saved metrics: %v", err)
+			}
+			assert.Equal(t, os.FileMode(0600), fi.Mode().Perm(), "saved metrics are only readable by root")
+		})
+	}
+}
@@ -38,8 +38,9 @@ type call struct {
 	LogServer  string
 	OrigStream string
 	LogStream  string
-	ReqType    string
-	Name       string
+	// ReqType is empty for client streaming calls, receiving their requests from the stream.
+	ReqType string
+	Name    string
 }
 
 type streamwriter struct {
@@ -201,11 +202,17 @@ func main() {
 
 						fields := callFunc.Type.(*ast.FuncType).Params.List
 
-						if len(fields) != 2 {
+						// Server streaming calls take a request and a stream, client streaming ones only a stream.
+						var reqType, origStream string
+						switch len(fields) {
+						case 1:
+							origStream = fields[0].Type.(*ast.Ident).Name
+						case 2:
+							reqType = fields[0].Type.(*ast.StarExpr).X.(*ast.Ident).Name
+							origStream = fields[1].Type.(*ast.Ident).Name
+						default:
 							continue
 						}
-						reqType := fields[0].Type.(*ast.StarExpr).X.(*ast.Ident).Name
-						origStream := fields[1].Type.(*ast.Ident).Name
 
 						// Per function call server
 						r := strings.SplitN(origStream, "_", 2)
@@ -231,6 +238,10 @@ func main() {
 			}
 
 			stream := m.Recv.List[0].Type.(*ast.StarExpr).X.(*ast.Ident).Name
+			// Client streams send requests, not logs.
+			if strings.HasSuffix(stream, "Client") {
+				continue
+			}
 			messageType := m.Type.Params.List[0].Type.(*ast.StarExpr).X.(*ast.Ident).Name
 
 			messageDetails, ok := messageLogs[messageType]
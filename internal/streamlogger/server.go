@@ -60,6 +60,20 @@ func AddLogger(stream StreamLogger, funcName string) (context.Context, error) {
 	return ctx, nil
 }
 
+// LogRequestEnd reports in the daemon logs how the request funcName ended.
+// A request cancelled by the client, or timing out, has reverted any in progress transactional changes.
+func LogRequestEnd(ctx context.Context, funcName string, err error) {
+	id, _ := log.IDFromContext(ctx)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		logrus.Warningf(i18n.G("request %s() for %q was cancelled by the client: %v"), funcName, id, err)
+	case err != nil:
+		logrus.Infof(i18n.G("request %s() for %q failed: %v"), funcName, id, err)
+	default:
+		logrus.Infof(i18n.G("request %s() for %q done"), funcName, id)
+	}
+}
+
 type requestTracker interface {
 	TrackRequest() func()
 }
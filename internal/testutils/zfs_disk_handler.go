@@ -38,11 +38,26 @@ type fakePool struct {
 		IsVolume         bool
 		Mountpoint       string
 		CanMount         string
-		ZsysBootfs       string    `yaml:"zsys_bootfs"`
-		LastUsed         time.Time `yaml:"last_used"`
-		LastBootedKernel string    `yaml:"last_booted_kernel"`
-		BootfsDatasets   string    `yaml:"bootfs_datasets"`
-		Origin           string    `yaml:"origin"`
+		ZsysBootfs       string            `yaml:"zsys_bootfs"`
+		LastUsed         time.Time         `yaml:"last_used"`
+		LastBootedKernel string            `yaml:"last_booted_kernel"`
+		BootfsDatasets   string            `yaml:"bootfs_datasets"`
+		NextBoot         string            `yaml:"next_boot"`
+		LastReplicated   string            `yaml:"last_replicated"`
+		ClonedFrom       string            `yaml:"cloned_from"`
+		Encryption       string            // Make it a new encryption root, only work for mock usage.
+		KeyFormat        string            `yaml:"key_format"` // Only work for mock usage.
+		KeyStatus        string            `yaml:"key_status"` // "unavailable" unloads the key, only work for mock usage.
+		Origin           string            `yaml:"origin"`
+		Description      string            `yaml:"description"`
+		Label            string            `yaml:"label"`
+		Pinned           string            `yaml:"pinned"`
+		Excluded         string            `yaml:"excluded"`
+		FollowSystem     string            `yaml:"follow_system"`
+		Used             string            // Size in bytes, only work for mock usage.
+		Referenced       string            // Size in bytes, only work for mock usage.
+		Written          string            // Size in bytes, only work for mock usage.
+		Files            map[string]string // File path to content, only work for mock usage.
 		Snapshots        orderedSnapshots
 	}
 }
@@ -51,12 +66,20 @@ type orderedSnapshots []struct {
 	Name             string
 	Mountpoint       string
 	CanMount         string
-	ZsysBootfs       string     `yaml:"zsys_bootfs"`
-	LastBootedKernel string     `yaml:"last_booted_kernel"`
-	BootfsDatasets   string     `yaml:"bootfs_datasets"`
-	CreationTime     *time.Time `yaml:"creation_time"` // Snapshot creation time, only work for mock usage.
-	//TODO: one libzfs support bookmarks
-	//BookMarks        []string
+	ZsysBootfs       string            `yaml:"zsys_bootfs"`
+	LastBootedKernel string            `yaml:"last_booted_kernel"`
+	BootfsDatasets   string            `yaml:"bootfs_datasets"`
+	Description      string            `yaml:"description"`
+	Label            string            `yaml:"label"`
+	Pinned           string            `yaml:"pinned"`
+	CreationTime     *time.Time        `yaml:"creation_time"` // Snapshot creation time, only work for mock usage.
+	Used             string            // Size in bytes, only work for mock usage.
+	Referenced       string            // Size in bytes, only work for mock usage.
+	Written          string            // Size in bytes, only work for mock usage.
+	Files            map[string]string // File path to content, only work for mock usage.
+	// Bookmark creates a bookmark of the same name of the snapshot if set to "yes".
+	// If set to "only", the snapshot is then destroyed and only the bookmark remains.
+	Bookmark string
 }
 
 func (s orderedSnapshots) Len() int           { return len(s) }
@@ -157,6 +180,7 @@ func (fpools FakePools) cleanup() {
 // Create on disk mock pools as files
 func (fpools FakePools) Create(path string) func() {
 	snapshotWG := sync.WaitGroup{}
+	var onlyBookmarks []string
 	for _, fpool := range fpools.Pools {
 		func() {
 			// Create device as file on disk
@@ -219,6 +243,15 @@ func (fpools FakePools) Create(path string) func() {
 						props[libzfs.DatasetPropVolsize] = libzfs.Property{Value: strSize}
 					}
 
+					fpools.setSizes(props, datasetName, dataset.Used, dataset.Referenced, dataset.Written)
+					if dataset.Encryption != "" {
+						if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
+							fpools.Fatalf("trying to encrypt %q on real ZFS run. This is not possible", datasetName)
+						}
+						props[libzfs.DatasetPropEncryption] = libzfs.Property{Value: dataset.Encryption}
+						props[libzfs.DatasetPropKeyFormat] = libzfs.Property{Value: dataset.KeyFormat}
+					}
+
 					d, err = fpools.libzfs.DatasetCreate(datasetName, dType, props)
 					if err != nil {
 						fpools.Fatalf("couldn't create dataset %q: %v", datasetName, err)
@@ -251,14 +284,63 @@ func (fpools FakePools) Create(path string) func() {
 				if dataset.BootfsDatasets != "" {
 					d.SetUserProperty(libzfs.BootfsDatasetsProp, dataset.BootfsDatasets)
 				}
+				if dataset.NextBoot != "" {
+					d.SetUserProperty(libzfs.NextBootProp, dataset.NextBoot)
+				}
+				if dataset.LastReplicated != "" {
+					d.SetUserProperty(libzfs.LastReplicatedProp, dataset.LastReplicated)
+				}
+				if dataset.ClonedFrom != "" {
+					d.SetUserProperty(libzfs.ClonedFromProp, dataset.ClonedFrom)
+				}
+				if dataset.Description != "" {
+					d.SetUserProperty(libzfs.DescriptionProp, dataset.Description)
+				}
+				if dataset.Label != "" {
+					d.SetUserProperty(libzfs.LabelProp, dataset.Label)
+				}
+				if dataset.Pinned != "" {
+					d.SetUserProperty(libzfs.PinnedProp, dataset.Pinned)
+				}
+				if dataset.Excluded != "" {
+					d.SetUserProperty(libzfs.ExcludedProp, dataset.Excluded)
+				}
+				if dataset.FollowSystem != "" {
+					d.SetUserProperty(libzfs.FollowSystemProp, dataset.FollowSystem)
+				}
 				if dataset.Origin != "" {
 					if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
 						fpools.Fatalf("trying to set origin on clone for %q on real ZFS run. This is not possible", datasetName)
 					}
 					d.SetProperty(libzfs.DatasetPropOrigin, dataset.Origin)
 				}
+				if dataset.Files != nil {
+					lzfs, ok := fpools.libzfs.(*mock.LibZFS)
+					if !ok {
+						fpools.Fatalf("trying to set files for %q on real ZFS run. This is not possible", datasetName)
+					}
+					lzfs.SetFiles(datasetName, dataset.Files)
+				}
+				if dataset.KeyStatus == "unavailable" {
+					lzfs, ok := fpools.libzfs.(*mock.LibZFS)
+					if !ok {
+						fpools.Fatalf("trying to unload key of %q on real ZFS run. This is not possible", datasetName)
+					}
+					lzfs.UnloadKey(datasetName)
+				}
 				d.Close()
 
+				// Compute sizes before snapshotting concurrently, as reading fpools races with the pools creation.
+				sizes := make(map[string]map[libzfs.Prop]libzfs.Property)
+				for _, s := range dataset.Snapshots {
+					props := make(map[libzfs.Prop]libzfs.Property)
+					fpools.setSizes(props, datasetName+"@"+s.Name, s.Used, s.Referenced, s.Written)
+					sizes[s.Name] = props
+					if s.Bookmark == "only" {
+						onlyBookmarks = append(onlyBookmarks, datasetName+"@"+s.Name)
+					}
+				}
+
 				snapshotWG.Add(1)
 				go func(snapshots orderedSnapshots) {
 					defer snapshotWG.Done()
@@ -269,7 +351,7 @@ func (fpools FakePools) Create(path string) func() {
 						if fpools.waitBetweenSnapshots && i > 0 {
 							time.Sleep(time.Second)
 						}
-						props := make(map[libzfs.Prop]libzfs.Property)
+						props := sizes[s.Name]
 						if s.CreationTime != nil {
 							if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
 								fpools.Fatalf("trying to set snapshot time for %q on real ZFS run. This is not possible", datasetName)
@@ -292,11 +374,34 @@ func (fpools FakePools) Create(path string) func() {
 						if s.BootfsDatasets != "" {
 							userProps[libzfs.BootfsDatasetsProp] = s.BootfsDatasets
 						}
+						if s.Description != "" {
+							userProps[libzfs.DescriptionProp] = s.Description
+						}
+						if s.Label != "" {
+							userProps[libzfs.LabelProp] = s.Label
+						}
+						if s.Pinned != "" {
+							userProps[libzfs.PinnedProp] = s.Pinned
+						}
 						d, err := fpools.libzfs.DatasetSnapshot(datasetName+"@"+s.Name, false, props, userProps)
 						if err != nil {
 							fmt.Fprintf(os.Stderr, "Couldn't create snapshot %q: %v\n", datasetName+"@"+s.Name, err)
 							os.Exit(1)
 						}
+						if s.Files != nil {
+							lzfs, ok := fpools.libzfs.(*mock.LibZFS)
+							if !ok {
+								fmt.Fprintf(os.Stderr, "Trying to set files for %q on real ZFS run. This is not possible\n", datasetName+"@"+s.Name)
+								os.Exit(1)
+							}
+							lzfs.SetFiles(datasetName+"@"+s.Name, s.Files)
+						}
+						if s.Bookmark != "" {
+							if err := fpools.libzfs.BookmarkCreate(datasetName+"@"+s.Name, datasetName+"#"+s.Name); err != nil {
+								fmt.Fprintf(os.Stderr, "Couldn't create bookmark of %q: %v\n", datasetName+"@"+s.Name, err)
+								os.Exit(1)
+							}
+						}
 						d.Close()
 					}
 				}(dataset.Snapshots)
@@ -304,5 +409,37 @@ func (fpools FakePools) Create(path string) func() {
 		}()
 	}
 	snapshotWG.Wait()
+
+	// Only keep bookmarks once all snapshots are created, as destroying looks at other datasets.
+	for _, name := range onlyBookmarks {
+		d, err := fpools.libzfs.DatasetOpen(name)
+		if err != nil {
+			fpools.Fatalf("couldn't open bookmarked snapshot %q: %v", name, err)
+		}
+		if err := d.Destroy(false); err != nil {
+			fpools.Fatalf("couldn't destroy bookmarked snapshot %q: %v", name, err)
+		}
+		d.Close()
+	}
+
 	return fpools.cleanup
 }
+
+// setSizes adds the size properties, if any, to props. This only works with the mock.
+func (fpools FakePools) setSizes(props map[libzfs.Prop]libzfs.Property, name, used, referenced, written string) {
+	if used == "" && referenced == "" && written == "" {
+		return
+	}
+	if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
+		fpools.Fatalf("trying to set sizes for %q on real ZFS run. This is not possible", name)
+	}
+	for p, v := range map[libzfs.Prop]string{
+		libzfs.DatasetPropUsed:       used,
+		libzfs.DatasetPropReferenced: referenced,
+		libzfs.DatasetPropWritten:    written,
+	} {
+		if v != "" {
+			props[p] = libzfs.Property{Value: v, Source: "-"}
+		}
+	}
+}
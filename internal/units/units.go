@@ -0,0 +1,18 @@
+// Package units formats quantities for display.
+package units
+
+import "fmt"
+
+// HumanSize returns a human readable representation of a size in bytes, with the same units as zfs.
+func HumanSize(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(size)/float64(div), "KMGTPE"[exp])
+}
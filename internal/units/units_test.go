@@ -0,0 +1,34 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/units"
+)
+
+func TestHumanSize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		size uint64
+
+		want string
+	}{
+		"Bytes":             {size: 512, want: "512B"},
+		"Zero":              {size: 0, want: "0B"},
+		"Kilobytes":         {size: 1024, want: "1.0K"},
+		"Rounded megabytes": {size: 1572864, want: "1.5M"},
+		"Gigabytes":         {size: 3221225472, want: "3.0G"},
+		"Exabytes":          {size: 1 << 62, want: "4.0E"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, units.HumanSize(tc.size), "didn't get expected human readable size")
+		})
+	}
+}
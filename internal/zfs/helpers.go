@@ -139,6 +139,84 @@ func (d *Dataset) refreshProperties(ctx context.Context) error {
 	}
 	sources.BootfsDatasets = srcBootfsDatasets
 
+	var nextBoot, srcNextBoot string
+	if !d.IsSnapshot {
+		if nextBoot, srcNextBoot, err = getUserPropertyFromSys(ctx, libzfs.NextBootProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read next boot property, ignoring: ")+config.ErrorFormat, err)
+		}
+	}
+	sources.NextBoot = srcNextBoot
+
+	var lastReplicated, srcLastReplicated string
+	if !d.IsSnapshot {
+		if lastReplicated, srcLastReplicated, err = getUserPropertyFromSys(ctx, libzfs.LastReplicatedProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read last replicated property, ignoring: ")+config.ErrorFormat, err)
+		}
+	}
+	sources.LastReplicated = srcLastReplicated
+
+	var clonedFrom, srcClonedFrom string
+	if !d.IsSnapshot {
+		if clonedFrom, srcClonedFrom, err = getUserPropertyFromSys(ctx, libzfs.ClonedFromProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read cloned from property, ignoring: ")+config.ErrorFormat, err)
+		}
+	}
+	sources.ClonedFrom = srcClonedFrom
+
+	description, srcDescription, err := getUserPropertyFromSys(ctx, libzfs.DescriptionProp, d.dZFS)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't read description property, ignoring: ")+config.ErrorFormat, err)
+	}
+	sources.Description = srcDescription
+
+	label, srcLabel, err := getUserPropertyFromSys(ctx, libzfs.LabelProp, d.dZFS)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't read label property, ignoring: ")+config.ErrorFormat, err)
+	}
+	sources.Label = srcLabel
+
+	pinnedValue, srcPinned, err := getUserPropertyFromSys(ctx, libzfs.PinnedProp, d.dZFS)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't read pinned property, ignoring: ")+config.ErrorFormat, err)
+	}
+	var pinned bool
+	if pinnedValue == "yes" {
+		pinned = true
+	}
+	sources.Pinned = srcPinned
+
+	var excluded, srcExcluded string
+	if !d.IsSnapshot {
+		if excluded, srcExcluded, err = getUserPropertyFromSys(ctx, libzfs.ExcludedProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read excluded property, ignoring: ")+config.ErrorFormat, err)
+		}
+	}
+	sources.Excluded = srcExcluded
+
+	var followSystem, srcFollowSystem string
+	if !d.IsSnapshot {
+		if followSystem, srcFollowSystem, err = getUserPropertyFromSys(ctx, libzfs.FollowSystemProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read follow-system property, ignoring: ")+config.ErrorFormat, err)
+		}
+	}
+	sources.FollowSystem = srcFollowSystem
+
+	used := sizeFromProp(ctx, name, libzfs.DatasetPropUsed, dZFSprops)
+	referenced := sizeFromProp(ctx, name, libzfs.DatasetPropReferenced, dZFSprops)
+	written := sizeFromProp(ctx, name, libzfs.DatasetPropWritten, dZFSprops)
+
+	// Encryption properties are read only and always inherited from the encryption root, including on snapshots.
+	var encryptionRoot, keyFormat, keyStatus string
+	encryption := nativeValue(dZFSprops, libzfs.DatasetPropEncryption)
+	if encryption == "off" {
+		encryption = ""
+	}
+	if encryption != "" {
+		encryptionRoot = nativeValue(dZFSprops, libzfs.DatasetPropEncryptionRoot)
+		keyFormat = nativeValue(dZFSprops, libzfs.DatasetPropKeyFormat)
+		keyStatus = nativeValue(dZFSprops, libzfs.DatasetPropKeyStatus)
+	}
+
 	d.DatasetProp = DatasetProp{
 		Mountpoint:       mountpoint,
 		CanMount:         canMount,
@@ -147,12 +225,50 @@ func (d *Dataset) refreshProperties(ctx context.Context) error {
 		LastUsed:         lastUsed,
 		LastBootedKernel: lastBootedKernel,
 		BootfsDatasets:   bootfsDatasets,
+		NextBoot:         nextBoot,
+		LastReplicated:   lastReplicated,
+		ClonedFrom:       clonedFrom,
 		Origin:           origin,
+		Description:      description,
+		Label:            label,
+		Pinned:           pinned,
+		Excluded:         excluded == "yes",
+		FollowSystem:     followSystem == "yes",
+		Used:             used,
+		Referenced:       referenced,
+		Written:          written,
+		Encryption:       encryption,
+		EncryptionRoot:   encryptionRoot,
+		KeyFormat:        keyFormat,
+		KeyStatus:        keyStatus,
 		sources:          sources,
 	}
 	return nil
 }
 
+// sizeFromProp returns the size in bytes stored in a native property, 0 if not set or invalid.
+func sizeFromProp(ctx context.Context, name string, prop libzfs.Prop, props map[libzfs.Prop]libzfs.Property) uint64 {
+	v := props[prop].Value
+	if v == "" || v == "-" {
+		return 0
+	}
+	size, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("size property for %q isn't a number, ignoring: ")+config.ErrorFormat, name, err)
+		return 0
+	}
+	return size
+}
+
+// nativeValue returns the value of a native property, empty if not set or not applicable.
+func nativeValue(props map[libzfs.Prop]libzfs.Property, prop libzfs.Prop) string {
+	v := props[prop].Value
+	if v == "-" || v == "none" {
+		return ""
+	}
+	return v
+}
+
 // getUserPropertyFromSys returns the value of a user property and its source from the underlying
 // ZFS system dataset state.
 // It also sanitize the sources to only return "local" or "inherited".
@@ -253,6 +369,15 @@ func splitSnapshotName(name string) (string, string) {
 	return name[:i], name[i+1:]
 }
 
+// splitSnapshotOrBookmarkName return base and trailing names of a snapshot or a bookmark
+func splitSnapshotOrBookmarkName(name string) (string, string) {
+	i := strings.LastIndexAny(name, "@#")
+	if i < 0 {
+		return name, ""
+	}
+	return name[:i], name[i+1:]
+}
+
 // checkSnapshotHierarchyIntegrity checks that the hierarchy follow the correct rules.
 // There are multiple cases:
 // * All children datasets with a snapshot with the same name exists -> OK, nothing in particular to deal with
@@ -416,6 +541,12 @@ func (d *Dataset) setProperty(name, value, source string) (err error) {
 			bootFS = true
 		}
 		d.BootFS = bootFS
+	case libzfs.PinnedProp:
+		d.Pinned = value == "yes"
+	case libzfs.ExcludedProp:
+		d.Excluded = value == "yes"
+	case libzfs.FollowSystemProp:
+		d.FollowSystem = value == "yes"
 	case libzfs.LastUsedProp:
 		lastUsed, err := strconv.Atoi(value)
 		if err != nil {
@@ -476,6 +607,12 @@ func (d *Dataset) setProperty(name, value, source string) (err error) {
 				bootFS = true
 			}
 			c.BootFS = bootFS
+		case libzfs.PinnedProp:
+			c.Pinned = value == "yes"
+		case libzfs.ExcludedProp:
+			c.Excluded = value == "yes"
+		case libzfs.FollowSystemProp:
+			c.FollowSystem = value == "yes"
 		case libzfs.LastUsedProp:
 			lastUsed, err := strconv.Atoi(value)
 			if err != nil {
@@ -522,7 +659,7 @@ func (d *Dataset) stringToProp(name string) (nativeProp libzfs.Prop, userProp st
 	case libzfs.SnapshotMountpointProp:
 		value = &d.Mountpoint
 		simplifiedSource = &d.sources.Mountpoint
-	// Bootfs and LastUsed are non string. Return a local string
+	// Bootfs, Pinned, Excluded, FollowSystem and LastUsed are non string. Return a local string
 	case libzfs.BootfsProp:
 		bootfs := "yes"
 		if !d.BootFS {
@@ -530,6 +667,27 @@ func (d *Dataset) stringToProp(name string) (nativeProp libzfs.Prop, userProp st
 		}
 		value = &bootfs
 		simplifiedSource = &d.sources.BootFS
+	case libzfs.PinnedProp:
+		pinned := "yes"
+		if !d.Pinned {
+			pinned = "no"
+		}
+		value = &pinned
+		simplifiedSource = &d.sources.Pinned
+	case libzfs.ExcludedProp:
+		excluded := "yes"
+		if !d.Excluded {
+			excluded = "no"
+		}
+		value = &excluded
+		simplifiedSource = &d.sources.Excluded
+	case libzfs.FollowSystemProp:
+		followSystem := "yes"
+		if !d.FollowSystem {
+			followSystem = "no"
+		}
+		value = &followSystem
+		simplifiedSource = &d.sources.FollowSystem
 	case libzfs.LastUsedProp:
 		lu := strconv.Itoa(d.LastUsed)
 		value = &lu
@@ -540,6 +698,21 @@ func (d *Dataset) stringToProp(name string) (nativeProp libzfs.Prop, userProp st
 	case libzfs.LastBootedKernelProp:
 		value = &d.LastBootedKernel
 		simplifiedSource = &d.sources.LastBootedKernel
+	case libzfs.NextBootProp:
+		value = &d.NextBoot
+		simplifiedSource = &d.sources.NextBoot
+	case libzfs.LastReplicatedProp:
+		value = &d.LastReplicated
+		simplifiedSource = &d.sources.LastReplicated
+	case libzfs.ClonedFromProp:
+		value = &d.ClonedFrom
+		simplifiedSource = &d.sources.ClonedFrom
+	case libzfs.DescriptionProp:
+		value = &d.Description
+		simplifiedSource = &d.sources.Description
+	case libzfs.LabelProp:
+		value = &d.Label
+		simplifiedSource = &d.sources.Label
 	default:
 		panic(fmt.Sprintf("unsupported property %q", name))
 	}
@@ -1,6 +1,8 @@
 package libzfs
 
 import (
+	"io"
+
 	golibzfs "github.com/bicomsystems/go-libzfs"
 )
 
@@ -29,6 +31,8 @@ const (
 	PoolPropAltroot = golibzfs.PoolPropAltroot
 	// PoolPropCapacity ZFS Pool property
 	PoolPropCapacity = golibzfs.PoolPropCapacity
+	// PoolPropSize ZFS Pool property
+	PoolPropSize = golibzfs.PoolPropSize
 	// PoolNumProps is the end pool number property
 	PoolNumProps = golibzfs.PoolNumProps
 	// VDevTypeFile is the vdevtype on file
@@ -55,6 +59,34 @@ const (
 	DatasetPropCreation = golibzfs.DatasetPropCreation
 	// DatasetPropVolsize is the volume size property for the dataset
 	DatasetPropVolsize = golibzfs.DatasetPropVolsize
+	// DatasetPropUsed is the space consumed by the dataset and all its descendents, in bytes
+	DatasetPropUsed = golibzfs.DatasetPropUsed
+	// DatasetPropReferenced is the space referenced by the dataset, in bytes
+	DatasetPropReferenced = golibzfs.DatasetPropReferenced
+	// DatasetPropWritten is the space written since the previous snapshot, in bytes
+	DatasetPropWritten = golibzfs.DatasetPropWritten
+	// DatasetPropAtime controls whether the access time of files is updated when they are read
+	DatasetPropAtime = golibzfs.DatasetPropAtime
+	// DatasetPropCompression is the compression algorithm of the dataset
+	DatasetPropCompression = golibzfs.DatasetPropCompression
+	// DatasetPropCopies is the number of copies of data stored for the dataset
+	DatasetPropCopies = golibzfs.DatasetPropCopies
+	// DatasetPropQuota limits the space used by the dataset and all its descendents
+	DatasetPropQuota = golibzfs.DatasetPropQuota
+	// DatasetPropRecordsize is the suggested block size for files in the dataset
+	DatasetPropRecordsize = golibzfs.DatasetPropRecordsize
+	// DatasetPropRefquota limits the space referenced by the dataset
+	DatasetPropRefquota = golibzfs.DatasetPropRefquota
+	// DatasetPropEncryption is the encryption algorithm of the dataset, "off" if not encrypted
+	DatasetPropEncryption = golibzfs.DatasetPropEncryption
+	// DatasetPropEncryptionRoot is the dataset whose key encrypts this dataset
+	DatasetPropEncryptionRoot = golibzfs.DatasetPropEncryptionRoot
+	// DatasetPropKeyFormat is the format of the encryption key: passphrase, hex or raw
+	DatasetPropKeyFormat = golibzfs.DatasetPropKeyFormat
+	// DatasetPropKeyLocation is where the encryption key is loaded from: prompt or a file:// URI
+	DatasetPropKeyLocation = golibzfs.DatasetPropKeyLocation
+	// DatasetPropKeyStatus reports if the encryption key is loaded: available or unavailable
+	DatasetPropKeyStatus = golibzfs.DatasetPropKeyStatus
 )
 
 const (
@@ -67,6 +99,22 @@ const (
 	BootfsDatasetsProp = zsysPrefix + "bootfs-datasets"
 	// LastBootedKernelProp string value
 	LastBootedKernelProp = zsysPrefix + "last-booted-kernel"
+	// NextBootProp string value
+	NextBootProp = zsysPrefix + "next-boot"
+	// LastReplicatedProp string value
+	LastReplicatedProp = zsysPrefix + "last-replicated"
+	// ClonedFromProp string value
+	ClonedFromProp = zsysPrefix + "cloned-from"
+	// DescriptionProp string value
+	DescriptionProp = zsysPrefix + "description"
+	// LabelProp string value
+	LabelProp = zsysPrefix + "label"
+	// PinnedProp string value
+	PinnedProp = zsysPrefix + "pinned"
+	// ExcludedProp string value
+	ExcludedProp = zsysPrefix + "excluded"
+	// FollowSystemProp string value
+	FollowSystemProp = zsysPrefix + "follow-system"
 	// CanmountProp string value
 	CanmountProp = "canmount"
 	// SnapshotCanmountProp is the equivalent to CanmountProp, but as a user property to store on zsys snapshot
@@ -77,6 +125,36 @@ const (
 	SnapshotMountpointProp = zsysPrefix + MountPointProp
 )
 
+// DiffChange is the type of change on a path between two datasets, as reported by zfs diff.
+type DiffChange string
+
+const (
+	// DiffAdded is a path which has been added
+	DiffAdded DiffChange = "+"
+	// DiffRemoved is a path which has been removed
+	DiffRemoved DiffChange = "-"
+	// DiffModified is a path which has been modified
+	DiffModified DiffChange = "M"
+	// DiffRenamed is a path which has been renamed to NewPath
+	DiffRenamed DiffChange = "R"
+)
+
+// DiffEntry is a single path change between a snapshot and a later snapshot or filesystem.
+// Paths are relative to the dataset root and always start with "/".
+type DiffEntry struct {
+	Change  DiffChange
+	Path    string
+	NewPath string
+}
+
+// Bookmark is a reference to a snapshot, which stays valid as the base of incremental streams once the snapshot is destroyed.
+type Bookmark struct {
+	// Name of the bookmark, as <filesystem>#<bookmark name>.
+	Name string
+	// Creation is the creation time of the bookmarked snapshot, as a unix timestamp.
+	Creation int64
+}
+
 // Interface is the interface to use real libzfs or our in memory mock.
 type Interface interface {
 	PoolOpen(name string) (pool Pool, err error)
@@ -84,6 +162,13 @@ type Interface interface {
 	DatasetOpen(name string) (d DZFSInterface, err error)
 	DatasetCreate(path string, dtype DatasetType, props map[Prop]Property) (d DZFSInterface, err error)
 	DatasetSnapshot(path string, recur bool, props map[Prop]Property, userProps map[string]string) (rd DZFSInterface, err error)
+	Diff(from, to string) (changes []DiffEntry, err error)
+	Send(name, from string, w io.Writer) (err error)
+	Receive(name string, r io.Reader, props map[string]string) (err error)
+	DatasetRename(name, newName string) (err error)
+	Bookmarks() (bookmarks []Bookmark, err error)
+	BookmarkCreate(snapshot, bookmark string) (err error)
+	BookmarkDestroy(name string) (err error)
 	GenerateID(length int) string
 }
 
@@ -1,7 +1,14 @@
 package libzfs
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
 	"math/rand"
+	"os/exec"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -62,6 +69,168 @@ func (*Adapter) DatasetSnapshot(path string, recur bool, props map[Prop]Property
 	return dZFSAdapter{&d}, nil
 }
 
+// Diff lists changed paths between snapshot from and to, which is a later snapshot or a filesystem.
+// go-libzfs doesn't expose zfs diff, so we shell out to the zfs command.
+func (*Adapter) Diff(from, to string) ([]DiffEntry, error) {
+	// zfs diff reports absolute paths under the filesystem mountpoint
+	base := strings.Split(from, "@")[0]
+	d, err := golibzfs.DatasetOpenSingle(base)
+	if err != nil {
+		return nil, err
+	}
+	mounted, where := d.IsMounted()
+	d.Close()
+	if !mounted {
+		return nil, fmt.Errorf("%q needs to be mounted to be diffed", base)
+	}
+	where = strings.TrimSuffix(where, "/")
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", "diff", "-H", from, to)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("zfs diff failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var changes []DiffEntry
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		e := DiffEntry{
+			Change: DiffChange(fields[0]),
+			Path:   relativeDiffPath(fields[1], where),
+		}
+		if e.Change == DiffRenamed && len(fields) > 2 {
+			e.NewPath = relativeDiffPath(fields[2], where)
+		}
+		changes = append(changes, e)
+	}
+	return changes, scanner.Err()
+}
+
+// Send writes to w a replication stream of snapshot name, including its properties.
+// If from is not empty, the stream is incremental from this earlier snapshot of the same filesystem.
+// go-libzfs doesn't expose zfs send with properties, so we shell out to the zfs command.
+func (*Adapter) Send(name, from string, w io.Writer) error {
+	args := []string{"send", "-p"}
+	if from != "" {
+		args = append(args, "-i", from)
+	}
+	args = append(args, name)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", args...)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("zfs send failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// Receive creates or updates filesystem name from the replication stream read from r, without mounting it.
+// props are set on the received filesystem, overriding the ones from the stream.
+func (*Adapter) Receive(name string, r io.Reader, props map[string]string) error {
+	args := []string{"receive", "-u"}
+	for k, v := range props {
+		args = append(args, "-o", k+"="+v)
+	}
+	args = append(args, name)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", args...)
+	cmd.Stdin = r
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("zfs receive failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// DatasetRename renames filesystem name to newName, moving its children and snapshots along.
+func (Adapter) DatasetRename(name, newName string) error {
+	d, err := golibzfs.DatasetOpen(name)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Rename(newName, false, false)
+}
+
+// Bookmarks lists all bookmarks of imported pools.
+// go-libzfs doesn't iterate over bookmarks, so we shell out to the zfs command.
+func (*Adapter) Bookmarks() ([]Bookmark, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", "list", "-H", "-p", "-t", "bookmark", "-o", "name,creation")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("zfs list failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var bookmarks []Bookmark
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected zfs list output: %q", scanner.Text())
+		}
+		creation, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid creation time for %q: %v", fields[0], err)
+		}
+		bookmarks = append(bookmarks, Bookmark{Name: fields[0], Creation: creation})
+	}
+	return bookmarks, scanner.Err()
+}
+
+// BookmarkCreate creates bookmark, named <filesystem>#<name>, of snapshot.
+func (*Adapter) BookmarkCreate(snapshot, bookmark string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", "bookmark", snapshot, bookmark)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("zfs bookmark failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// BookmarkDestroy destroys bookmark name.
+func (*Adapter) BookmarkDestroy(name string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", "destroy", name)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("zfs destroy failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// relativeDiffPath unescapes a path printed by zfs diff and makes it relative to the dataset mountpoint.
+func relativeDiffPath(p, mountpoint string) string {
+	// zfs diff escapes non printable characters as \ooo octal sequences
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		if p[i] == '\\' && i+3 < len(p) {
+			if c, err := strconv.ParseUint(p[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(p[i])
+	}
+	r := strings.TrimPrefix(b.String(), mountpoint)
+	if !strings.HasPrefix(r, "/") {
+		r = "/" + r
+	}
+	return r
+}
+
 var seedOnce = sync.Once{}
 
 // GenerateID with n ascii or digits, lowercase, characters
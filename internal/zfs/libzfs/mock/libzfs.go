@@ -1,9 +1,13 @@
 package mock
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,16 +20,19 @@ const currentMagicTime = "2000000000"
 
 // LibZFS is the mock, in memory implementation of libzfs
 type LibZFS struct {
-	mu       sync.RWMutex
-	datasets map[string]*dZFS
-	pools    map[string]libzfs.Pool
+	mu        sync.RWMutex
+	datasets  map[string]*dZFS
+	bookmarks map[string]libzfs.Bookmark
+	pools     map[string]libzfs.Pool
 
+	errOnBookmarks    bool
 	errOnCreate       bool
 	errOnClone        bool
 	errOnDestroyDS    []string
 	errOnPromote      bool
 	errOnScan         bool
 	errOnSetProperty  bool
+	errOnSetUserProp  bool
 	forceLastUsedTime bool
 }
 
@@ -44,6 +51,7 @@ func (l *LibZFS) PoolCreate(name string, vdev libzfs.VDevTree, features map[stri
 		Properties: make([]libzfs.Property, libzfs.PoolNumProps+1),
 	}
 	p.Properties[libzfs.PoolPropCapacity] = libzfs.Property{Value: "30"}
+	p.Properties[libzfs.PoolPropSize] = libzfs.Property{Value: "107374182400"}
 	for i, prop := range props {
 		p.Properties[i] = libzfs.Property{Value: prop}
 	}
@@ -142,6 +150,7 @@ func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[
 	}
 	l.mu.RLock()
 	if _, ok := l.pools[poolName]; !ok {
+		l.mu.RUnlock()
 		return nil, fmt.Errorf("pool %q doesn't exists", poolName)
 	}
 	l.mu.RUnlock()
@@ -217,7 +226,8 @@ func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[
 
 		// User properties (can only be from parent at creation time)
 		for _, k := range []string{libzfs.BootfsProp, libzfs.LastUsedProp, libzfs.BootfsDatasetsProp, libzfs.LastBootedKernelProp,
-			libzfs.CanmountProp, libzfs.SnapshotCanmountProp, libzfs.MountPointProp, libzfs.SnapshotMountpointProp} {
+			libzfs.NextBootProp, libzfs.DescriptionProp, libzfs.LabelProp, libzfs.PinnedProp, libzfs.ExcludedProp, libzfs.FollowSystemProp, libzfs.CanmountProp, libzfs.SnapshotCanmountProp,
+			libzfs.MountPointProp, libzfs.SnapshotMountpointProp, libzfs.LastReplicatedProp, libzfs.ClonedFromProp} {
 			if _, ok := parent.userProperties[k]; ok {
 				p := parent.userProperties[k]
 				if p.Source == "local" {
@@ -235,6 +245,38 @@ func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[
 		}
 	}
 
+	// Encryption: either a new encryption root or inherited from the parent encryption root
+	if enc, ok := props[libzfs.DatasetPropEncryption]; ok && enc.Value != "off" && dtype != libzfs.DatasetTypeSnapshot {
+		if enc.Value == "on" {
+			enc.Value = "aes-256-gcm"
+		}
+		keyFormat := props[libzfs.DatasetPropKeyFormat].Value
+		if keyFormat == "" {
+			keyFormat = "passphrase"
+		}
+		keyLocation := props[libzfs.DatasetPropKeyLocation].Value
+		if keyLocation == "" {
+			keyLocation = "prompt"
+		}
+		// As zfs, read the passphrase on creation
+		if keyFormat == "passphrase" && strings.HasPrefix(keyLocation, "file://") {
+			key, err := ioutil.ReadFile(strings.TrimPrefix(keyLocation, "file://"))
+			if err != nil {
+				return nil, fmt.Errorf("can't read passphrase of %q: %v", path, err)
+			}
+			if len(key) == 0 {
+				return nil, fmt.Errorf("empty passphrase for %q", path)
+			}
+		}
+		props[libzfs.DatasetPropEncryption] = libzfs.Property{Value: enc.Value, Source: "-"}
+		props[libzfs.DatasetPropEncryptionRoot] = libzfs.Property{Value: path, Source: "-"}
+		props[libzfs.DatasetPropKeyFormat] = libzfs.Property{Value: keyFormat, Source: "-"}
+		props[libzfs.DatasetPropKeyLocation] = libzfs.Property{Value: keyLocation, Source: "local"}
+		props[libzfs.DatasetPropKeyStatus] = libzfs.Property{Value: "available", Source: "-"}
+	} else if hasParent {
+		copyEncryptionProperties(props, parent.Dataset.Properties)
+	}
+
 	d := dZFS{
 		Dataset: &libzfs.Dataset{
 			Type:       dtype,
@@ -286,6 +328,11 @@ func (l *LibZFS) createSnapshot(path string, recur bool, props map[libzfs.Prop]l
 	}
 
 	d := dinterface.(*dZFS)
+	l.mu.RLock()
+	if parent, ok := l.datasets[strings.Split(path, "@")[0]]; ok {
+		d.files = copyFiles(parent.files)
+	}
+	l.mu.RUnlock()
 	for k, v := range userProps {
 		if err := d.SetUserProperty(k, v); err != nil {
 			return nil, err
@@ -310,6 +357,35 @@ func (l *LibZFS) createSnapshot(path string, recur bool, props map[libzfs.Prop]l
 	return d, nil
 }
 
+// copyEncryptionProperties sets encryption properties of props from the ones of the dataset sharing its key.
+func copyEncryptionProperties(props, from map[libzfs.Prop]libzfs.Property) {
+	for _, p := range []libzfs.Prop{libzfs.DatasetPropEncryption, libzfs.DatasetPropEncryptionRoot,
+		libzfs.DatasetPropKeyFormat, libzfs.DatasetPropKeyStatus} {
+		v, ok := from[p]
+		if !ok || v.Value == "off" {
+			delete(props, p)
+			continue
+		}
+		props[p] = v
+	}
+	delete(props, libzfs.DatasetPropKeyLocation)
+	if _, ok := props[libzfs.DatasetPropEncryption]; ok {
+		props[libzfs.DatasetPropKeyLocation] = libzfs.Property{Value: "none", Source: "default"}
+	}
+}
+
+// UnloadKey is a test-only helper to mark the key of the encryption root, and so all datasets it encrypts, as not loaded.
+func (l *LibZFS) UnloadKey(root string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, d := range l.datasets {
+		if d.Dataset.Properties[libzfs.DatasetPropEncryptionRoot].Value != root {
+			continue
+		}
+		d.Dataset.Properties[libzfs.DatasetPropKeyStatus] = libzfs.Property{Value: "unavailable", Source: "-"}
+	}
+}
+
 // SetDatasetAsMounted is a test-only property allowing forcing one dataset to be mounted
 func (l *LibZFS) SetDatasetAsMounted(name string, mounted bool) {
 	l.mu.Lock()
@@ -322,6 +398,387 @@ func (l *LibZFS) SetDatasetAsMounted(name string, mounted bool) {
 	d.setPropertyWithSource(libzfs.DatasetPropMounted, m, "")
 }
 
+// SetFiles sets the file content of a dataset or snapshot, as a map of path to file content.
+// Paths are relative to the dataset root.
+func (l *LibZFS) SetFiles(name string, files map[string]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.datasets[name].files = copyFiles(files)
+}
+
+// Diff lists changed paths between snapshot from and to, which is a later snapshot or a filesystem.
+// Renames are detected on removed and added paths sharing the same unique content.
+func (l *LibZFS) Diff(from, to string) ([]libzfs.DiffEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	f, ok := l.datasets[from]
+	if !ok {
+		return nil, fmt.Errorf("No dataset found with name %q", from)
+	}
+	if !f.IsSnapshot() {
+		return nil, fmt.Errorf("%q is not a snapshot", from)
+	}
+	t, ok := l.datasets[to]
+	if !ok {
+		return nil, fmt.Errorf("No dataset found with name %q", to)
+	}
+
+	var changes []libzfs.DiffEntry
+	var removed, added []string
+	for p, c := range f.files {
+		nc, ok := t.files[p]
+		if !ok {
+			removed = append(removed, p)
+			continue
+		}
+		if nc != c {
+			changes = append(changes, libzfs.DiffEntry{Change: libzfs.DiffModified, Path: p})
+		}
+	}
+	for p := range t.files {
+		if _, ok := f.files[p]; !ok {
+			added = append(added, p)
+		}
+	}
+
+	// Pair removed and added paths with the same content, only if it is unique on both sides.
+	contentCount := func(files map[string]string, paths []string) map[string]int {
+		r := make(map[string]int)
+		for _, p := range paths {
+			r[files[p]]++
+		}
+		return r
+	}
+	removedContents, addedContents := contentCount(f.files, removed), contentCount(t.files, added)
+	renamedTo := make(map[string]string)
+	for _, p := range added {
+		c := t.files[p]
+		if addedContents[c] == 1 && removedContents[c] == 1 {
+			renamedTo[c] = p
+		}
+	}
+	for _, p := range removed {
+		if newPath, ok := renamedTo[f.files[p]]; ok {
+			changes = append(changes, libzfs.DiffEntry{Change: libzfs.DiffRenamed, Path: p, NewPath: newPath})
+			continue
+		}
+		changes = append(changes, libzfs.DiffEntry{Change: libzfs.DiffRemoved, Path: p})
+	}
+	for _, p := range added {
+		if renamedTo[t.files[p]] == p {
+			continue
+		}
+		changes = append(changes, libzfs.DiffEntry{Change: libzfs.DiffAdded, Path: p})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// sendStream is the content of a mock replication stream.
+type sendStream struct {
+	Snapshot               string
+	From                   string
+	Creation               string
+	Properties             map[string]string
+	UserProperties         map[string]string
+	SnapshotUserProperties map[string]string
+	Files                  map[string]string
+}
+
+// Send writes to w a replication stream of snapshot name, including local properties of the snapshot and its filesystem.
+// If from is not empty, the stream is incremental from this earlier snapshot of the same filesystem.
+func (l *LibZFS) Send(name, from string, w io.Writer) error {
+	l.mu.RLock()
+	s, ok := l.datasets[name]
+	if !ok {
+		l.mu.RUnlock()
+		return fmt.Errorf("No dataset found with name %q", name)
+	}
+	if !s.IsSnapshot() {
+		l.mu.RUnlock()
+		return fmt.Errorf("%q is not a snapshot", name)
+	}
+	base := strings.Split(name, "@")[0]
+	fs := l.datasets[base]
+
+	stream := sendStream{
+		Snapshot:               strings.Split(name, "@")[1],
+		Creation:               s.Dataset.Properties[libzfs.DatasetPropCreation].Value,
+		Properties:             make(map[string]string),
+		UserProperties:         localUserProperties(fs.userProperties),
+		SnapshotUserProperties: localUserProperties(s.userProperties),
+		Files:                  copyFiles(s.files),
+	}
+	for _, p := range []libzfs.Prop{libzfs.DatasetPropMountpoint, libzfs.DatasetPropCanmount} {
+		if fs.Dataset.Properties[p].Source != "local" {
+			continue
+		}
+		k := libzfs.MountPointProp
+		if p == libzfs.DatasetPropCanmount {
+			k = libzfs.CanmountProp
+		}
+		stream.Properties[k] = fs.Dataset.Properties[p].Value
+	}
+
+	if from != "" {
+		// Incremental streams can be based on a snapshot or a bookmark of the same filesystem.
+		if _, ok := l.bookmarks[from]; ok && strings.HasPrefix(from, base+"#") {
+			stream.From = strings.Split(from, "#")[1]
+		} else if f, ok := l.datasets[from]; ok && f.IsSnapshot() && strings.HasPrefix(from, base+"@") {
+			stream.From = strings.Split(from, "@")[1]
+		} else {
+			l.mu.RUnlock()
+			return fmt.Errorf("%q is not an earlier snapshot or bookmark of %q", from, base)
+		}
+	}
+	l.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(stream)
+}
+
+// Receive creates or updates filesystem name from the replication stream read from r.
+// props are set on the received filesystem, overriding the ones from the stream.
+func (l *LibZFS) Receive(name string, r io.Reader, props map[string]string) error {
+	var stream sendStream
+	if err := json.NewDecoder(r).Decode(&stream); err != nil {
+		return fmt.Errorf("invalid stream: %v", err)
+	}
+	if strings.Contains(name, "@") {
+		return fmt.Errorf("%q is not a filesystem", name)
+	}
+
+	l.mu.RLock()
+	fs, exists := l.datasets[name]
+	_, parentExists := l.datasets[filepath.Dir(name)]
+	_, snapshotExists := l.datasets[name+"@"+stream.Snapshot]
+	var fromExists bool
+	var latest string
+	if stream.From != "" {
+		_, fromExists = l.datasets[name+"@"+stream.From]
+		latest = l.latestSnapshot(name)
+	}
+	l.mu.RUnlock()
+
+	switch {
+	case stream.From == "" && exists:
+		return fmt.Errorf("destination %q exists", name)
+	case stream.From != "" && !exists:
+		return fmt.Errorf("destination %q doesn't exist for incremental stream", name)
+	case stream.From != "" && !fromExists:
+		return fmt.Errorf("destination %q doesn't have snapshot %q", name, stream.From)
+	case stream.From != "" && latest != stream.From:
+		return fmt.Errorf("destination %q has been modified since most recent snapshot %q", name, stream.From)
+	case snapshotExists:
+		return fmt.Errorf("destination %q already exists", name+"@"+stream.Snapshot)
+	case strings.Contains(name, "/") && !parentExists:
+		return fmt.Errorf("parent of %q doesn't exist", name)
+	}
+
+	fsProps := make(map[string]string)
+	for k, v := range stream.Properties {
+		fsProps[k] = v
+	}
+	userProps := make(map[string]string)
+	for k, v := range stream.UserProperties {
+		userProps[k] = v
+	}
+	for k, v := range props {
+		if k == libzfs.MountPointProp || k == libzfs.CanmountProp {
+			fsProps[k] = v
+			continue
+		}
+		userProps[k] = v
+	}
+
+	if !exists {
+		dProps := make(map[libzfs.Prop]libzfs.Property)
+		if v, ok := fsProps[libzfs.MountPointProp]; ok {
+			dProps[libzfs.DatasetPropMountpoint] = libzfs.Property{Value: v}
+		}
+		if v, ok := fsProps[libzfs.CanmountProp]; ok {
+			dProps[libzfs.DatasetPropCanmount] = libzfs.Property{Value: v}
+		}
+		d, err := l.DatasetCreate(name, libzfs.DatasetTypeFilesystem, dProps)
+		if err != nil {
+			return err
+		}
+		fs = d.(*dZFS)
+	} else {
+		if v, ok := fsProps[libzfs.MountPointProp]; ok {
+			fs.setPropertyWithSource(libzfs.DatasetPropMountpoint, v, "local")
+		}
+		if v, ok := fsProps[libzfs.CanmountProp]; ok {
+			fs.setPropertyWithSource(libzfs.DatasetPropCanmount, v, "local")
+		}
+	}
+	for k, v := range userProps {
+		fs.setUserPropertyWithSource(k, v, "local")
+	}
+
+	d, err := l.DatasetCreate(name+"@"+stream.Snapshot, libzfs.DatasetTypeSnapshot,
+		map[libzfs.Prop]libzfs.Property{libzfs.DatasetPropCreation: {Value: stream.Creation}})
+	if err != nil {
+		return err
+	}
+	snap := d.(*dZFS)
+	for k, v := range stream.SnapshotUserProperties {
+		snap.setUserPropertyWithSource(k, v, "local")
+	}
+
+	l.mu.Lock()
+	snap.files = copyFiles(stream.Files)
+	fs.files = copyFiles(stream.Files)
+	l.mu.Unlock()
+
+	return nil
+}
+
+// DatasetRename renames filesystem name to newName, moving its children, snapshots and bookmarks along.
+// Clones of moved snapshots are updated to point to their new origin.
+func (l *LibZFS) DatasetRename(name, newName string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	d, ok := l.datasets[name]
+	if !ok || d.IsSnapshot() {
+		return fmt.Errorf("%q is not a filesystem", name)
+	}
+	if strings.Split(name, "/")[0] != strings.Split(newName, "/")[0] {
+		return fmt.Errorf("can't rename %q to %q on another pool", name, newName)
+	}
+	if _, exists := l.datasets[newName]; exists {
+		return fmt.Errorf("dataset %q already exists", newName)
+	}
+	if _, exists := l.datasets[filepath.Dir(newName)]; !exists {
+		return fmt.Errorf("parent of %q doesn't exist", newName)
+	}
+	if strings.HasPrefix(newName, name+"/") {
+		return fmt.Errorf("can't rename %q to one of its descendants", name)
+	}
+
+	renamed := make(map[string]string)
+	for n, ds := range l.datasets {
+		if n != name && !strings.HasPrefix(n, name+"/") && !strings.HasPrefix(n, name+"@") {
+			continue
+		}
+		nn := newName + strings.TrimPrefix(n, name)
+		renamed[n] = nn
+		delete(l.datasets, n)
+		l.datasets[nn] = ds
+		ds.Dataset.Properties[libzfs.DatasetPropName] = libzfs.Property{Value: nn, Source: ds.Dataset.Properties[libzfs.DatasetPropName].Source}
+	}
+	for _, ds := range l.datasets {
+		if nn, ok := renamed[ds.Dataset.Properties[libzfs.DatasetPropOrigin].Value]; ok {
+			ds.Dataset.Properties[libzfs.DatasetPropOrigin] = libzfs.Property{Value: nn, Source: "-"}
+		}
+	}
+	for n, b := range l.bookmarks {
+		fs := strings.Split(n, "#")[0]
+		if fs != name && !strings.HasPrefix(fs, name+"/") {
+			continue
+		}
+		delete(l.bookmarks, n)
+		b.Name = newName + strings.TrimPrefix(n, name)
+		l.bookmarks[b.Name] = b
+	}
+	return nil
+}
+
+// Bookmarks lists all bookmarks, sorted by name.
+func (l *LibZFS) Bookmarks() ([]libzfs.Bookmark, error) {
+	if l.errOnBookmarks {
+		return nil, errors.New("Error on Bookmarks requested")
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var bookmarks []libzfs.Bookmark
+	for _, b := range l.bookmarks {
+		bookmarks = append(bookmarks, b)
+	}
+	sort.Slice(bookmarks, func(i, j int) bool { return bookmarks[i].Name < bookmarks[j].Name })
+	return bookmarks, nil
+}
+
+// BookmarkCreate creates bookmark, named <filesystem>#<name>, of snapshot.
+func (l *LibZFS) BookmarkCreate(snapshot, bookmark string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.datasets[snapshot]
+	if !ok || !s.IsSnapshot() {
+		return fmt.Errorf("%q is not a snapshot", snapshot)
+	}
+	if !strings.HasPrefix(bookmark, strings.Split(snapshot, "@")[0]+"#") {
+		return fmt.Errorf("bookmark %q is not on the filesystem of %q", bookmark, snapshot)
+	}
+	if _, exists := l.bookmarks[bookmark]; exists {
+		return fmt.Errorf("bookmark %q already exists", bookmark)
+	}
+	creation, err := strconv.ParseInt(s.Dataset.Properties[libzfs.DatasetPropCreation].Value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid creation time on %q: %v", snapshot, err)
+	}
+	l.bookmarks[bookmark] = libzfs.Bookmark{Name: bookmark, Creation: creation}
+	return nil
+}
+
+// BookmarkDestroy destroys bookmark name.
+func (l *LibZFS) BookmarkDestroy(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.bookmarks[name]; !exists {
+		return fmt.Errorf("No bookmark found with name %q", name)
+	}
+	delete(l.bookmarks, name)
+	return nil
+}
+
+// latestSnapshot returns the name of the most recent snapshot of filesystem name.
+func (l *LibZFS) latestSnapshot(name string) (latest string) {
+	var latestCreation int
+	for n, d := range l.datasets {
+		if !strings.HasPrefix(n, name+"@") {
+			continue
+		}
+		creation, err := strconv.Atoi(d.Dataset.Properties[libzfs.DatasetPropCreation].Value)
+		if err != nil {
+			continue
+		}
+		if latest == "" || creation > latestCreation {
+			latest, latestCreation = strings.TrimPrefix(n, name+"@"), creation
+		}
+	}
+	return latest
+}
+
+// localUserProperties returns the user properties set directly on a dataset.
+func localUserProperties(props map[string]libzfs.Property) map[string]string {
+	r := make(map[string]string)
+	for k, p := range props {
+		if p.Source != "local" {
+			continue
+		}
+		r[k] = p.Value
+	}
+	return r
+}
+
+func copyFiles(files map[string]string) map[string]string {
+	if files == nil {
+		return nil
+	}
+	r := make(map[string]string, len(files))
+	for k, v := range files {
+		r[k] = v
+	}
+	return r
+}
+
 // SetPoolCapacity allows forcing a capabity value on a pool
 func (l *LibZFS) SetPoolCapacity(name, cap string) {
 	l.mu.Lock()
@@ -329,6 +786,13 @@ func (l *LibZFS) SetPoolCapacity(name, cap string) {
 	l.pools[name].Properties[libzfs.PoolPropCapacity] = libzfs.Property{Value: cap}
 }
 
+// SetPoolSize allows forcing a size value in bytes on a pool
+func (l *LibZFS) SetPoolSize(name, size string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pools[name].Properties[libzfs.PoolPropSize] = libzfs.Property{Value: size}
+}
+
 // ErrOnPromote forces a failure of the mock on clone operation
 func (l *LibZFS) ErrOnPromote(shouldErr bool) {
 	l.errOnPromote = shouldErr
@@ -339,6 +803,11 @@ func (l *LibZFS) ErrOnClone(shouldErr bool) {
 	l.errOnClone = shouldErr
 }
 
+// ErrOnBookmarks forces a failure of the mock on listing bookmarks
+func (l *LibZFS) ErrOnBookmarks(shouldErr bool) {
+	l.errOnBookmarks = shouldErr
+}
+
 // ErrOnScan forces a failure of the mock on scan operation
 func (l *LibZFS) ErrOnScan(shouldErr bool) {
 	l.errOnScan = shouldErr
@@ -349,6 +818,11 @@ func (l *LibZFS) ErrOnSetProperty(shouldErr bool) {
 	l.errOnSetProperty = shouldErr
 }
 
+// ErrOnSetUserProperty forces a failure of the mock on set user property operation only
+func (l *LibZFS) ErrOnSetUserProperty(shouldErr bool) {
+	l.errOnSetUserProp = shouldErr
+}
+
 // ErrOnCreate forces a failure of the mock on create operation
 func (l *LibZFS) ErrOnCreate(shouldErr bool) {
 	l.errOnCreate = shouldErr
@@ -376,6 +850,7 @@ type dZFS struct {
 	userProperties map[string]libzfs.Property
 	isClosed       bool
 	tempOrigin     string
+	files          map[string]string
 }
 
 func (d dZFS) assertDatasetOpened() {
@@ -422,6 +897,9 @@ func (d dZFS) Clone(target string, props map[libzfs.Prop]libzfs.Property) (libzf
 	}
 
 	di := dinterface.(*dZFS)
+	// Clones share the key of their origin, whatever their parent is
+	copyEncryptionProperties(di.Dataset.Properties, d.Dataset.Properties)
+	di.files = copyFiles(d.files)
 	return di, nil
 }
 
@@ -447,7 +925,7 @@ func (d dZFS) GetUserProperty(p string) (prop libzfs.Property, err error) {
 }
 
 func (d *dZFS) SetUserProperty(prop, value string) error {
-	if d.libZFSMock.errOnSetProperty {
+	if d.libZFSMock.errOnSetProperty || d.libZFSMock.errOnSetUserProp {
 		return errors.New("Error on SetProperty requested")
 	}
 	d.assertDatasetOpened()
@@ -544,6 +1022,12 @@ func (d *dZFS) Destroy(Defer bool) (err error) {
 		}
 	}
 	delete(d.libZFSMock.datasets, n)
+	// Bookmarks don't prevent destroying their filesystem, but are destroyed with it.
+	for name := range d.libZFSMock.bookmarks {
+		if strings.HasPrefix(name, n+"#") {
+			delete(d.libZFSMock.bookmarks, name)
+		}
+	}
 	return nil
 }
 
@@ -675,7 +1159,8 @@ func (d *dZFS) ReloadProperties() (err error) {
 // New returns a initialized LibZFS mock object
 func New() LibZFS {
 	return LibZFS{
-		datasets: make(map[string]*dZFS),
-		pools:    make(map[string]libzfs.Pool),
+		datasets:  make(map[string]*dZFS),
+		bookmarks: make(map[string]libzfs.Bookmark),
+		pools:     make(map[string]libzfs.Pool),
 	}
 }
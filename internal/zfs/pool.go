@@ -22,3 +22,18 @@ func (z Zfs) GetPoolFreeSpace(n string) (freespace int, err error) {
 	}
 	return 100 - freespace, nil
 }
+
+// GetPoolSize returns the total size of the pool in bytes
+func (z Zfs) GetPoolSize(n string) (size uint64, err error) {
+	p, err := z.libzfs.PoolOpen(n)
+	if err != nil {
+		return 0, fmt.Errorf(i18n.G("Couldn't open pool %s: %v"), n, err)
+	}
+	defer p.Close()
+	s := p.Properties[libzfs.PoolPropSize].Value
+	size, err = strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf(i18n.G("Invalid size %q on pool %q: %v"), s, n, err)
+	}
+	return size, nil
+}
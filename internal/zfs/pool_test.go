@@ -63,3 +63,57 @@ func TestGetFreeSpace(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPoolSize(t *testing.T) {
+	failOnZFSPermissionDenied(t)
+
+	tests := map[string]struct {
+		pool string
+		size string
+
+		wantSize uint64
+		wantErr  bool
+	}{
+		"Size returned": {pool: "rpool", size: "10737418240", wantSize: 10737418240},
+
+		"Size is not a number":      {pool: "rpool", size: "NaN", wantErr: true},
+		"Size is negative":          {pool: "rpool", size: "-1", wantErr: true},
+		"Called on unexisting pool": {pool: "doesntexist", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			adapter := testutils.GetLibZFS(t)
+
+			lzfs, ok := adapter.(*mock.LibZFS)
+			if !ok {
+				t.Skip("Can only be called with the mock libzfs")
+			}
+
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "one_pool_one_dataset.yaml"), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			lzfs.SetPoolSize("rpool", tc.size)
+
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("ZFS new errored out when we expected not to: %v", err)
+			}
+
+			size, err := z.GetPoolSize(tc.pool)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assert.Equal(t, tc.wantSize, size, "Pool size is the expected value")
+		})
+	}
+}
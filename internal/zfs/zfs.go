@@ -3,8 +3,12 @@ package zfs
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
@@ -22,6 +26,9 @@ type Dataset struct {
 	// Name of the dataset.
 	Name       string
 	IsSnapshot bool `json:",omitempty"`
+	// IsBookmark is set on bookmarks, which only have a name and a creation time, stored as LastUsed.
+	// Bookmarks aren't part of the dataset hierarchy and are only returned by Bookmarks().
+	IsBookmark bool `json:",omitempty"`
 	DatasetProp
 
 	children []*Dataset
@@ -44,14 +51,51 @@ type DatasetProp struct {
 	LastBootedKernel string `json:",omitempty"`
 	// BootfsDatasets is a user property for user datasets, linking them to relevant system bootfs datasets.
 	BootfsDatasets string `json:",omitempty"`
+	// NextBoot is a user property on main system datasets, storing a one-shot revert request to a history state.
+	NextBoot string `json:",omitempty"`
+	// LastReplicated is a user property on filesystem datasets, storing the last snapshot replicated to the backup pool.
+	LastReplicated string `json:",omitempty"`
+	// ClonedFrom is a user property on filesystem datasets of a machine cloned from a state of another machine.
+	// It stores the ID of this state and separates the clone from the origin machine history.
+	ClonedFrom string `json:",omitempty"`
 	// Origin points to the dataset snapshot this one was clone from.
 	Origin string `json:",omitempty"`
+	// Description is a user property storing a free form text describing the state this dataset is part of.
+	Description string `json:",omitempty"`
+	// Label is a user property storing a short tag for the state this dataset is part of.
+	Label string `json:",omitempty"`
+	// Pinned is a user property protecting the state this dataset is part of from garbage collection.
+	Pinned bool `json:",omitempty"`
+	// Excluded is a user property keeping this dataset, and its children, out of system and user states.
+	// They are handled as persistent datasets instead.
+	Excluded bool `json:",omitempty"`
+	// FollowSystem is a user property on persistent datasets, snapshotting them with each saved system state.
+	FollowSystem bool `json:",omitempty"`
+	// Used is the space in bytes consumed by the dataset and all its descendents, snapshots included.
+	Used uint64 `json:",omitempty"`
+	// Referenced is the space in bytes accessible by the dataset, which may be shared with other datasets.
+	Referenced uint64 `json:",omitempty"`
+	// Written is the space in bytes written on the dataset since its previous snapshot.
+	Written uint64 `json:",omitempty"`
+	// Encryption is the encryption algorithm of the dataset. It is empty if the dataset isn't encrypted.
+	Encryption string `json:",omitempty"`
+	// EncryptionRoot is the dataset whose key encrypts this dataset, itself if it has its own key.
+	EncryptionRoot string `json:",omitempty"`
+	// KeyFormat is the format of the key of the encryption root: passphrase, hex or raw.
+	KeyFormat string `json:",omitempty"`
+	// KeyStatus reports if the key of an encrypted dataset is loaded: available or unavailable.
+	KeyStatus string `json:",omitempty"`
 
 	// Here are the sources (not exposed to the public API) for each property
 	// Used mostly for tests
 	sources datasetSources
 }
 
+// IsLocked returns true if the dataset is encrypted and its key isn't loaded.
+func (p DatasetProp) IsLocked() bool {
+	return p.Encryption != "" && p.KeyStatus != "available"
+}
+
 // datasetSources list sources some properties for a given dataset
 type datasetSources struct {
 	Mountpoint       string `json:",omitempty"`
@@ -60,6 +104,14 @@ type datasetSources struct {
 	LastUsed         string `json:",omitempty"`
 	LastBootedKernel string `json:",omitempty"`
 	BootfsDatasets   string `json:",omitempty"`
+	NextBoot         string `json:",omitempty"`
+	LastReplicated   string `json:",omitempty"`
+	ClonedFrom       string `json:",omitempty"`
+	Description      string `json:",omitempty"`
+	Label            string `json:",omitempty"`
+	Pinned           string `json:",omitempty"`
+	Excluded         string `json:",omitempty"`
+	FollowSystem     string `json:",omitempty"`
 }
 
 // Zfs is a system handler talking to zfs linux module.
@@ -123,10 +175,34 @@ func (z *Zfs) Refresh(ctx context.Context) error {
 	}
 	newZ.root.children = children
 
+	// Bookmarks only add information on removed states: don't fail the whole scan on them.
+	bookmarks, err := newZ.libzfs.Bookmarks()
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't list bookmarks, ignoring: ")+config.ErrorFormat, err)
+	}
+	for _, b := range bookmarks {
+		log.Debugf(ctx, i18n.G("New bookmark found: %q"), b.Name)
+		newZ.allDatasets[b.Name] = &Dataset{
+			Name:        b.Name,
+			IsBookmark:  true,
+			DatasetProp: DatasetProp{LastUsed: int(b.Creation)},
+		}
+	}
+
 	*z = newZ
 	return nil
 }
 
+// Fork returns a new zfs instance from a rescan of all datasets. Both instances can then be used concurrently,
+// as they don't share any dataset.
+func (z *Zfs) Fork(ctx context.Context) (*Zfs, error) {
+	n := &Zfs{libzfs: z.libzfs}
+	if err := n.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
 // Datasets returns all datasets on the system, where parent will always be before children.
 func (z Zfs) Datasets() []*Dataset {
 	ds := make(chan *Dataset)
@@ -152,11 +228,125 @@ func (z Zfs) Datasets() []*Dataset {
 	return r
 }
 
+// Bookmarks returns all bookmarks on the system, sorted by name.
+func (z Zfs) Bookmarks() []*Dataset {
+	var r []*Dataset
+	for _, d := range z.allDatasets {
+		if d.IsBookmark {
+			r = append(r, d)
+		}
+	}
+	sort.Slice(r, func(i, j int) bool { return r[i].Name < r[j].Name })
+	return r
+}
+
 // GenerateID returns from a given length a random string (known in advanced if libzfs mock is used)
 func (z Zfs) GenerateID(length int) string {
 	return z.libzfs.GenerateID(length)
 }
 
+// Diff returns changed paths between snapshot from and to, which is a later snapshot or a filesystem.
+// from needs to be a snapshot of to filesystem or of one of its origins, taken before to was cloned from it.
+// The filesystems of from and to need to be mounted. Paths are relative to the dataset root.
+func (z *Zfs) Diff(ctx context.Context, from, to string) ([]libzfs.DiffEntry, error) {
+	log.Debugf(ctx, i18n.G("ZFS: diff %q and %q"), from, to)
+
+	f, err := z.findDatasetByName(from)
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsSnapshot {
+		return nil, fmt.Errorf(i18n.G("%q is not a snapshot"), from)
+	}
+	t, err := z.findDatasetByName(to)
+	if err != nil {
+		return nil, err
+	}
+	if err := z.checkDiffLineage(f, t); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{from, to} {
+		fs, _ := splitSnapshotName(name)
+		d, err := z.findDatasetByName(fs)
+		if err != nil {
+			return nil, err
+		}
+		if !d.Mounted {
+			return nil, fmt.Errorf(i18n.G("%q needs to be mounted to compare %q and %q"), fs, from, to)
+		}
+	}
+
+	changes, err := z.libzfs.Diff(from, to)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't diff %q and %q: %v"), from, to, err)
+	}
+	return changes, nil
+}
+
+// checkDiffLineage returns an error if snapshot from isn't an earlier snapshot of to filesystem or of one of its
+// origins, taken before to was cloned from it.
+func (z *Zfs) checkDiffLineage(from, to *Dataset) error {
+	fromFS, _ := splitSnapshotName(from.Name)
+
+	// before is the snapshot from needs to be older than, if any.
+	cur, before := to.Name, (*Dataset)(nil)
+	if to.IsSnapshot {
+		cur, _ = splitSnapshotName(to.Name)
+		before = to
+	}
+	for {
+		if cur == fromFS {
+			if before != nil && from.LastUsed > before.LastUsed {
+				if before == to {
+					return fmt.Errorf(i18n.G("%q is newer than %q"), from.Name, to.Name)
+				}
+				return fmt.Errorf(i18n.G("%q was cloned from %q before %q was taken"), to.Name, before.Name, from.Name)
+			}
+			return nil
+		}
+		d, err := z.findDatasetByName(cur)
+		if err != nil {
+			return err
+		}
+		if d.Origin == "" {
+			return fmt.Errorf(i18n.G("%q and %q are unrelated: %q doesn't derive from %q"), from.Name, to.Name, to.Name, fromFS)
+		}
+		if before, err = z.findDatasetByName(d.Origin); err != nil {
+			return err
+		}
+		cur, _ = splitSnapshotName(d.Origin)
+	}
+}
+
+// Send writes to w a replication stream of snapshot name, including its properties.
+// If from is not empty, the stream is incremental from this earlier snapshot or bookmark of the same filesystem.
+func (z *Zfs) Send(ctx context.Context, name, from string, w io.Writer) error {
+	log.Debugf(ctx, i18n.G("ZFS: send %q from %q"), name, from)
+
+	d, err := z.findDatasetByName(name)
+	if err != nil {
+		return err
+	}
+	if !d.IsSnapshot {
+		return fmt.Errorf(i18n.G("%q is not a snapshot"), name)
+	}
+	if from != "" {
+		f, err := z.findDatasetByName(from)
+		if err != nil {
+			return err
+		}
+		base, _ := splitSnapshotName(name)
+		if fromBase, _ := splitSnapshotOrBookmarkName(from); !(f.IsSnapshot || f.IsBookmark) || fromBase != base {
+			return fmt.Errorf(i18n.G("%q is not a snapshot or bookmark of %q"), from, base)
+		}
+	}
+
+	if err := z.libzfs.Send(name, from, w); err != nil {
+		return fmt.Errorf(i18n.G("couldn't send %q: %v"), name, err)
+	}
+	return nil
+}
+
 // Transaction is a particular transaction on a Zfs state
 type Transaction struct {
 	*Zfs
@@ -193,18 +383,25 @@ func (z *Zfs) NewNoTransaction(ctx context.Context) *NoTransaction {
 // It returns a cancelFunc that is automatically purged on <Transaction>.Done().
 // If ctx is a cancellable or if CancelFunc is called before <Transaction>.Done(),
 // the transaction will revert any in progress zfs changes.
+// Once ctx is cancelled, any new operation on the transaction fails and the revert happens on Done(), so that it
+// never runs concurrently with an in progress operation.
 func (z *Zfs) NewTransaction(ctx context.Context) (*Transaction, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancelCtx := context.WithCancel(ctx)
+	cancelled := make(chan struct{})
+	var once sync.Once
 
 	t := Transaction{
-		Zfs:    z,
-		ctx:    ctx,
-		cancel: cancel,
-		done:   make(chan struct{}),
+		Zfs: z,
+		ctx: ctx,
+		cancel: func() {
+			once.Do(func() { close(cancelled) })
+			cancelCtx()
+		},
+		done: make(chan struct{}),
 	}
 
 	go func() {
-		<-ctx.Done()
+		<-cancelled
 
 		// check that any potential lastNestedTransaction has fully processed its reverted if it wasn't ended
 		if t.lastNestedTransaction != nil {
@@ -223,7 +420,7 @@ func (z *Zfs) NewTransaction(ctx context.Context) (*Transaction, context.CancelF
 		close(t.done)
 	}()
 
-	return &t, cancel
+	return &t, t.cancel
 }
 
 // Context returns the current context of the transaction
@@ -261,12 +458,12 @@ func (t *Transaction) Done() {
 	log.Debugf(t.ctx, i18n.G("ZFS: ending transaction"))
 	defer func() { log.Debugf(t.ctx, i18n.G("ZFS: transaction done")) }()
 
-	// If cancel() was called before Done(), ensure we have proceeded the revert functions.
-	select {
-	case <-t.ctx.Done():
+	// If cancel() was called or the parent context was cancelled before Done(), ensure we have proceeded
+	// the revert functions.
+	if t.ctx.Err() != nil {
+		t.cancel()
 		<-t.done
 		return
-	default:
 	}
 
 	t.reverts = nil
@@ -280,12 +477,17 @@ func (t *Transaction) registerRevert(f func() error) {
 }
 
 // checkValid verifies if the transaction object is still valid and panics if not.
-func (t *Transaction) checkValid() {
+// It returns an error if the transaction context was cancelled, as no new change should be started.
+func (t *Transaction) checkValid() error {
 	select {
 	case <-t.done:
 		panic(i18n.G("The ZFS transaction object has already been used and Done() was called. It can't be reused"))
 	default:
 	}
+	if err := t.ctx.Err(); err != nil {
+		return fmt.Errorf(i18n.G("transaction cancelled: %w"), err)
+	}
+	return nil
 }
 
 // newNestedTransaction creates a sub transaction from an in progress transaction, reusing the parent transaction
@@ -311,22 +513,114 @@ func (t *nestedTransaction) Done(err *error) {
 		t.cancel()
 		return
 	}
-	// append to parents current in progress transactions
+	// append to parents current in progress transactions, which now owns reverting them
 	t.parent.reverts = append(t.parent.reverts, t.reverts...)
+	t.reverts = nil
+}
+
+// EncryptionKey describes the key of a new encryption root.
+type EncryptionKey struct {
+	// Format of the key: passphrase, hex or raw.
+	Format string
+	// Location is where the key is loaded from: prompt or a file:// URI.
+	Location string
+	// Passphrase is only used at creation when the key is prompted for.
+	Passphrase string
+}
+
+const (
+	// MinPassphraseLen is the minimum length of a passphrase, in bytes, accepted by zfs.
+	MinPassphraseLen = 8
+	// MaxPassphraseLen is the maximum length of a passphrase, in bytes, accepted by zfs.
+	MaxPassphraseLen = 512
+)
+
+// ValidatePassphrase returns an error if passphrase can't be used as an encryption key by zfs.
+func ValidatePassphrase(passphrase string) error {
+	if len(passphrase) < MinPassphraseLen || len(passphrase) > MaxPassphraseLen {
+		return fmt.Errorf(i18n.G("passphrase must be between %d and %d bytes long"), MinPassphraseLen, MaxPassphraseLen)
+	}
+	return nil
+}
+
+// creationProperties are the native properties, by name, which can be set when creating a dataset.
+var creationProperties = map[string]libzfs.Prop{
+	"atime":       libzfs.DatasetPropAtime,
+	"compression": libzfs.DatasetPropCompression,
+	"copies":      libzfs.DatasetPropCopies,
+	"quota":       libzfs.DatasetPropQuota,
+	"recordsize":  libzfs.DatasetPropRecordsize,
+	"refquota":    libzfs.DatasetPropRefquota,
+}
+
+// IsCreationProperty returns if the native property name can be set when creating a dataset.
+func IsCreationProperty(name string) bool {
+	_, ok := creationProperties[name]
+	return ok
 }
 
 // Create creates a dataset for that path.
 func (t *Transaction) Create(path, mountpoint, canmount string) error {
-	t.checkValid()
+	return t.create(path, mountpoint, canmount, nil, nil)
+}
+
+// CreateWithProperties creates a dataset for that path with native properties set locally, like compression or quota.
+// If key isn't nil, the dataset is encrypted with it and is its own encryption root.
+func (t *Transaction) CreateWithProperties(path, mountpoint, canmount string, properties map[string]string, key *EncryptionKey) error {
+	return t.create(path, mountpoint, canmount, properties, key)
+}
+
+func (t *Transaction) create(path, mountpoint, canmount string, properties map[string]string, key *EncryptionKey) error {
+	if err := t.checkValid(); err != nil {
+		return err
+	}
 
 	log.Debugf(t.ctx, i18n.G("ZFS: trying to Create %q with mountpoint %q"), path, mountpoint)
 
+	if key != nil && key.Passphrase != "" {
+		if err := ValidatePassphrase(key.Passphrase); err != nil {
+			return fmt.Errorf(i18n.G("can't create %q: %v"), path, err)
+		}
+	}
+
 	props := make(map[libzfs.Prop]libzfs.Property)
+	for name, value := range properties {
+		p, ok := creationProperties[name]
+		if !ok {
+			return fmt.Errorf(i18n.G("property %q can't be set when creating %q"), name, path)
+		}
+		props[p] = libzfs.Property{Value: value}
+	}
 	if mountpoint != "" {
 		props[libzfs.DatasetPropMountpoint] = libzfs.Property{Value: mountpoint}
 	}
 	props[libzfs.DatasetPropCanmount] = libzfs.Property{Value: canmount}
 
+	if key != nil {
+		location := key.Location
+		// libzfs can't prompt for the key: hand the passphrase over through a pipe for the creation, so that it's never
+		// written to disk.
+		if key.Passphrase != "" {
+			r, w, err := os.Pipe()
+			if err != nil {
+				return fmt.Errorf(i18n.G("couldn't pass encryption key of %q: %v"), path, err)
+			}
+			defer r.Close()
+			// The passphrase length is checked above and always fits in the pipe buffer.
+			_, err = w.WriteString(key.Passphrase)
+			if errClose := w.Close(); err == nil {
+				err = errClose
+			}
+			if err != nil {
+				return fmt.Errorf(i18n.G("couldn't pass encryption key of %q: %v"), path, err)
+			}
+			location = fmt.Sprintf("file:///dev/fd/%d", r.Fd())
+		}
+		props[libzfs.DatasetPropEncryption] = libzfs.Property{Value: "on"}
+		props[libzfs.DatasetPropKeyFormat] = libzfs.Property{Value: key.Format}
+		props[libzfs.DatasetPropKeyLocation] = libzfs.Property{Value: location}
+	}
+
 	dZFS, err := t.Zfs.libzfs.DatasetCreate(path, libzfs.DatasetTypeFilesystem, props)
 	if err != nil {
 		return fmt.Errorf(i18n.G("can't create %q: %v"), path, err)
@@ -355,12 +649,21 @@ func (t *Transaction) Create(path, mountpoint, canmount string) error {
 	}
 	parent.children = append(parent.children, &d)
 
+	// The key pipe is gone once created: the key will be asked for from now on.
+	if key != nil && key.Passphrase != "" {
+		if err := dZFS.SetProperty(libzfs.DatasetPropKeyLocation, key.Location); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set key location of %q to %q: ")+config.ErrorFormat, path, key.Location, err)
+		}
+	}
+
 	return nil
 }
 
 // Snapshot creates a new snapshot for dataset (and children if recursive is true) with the given name.
 func (t *Transaction) Snapshot(snapName, datasetName string, recursive bool) (errSnapshot error) {
-	t.checkValid()
+	if err := t.checkValid(); err != nil {
+		return err
+	}
 
 	log.Debugf(t.ctx, i18n.G("ZFS: trying to snapshot %q, recursive: %v"), datasetName, recursive)
 
@@ -406,6 +709,9 @@ func (t *nestedTransaction) snapshotRecursive(parent *Dataset, snapName string,
 
 	dZFS, err := t.Zfs.libzfs.DatasetSnapshot(parent.Name+"@"+snapName, false, props, userPropertiesToSet)
 	if err != nil {
+		if parent.IsLocked() {
+			return fmt.Errorf(i18n.G("couldn't create snapshot %q, encryption key of %q isn't loaded: %v"), parent.Name+"@"+snapName, parent.EncryptionRoot, err)
+		}
 		return fmt.Errorf(i18n.G("couldn't create snapshot %q: %v"), parent.Name+"@"+snapName, err)
 	}
 
@@ -446,7 +752,9 @@ func (t *nestedTransaction) snapshotRecursive(parent *Dataset, snapName string,
 // Clone creates a new dataset from a snapshot (and children if recursive is true) with a given suffix,
 // stripping older _<suffix> if any.
 func (t *Transaction) Clone(name, suffix string, ignoreErrorOnExists, recursive bool) (errClone error) {
-	t.checkValid()
+	if err := t.checkValid(); err != nil {
+		return err
+	}
 
 	log.Debugf(t.ctx, i18n.G("ZFS: trying to clone %q"), name)
 	if suffix == "" {
@@ -570,6 +878,9 @@ func (t *nestedTransaction) cloneDataset(d Dataset, target string, ignoreErrorOn
 		if ignoreErrorOnExists && t.Zfs.datasetExists(target) {
 			return nil
 		}
+		if d.IsLocked() {
+			return fmt.Errorf(i18n.G("couldn't clone %q to %q, encryption key of %q isn't loaded: ")+config.ErrorFormat, d.Name, target, d.EncryptionRoot, err)
+		}
 		return fmt.Errorf(i18n.G("couldn't clone %q to %q: ")+config.ErrorFormat, d.Name, target, err)
 	}
 
@@ -627,9 +938,12 @@ func (t *nestedTransaction) cloneDataset(d Dataset, target string, ignoreErrorOn
 
 // Promote recursively all children, including dataset named "name".
 // If the hierarchy is partially promoted, promote the missing one and be no-op for the rest.
-// It will promote the main dataset until its origin is empty.
+// It will promote the main dataset until its origin is empty or until it reaches a dataset of another machine it was
+// cloned from.
 func (t *Transaction) Promote(name string) (errPromote error) {
-	t.checkValid()
+	if err := t.checkValid(); err != nil {
+		return err
+	}
 	log.Debugf(t.ctx, i18n.G("ZFS: trying to promote %q"), name)
 
 	d, err := t.Zfs.findDatasetByName(name)
@@ -644,8 +958,8 @@ func (t *Transaction) Promote(name string) (errPromote error) {
 	nestedT := t.newNestedTransaction()
 	defer nestedT.Done(&errPromote)
 
-	// Already promoted dataset
-	if d.Origin == "" {
+	// Already promoted dataset, or dataset cloned from another machine
+	if d.Origin == "" || d.ClonedFrom != "" {
 		return nil
 	}
 
@@ -675,8 +989,8 @@ func (t *Transaction) Promote(name string) (errPromote error) {
 func (t *nestedTransaction) promoteRecursive(d *Dataset) error {
 	log.Debugf(t.ctx, i18n.G("Trying to promote %q"), d.Name)
 
-	// Repromote until its origin is empty as a "master dataset"
-	for d.Origin != "" {
+	// Repromote until its origin is empty as a "master dataset" or belongs to another machine
+	for d.Origin != "" && d.ClonedFrom == "" {
 		origDatasetName, _ := splitSnapshotName(d.Origin)
 		origD, err := t.Zfs.findDatasetByName(origDatasetName)
 		if err != nil {
@@ -694,6 +1008,17 @@ func (t *nestedTransaction) promoteRecursive(d *Dataset) error {
 		if err := t.inverseOrigin(origD, d); err != nil {
 			return fmt.Errorf(i18n.G("couldn't refresh our internal origin and layout cache: %v"), err)
 		}
+
+		// The link to the machine origD was cloned from is now held by d
+		if origD.ClonedFrom != "" {
+			clonedFrom := origD.ClonedFrom
+			if err := d.setProperty(libzfs.ClonedFromProp, clonedFrom, "local"); err != nil {
+				return fmt.Errorf(i18n.G("couldn't set %q on %q: ")+config.ErrorFormat, libzfs.ClonedFromProp, d.Name, err)
+			}
+			if err := origD.setProperty(libzfs.ClonedFromProp, "", "local"); err != nil {
+				return fmt.Errorf(i18n.G("couldn't reset %q on %q: ")+config.ErrorFormat, libzfs.ClonedFromProp, origD.Name, err)
+			}
+		}
 	}
 
 	for i, c := range d.children {
@@ -710,6 +1035,7 @@ func (t *nestedTransaction) promoteRecursive(d *Dataset) error {
 // Destroy recursively all children, including dataset named "name".
 // If the dataset is a filesystem dataset, only remove it and children if there is no snapshots in the descendants.
 // If the dataset is a snapshot, navigate through the hierarchy to delete all dataset with the same snapshot name.
+// A bookmark is destroyed alone.
 // Note that destruction can't be rollbacked as filesystem content can't be recreated, so we don't accept them
 // in a transactional Zfs element.
 func (nt *NoTransaction) Destroy(name string) error {
@@ -719,6 +1045,14 @@ func (nt *NoTransaction) Destroy(name string) error {
 		return fmt.Errorf(i18n.G("can't get dataset to destroy %q: ")+config.ErrorFormat, name, err)
 	}
 
+	if d.IsBookmark {
+		if err := nt.Zfs.libzfs.BookmarkDestroy(name); err != nil {
+			return fmt.Errorf(i18n.G("cannot destroy bookmark %q: %v"), name, err)
+		}
+		delete(nt.Zfs.allDatasets, name)
+		return nil
+	}
+
 	if err := d.checkNoClone(); err != nil {
 		return fmt.Errorf(i18n.G("couldn't destroy %q due to clones: %v"), name, err)
 	}
@@ -800,16 +1134,92 @@ func (nt *NoTransaction) destroyOne(d *Dataset) error {
 		return nil
 	}
 
-	// Delete from main list of dataset
+	// Delete from main list of dataset, with bookmarks destroyed alongside a filesystem
 	delete(nt.Zfs.allDatasets, d.Name)
+	if !d.IsSnapshot {
+		for n, b := range nt.Zfs.allDatasets {
+			if b.IsBookmark && strings.HasPrefix(n, d.Name+"#") {
+				delete(nt.Zfs.allDatasets, n)
+			}
+		}
+	}
 
 	return nil
 }
 
+// Bookmark creates a bookmark of snapshot, with the same name, on its filesystem.
+// Bookmarks are only useful once their snapshot is destroyed, so we don't accept them in a transactional Zfs element.
+func (nt *NoTransaction) Bookmark(snapshot string) error {
+	log.Debugf(nt.ctx, i18n.G("ZFS: bookmark %q"), snapshot)
+
+	d, err := nt.Zfs.findDatasetByName(snapshot)
+	if err != nil {
+		return fmt.Errorf(i18n.G("can't get snapshot to bookmark %q: ")+config.ErrorFormat, snapshot, err)
+	}
+	if !d.IsSnapshot {
+		return fmt.Errorf(i18n.G("%q is not a snapshot"), snapshot)
+	}
+
+	base, snapName := splitSnapshotName(snapshot)
+	name := base + "#" + snapName
+	if _, exists := nt.Zfs.allDatasets[name]; exists {
+		log.Debugf(nt.ctx, i18n.G("bookmark %q already exists"), name)
+		return nil
+	}
+	if err := nt.Zfs.libzfs.BookmarkCreate(snapshot, name); err != nil {
+		return fmt.Errorf(i18n.G("couldn't bookmark %q: %v"), snapshot, err)
+	}
+	nt.Zfs.allDatasets[name] = &Dataset{
+		Name:        name,
+		IsBookmark:  true,
+		DatasetProp: DatasetProp{LastUsed: d.LastUsed},
+	}
+	return nil
+}
+
+// Receive creates filesystem name from a full replication stream read from r, or adds a snapshot to it
+// from an incremental one. The received filesystem isn't mounted and props override the ones from the stream.
+// Received content can't be rollbacked, so we don't accept it in a transactional Zfs element.
+func (nt *NoTransaction) Receive(name string, r io.Reader, props map[string]string) error {
+	log.Debugf(nt.ctx, i18n.G("ZFS: receive %q"), name)
+
+	if err := nt.Zfs.libzfs.Receive(name, r, props); err != nil {
+		return fmt.Errorf(i18n.G("couldn't receive %q: %v"), name, err)
+	}
+
+	// The stream can create the filesystem, a snapshot and change properties: rescan everything.
+	return nt.Zfs.Refresh(nt.ctx)
+}
+
+// Rename moves filesystem name, with its children and snapshots, to newName on the same pool.
+// The parent of newName needs to exist. The dataset is moved back to name if the transaction is cancelled.
+func (t *Transaction) Rename(name, newName string) error {
+	if err := t.checkValid(); err != nil {
+		return err
+	}
+	log.Debugf(t.ctx, i18n.G("ZFS: rename %q to %q"), name, newName)
+
+	if err := t.Zfs.libzfs.DatasetRename(name, newName); err != nil {
+		return fmt.Errorf(i18n.G("couldn't rename %q to %q: %v"), name, newName, err)
+	}
+	t.registerRevert(func() error {
+		if err := t.Zfs.libzfs.DatasetRename(newName, name); err != nil {
+			return fmt.Errorf(i18n.G("couldn't rename %q back to %q: %v"), newName, name, err)
+		}
+		return t.Zfs.Refresh(t.ctx)
+	})
+
+	// Every descendant, snapshot and clone origin changed: rescan everything.
+	return t.Zfs.Refresh(t.ctx)
+}
+
 // SetProperty to given dataset if it was locally set or none directly inheriting from parent value.
 // force does it even if the property was inherited.
 // For zfs properties, only a fix set is supported. Right now: "canmount"
 func (t *Transaction) SetProperty(name, value, datasetName string, force bool) error {
+	if err := t.checkValid(); err != nil {
+		return err
+	}
 	log.Debugf(t.ctx, i18n.G("ZFS: trying to set %q=%q on %q"), name, value, datasetName)
 	d, err := t.Zfs.findDatasetByName(datasetName)
 	if err != nil {
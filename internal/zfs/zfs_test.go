@@ -1,6 +1,7 @@
 package zfs_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/ubuntu/zsys/internal/testutils"
 	"github.com/ubuntu/zsys/internal/zfs"
 	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs/mock"
 )
 
 func init() {
@@ -155,6 +157,33 @@ func TestRefresh(t *testing.T) {
 	assertDatasetsEquals(t, ta, oldZ.Datasets(), z.Datasets())
 }
 
+func TestRefreshWithBookmarksError(t *testing.T) {
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	ta := timeAsserter(time.Now())
+	adapter := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml"), testutils.WithLibZFS(adapter))
+	defer fPools.Create(dir)()
+
+	z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(z.Bookmarks()) == 0 {
+		t.Fatal("setup failed: expected bookmarks to be listed")
+	}
+
+	oldZ := *z
+	adapter.(*mock.LibZFS).ErrOnBookmarks(true)
+	if err := z.Refresh(context.Background()); err != nil {
+		t.Fatalf("expected no error on failing to list bookmarks but got: %v", err)
+	}
+
+	assertDatasetsEquals(t, ta, oldZ.Datasets(), z.Datasets())
+	assert.Empty(t, z.Bookmarks(), "no bookmark is listed")
+}
+
 func TestCreate(t *testing.T) {
 	failOnZFSPermissionDenied(t)
 
@@ -388,6 +417,7 @@ func TestPromote(t *testing.T) {
 		cloneFrom       string
 		cloneOnlyOne    bool   // only clone root element to have misssing intermediate snapshots
 		alreadyPromoted string // pre-promote a dataset and its children
+		asNewMachine    bool   // tag the clone as a machine cloned from another one
 
 		wantErr bool
 		isNoOp  bool
@@ -396,9 +426,10 @@ func TestPromote(t *testing.T) {
 		"Promote missing some leaf snapshots":           {def: "layout1_missing_leaf_snapshot.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1"},
 		"Promote with snapshots and ancestor snapshots": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r2"},
 
-		"Promote already promoted hierarchy":  {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_1234", isNoOp: true},
-		"Root of hierarchy already promoted":  {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1", alreadyPromoted: "rpool/ROOT/ubuntu_5678"},
-		"Child of hierarchy already promoted": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1", alreadyPromoted: "rpool/ROOT/ubuntu_5678/var"},
+		"Promote already promoted hierarchy":      {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_1234", isNoOp: true},
+		"Root of hierarchy already promoted":      {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1", alreadyPromoted: "rpool/ROOT/ubuntu_5678"},
+		"Child of hierarchy already promoted":     {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1", alreadyPromoted: "rpool/ROOT/ubuntu_5678/var"},
+		"Clone of another machine isn't promoted": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_5678", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1", asNewMachine: true, isNoOp: true},
 
 		"Dataset doesn't exists":                            {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_doesntexist", wantErr: true, isNoOp: true},
 		"Promote a snapshot fails":                          {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_1234@snap_r1", wantErr: true, isNoOp: true},
@@ -429,6 +460,16 @@ func TestPromote(t *testing.T) {
 					t.Fatalf("couldn't setup testbed when cloning: %v", err)
 				}
 			}
+			if tc.asNewMachine {
+				for _, d := range z.Datasets() {
+					if d.IsSnapshot || !strings.HasPrefix(d.Name, "rpool/ROOT/ubuntu_5678") {
+						continue
+					}
+					if err := trans.SetProperty(libzfs.ClonedFromProp, tc.cloneFrom, d.Name, true); err != nil {
+						t.Fatalf("couldn't setup testbed when tagging %q: %v", d.Name, err)
+					}
+				}
+			}
 			if tc.alreadyPromoted != "" {
 				err := trans.Promote(tc.alreadyPromoted)
 				if err != nil {
@@ -615,6 +656,295 @@ func TestDestroy(t *testing.T) {
 	}
 }
 
+func TestSendReceive(t *testing.T) {
+	failOnZFSPermissionDenied(t)
+
+	tests := map[string]struct {
+		def     string
+		send    string
+		from    string
+		receive string
+		props   map[string]string
+
+		// send and receive incrementally a second snapshot once the first one is received
+		thenSend string
+		// thenFrom is the base of the incremental stream. Default to send.
+		thenFrom string
+
+		wantErrSend    bool
+		wantErrReceive bool
+	}{
+		"Full stream":                 {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/ROOT/ubuntu_9999"},
+		"Full stream with properties": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/ROOT/ubuntu_9999", props: map[string]string{"canmount": "noauto", "com.ubuntu.zsys:bootfs": "no"}},
+		"Full then incremental":       {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/ROOT/ubuntu_9999", thenSend: "rpool/ROOT/ubuntu_1234@snap_r2"},
+		"Full then incremental from a bookmark": {def: "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/ROOT/ubuntu_9999",
+			thenSend: "rpool/ROOT/ubuntu_1234@snap_r2", thenFrom: "rpool/ROOT/ubuntu_1234#snap_r1"},
+
+		"Error on sending a filesystem":                       {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234", wantErrSend: true},
+		"Error on sending a non existing snapshot":            {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@doesntexist", wantErrSend: true},
+		"Error on sending from a snapshot of another dataset": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r2", from: "rpool/ROOT/ubuntu_1234/var@snap_r1", wantErrSend: true},
+		"Error on full stream to existing filesystem":         {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/ROOT/ubuntu_1234", wantErrReceive: true},
+		"Error on incremental stream without base":            {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r2", from: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/ROOT/ubuntu_9999", wantErrReceive: true},
+		"Error on missing parent":                             {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", send: "rpool/ROOT/ubuntu_1234@snap_r1", receive: "rpool/doesntexist/ubuntu_9999", wantErrReceive: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			ta := timeAsserter(time.Now())
+			adapter := testutils.GetLibZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithWaitBetweenSnapshots(), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			initState := copyState(z)
+
+			var stream bytes.Buffer
+			err = z.Send(context.Background(), tc.send, tc.from, &stream)
+			if err != nil && !tc.wantErrSend {
+				t.Fatalf("expected no error on send but got: %v", err)
+			} else if err == nil && tc.wantErrSend {
+				t.Fatal("expected an error on send but got none")
+			}
+			if tc.wantErrSend {
+				assertDatasetsEquals(t, ta, initState, z.Datasets())
+				return
+			}
+
+			nt := z.NewNoTransaction(context.Background())
+			err = nt.Receive(tc.receive, &stream, tc.props)
+			if err != nil && !tc.wantErrReceive {
+				t.Fatalf("expected no error on receive but got: %v", err)
+			} else if err == nil && tc.wantErrReceive {
+				t.Fatal("expected an error on receive but got none")
+			}
+			if tc.wantErrReceive {
+				assertDatasetsEquals(t, ta, initState, z.Datasets())
+				return
+			}
+
+			if tc.thenSend != "" {
+				stream.Reset()
+				thenFrom := tc.thenFrom
+				if thenFrom == "" {
+					thenFrom = tc.send
+				}
+				if err := z.Send(context.Background(), tc.thenSend, thenFrom, &stream); err != nil {
+					t.Fatalf("expected no error on incremental send but got: %v", err)
+				}
+				if err := nt.Receive(tc.receive, &stream, tc.props); err != nil {
+					t.Fatalf("expected no error on incremental receive but got: %v", err)
+				}
+			}
+
+			assertDatasetsToGolden(t, ta, z.Datasets())
+			assertIdempotentWithNew(t, ta, z.Datasets(), adapter)
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	failOnZFSPermissionDenied(t)
+
+	tests := map[string]struct {
+		def     string
+		name    string
+		newName string
+		cancel  bool
+
+		wantErr bool
+	}{
+		"Rename dataset with children and snapshots": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var", newName: "rpool/var"},
+		"Rename leaf dataset":                        {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var/lib", newName: "rpool/ROOT/lib"},
+		"Rename is reverted on cancel":               {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var", newName: "rpool/var", cancel: true},
+
+		"Error on non existing dataset":     {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/doesntexist", newName: "rpool/var", wantErr: true},
+		"Error on existing target":          {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var", newName: "rpool/ROOT", wantErr: true},
+		"Error on missing target parent":    {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var", newName: "rpool/doesntexist/var", wantErr: true},
+		"Error on renaming a snapshot":      {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var@snap_r1", newName: "rpool/var", wantErr: true},
+		"Error on renaming to another pool": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", name: "rpool/ROOT/ubuntu_1234/var", newName: "rpool2/var", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			ta := timeAsserter(time.Now())
+			adapter := testutils.GetLibZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			initState := copyState(z)
+
+			trans, cancel := z.NewTransaction(context.Background())
+			err = trans.Rename(tc.name, tc.newName)
+			if tc.cancel {
+				cancel()
+			}
+			trans.Done()
+
+			if err != nil && !tc.wantErr {
+				t.Fatalf("expected no error but got: %v", err)
+			} else if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			// check we didn't change anything on error or cancel
+			if err != nil || tc.cancel {
+				assertDatasetsEquals(t, ta, initState, z.Datasets())
+			} else {
+				assertDatasetsToGolden(t, ta, z.Datasets())
+			}
+
+			zfs.AssertNoZFSChildren(t, z)
+			assertIdempotentWithNew(t, ta, z.Datasets(), adapter)
+		})
+	}
+}
+
+func TestBookmark(t *testing.T) {
+	failOnZFSPermissionDenied(t)
+
+	tests := map[string]struct {
+		def      string
+		bookmark string
+		destroy  string
+
+		wantErr bool
+	}{
+		"Bookmark a snapshot":                            {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", bookmark: "rpool/ROOT/ubuntu_1234@snap_r2"},
+		"Bookmark an already bookmarked snapshot":        {def: "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml", bookmark: "rpool/ROOT/ubuntu_1234@snap_r1"},
+		"Scan existing bookmarks":                        {def: "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml"},
+		"Destroy a bookmark":                             {def: "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml", destroy: "rpool/ROOT/ubuntu_1234#snap_r1"},
+		"Destroying a filesystem destroys its bookmarks": {def: "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml", destroy: "rpool/ROOT/ubuntu_1234/opt"},
+
+		"Error on bookmarking a filesystem":            {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", bookmark: "rpool/ROOT/ubuntu_1234", wantErr: true},
+		"Error on bookmarking a non existing snapshot": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", bookmark: "rpool/ROOT/ubuntu_1234@doesntexist", wantErr: true},
+		"Error on destroying a non existing bookmark":  {def: "layout1__one_pool_n_datasets_n_snapshots_with_bookmarks.yaml", destroy: "rpool/ROOT/ubuntu_1234#doesntexist", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			ta := timeAsserter(time.Now())
+			adapter := testutils.GetLibZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithWaitBetweenSnapshots(), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			initState := append(copyState(z), z.Bookmarks()...)
+
+			nt := z.NewNoTransaction(context.Background())
+			if tc.bookmark != "" {
+				err = nt.Bookmark(tc.bookmark)
+			} else if tc.destroy != "" {
+				err = nt.Destroy(tc.destroy)
+			}
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertDatasetsEquals(t, ta, initState, append(z.Datasets(), z.Bookmarks()...))
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			got := append(z.Datasets(), z.Bookmarks()...)
+			assertDatasetsToGolden(t, ta, got)
+
+			newZ, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			assertDatasetsEquals(t, ta, append(newZ.Datasets(), newZ.Bookmarks()...), got)
+		})
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	tests := map[string]struct {
+		action  string
+		dataset string
+		key     zfs.EncryptionKey
+
+		wantErr bool
+	}{
+		"Create encrypted with passphrase":               {action: "create", dataset: "rpool/ROOT/ubuntu_1234/home", key: zfs.EncryptionKey{Format: "passphrase", Location: "prompt", Passphrase: "secret passphrase"}},
+		"Create encrypted with key file":                 {action: "create", dataset: "rpool/ROOT/ubuntu_1234/home", key: zfs.EncryptionKey{Format: "raw", Location: "file:///path/to/key"}},
+		"Create under encryption root inherits it":       {action: "create", dataset: "rpool/USERDATA/user3_ijkl"},
+		"Create new encryption root under encrypted one": {action: "create", dataset: "rpool/USERDATA/user3_ijkl", key: zfs.EncryptionKey{Format: "raw", Location: "file:///path/to/key"}},
+		"Snapshot on dataset with loaded key":            {action: "snapshot", dataset: "rpool/USERDATA/user1_abcd"},
+		"Snapshot on dataset with unloaded key":          {action: "snapshot", dataset: "rpool/USERDATA/user2_efgh"},
+		"Clone keeps origin encryption root":             {action: "clone", dataset: "rpool/USERDATA/user1_abcd@snap1"},
+		"Clone on dataset with unloaded key":             {action: "clone", dataset: "rpool/USERDATA/user2_efgh@snap1"},
+
+		"Error on too short passphrase": {action: "create", dataset: "rpool/ROOT/ubuntu_1234/home", key: zfs.EncryptionKey{Format: "passphrase", Location: "prompt", Passphrase: "short"}, wantErr: true},
+		"Error on too long passphrase":  {action: "create", dataset: "rpool/ROOT/ubuntu_1234/home", key: zfs.EncryptionKey{Format: "passphrase", Location: "prompt", Passphrase: strings.Repeat("a", 513)}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			ta := timeAsserter(time.Now())
+			adapter := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "one_pool_n_encrypted_datasets_n_snapshots.yaml"), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			initState := copyState(z)
+			trans, _ := z.NewTransaction(context.Background())
+			defer trans.Done()
+
+			switch tc.action {
+			case "create":
+				if tc.key.Format == "" {
+					err = trans.Create(tc.dataset, "/home/user3", "on")
+				} else {
+					err = trans.CreateWithProperties(tc.dataset, "/home/user3", "on", nil, &tc.key)
+				}
+			case "snapshot":
+				err = trans.Snapshot("snap2", tc.dataset, false)
+			case "clone":
+				err = trans.Clone(tc.dataset, "5678", false, false)
+			}
+
+			if err != nil && !tc.wantErr {
+				t.Fatalf("expected no error but got: %v", err)
+			} else if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			// check we didn't change anything on error
+			if err != nil {
+				assertDatasetsEquals(t, ta, initState, z.Datasets())
+			} else {
+				assertDatasetsToGolden(t, ta, z.Datasets())
+			}
+
+			zfs.AssertNoZFSChildren(t, z)
+			assertIdempotentWithNew(t, ta, z.Datasets(), adapter)
+		})
+	}
+}
+
 func TestSetProperty(t *testing.T) {
 	failOnZFSPermissionDenied(t)
 
@@ -655,6 +985,13 @@ func TestSetProperty(t *testing.T) {
 		"SnapshotMountpointProp is MountPointProp":                {def: "one_pool_one_dataset_one_snapshot_with_user_properties.yaml", propertyName: libzfs.SnapshotMountpointProp, propertyValue: "/home/a/path", dataset: "rpool@snap1", force: true},
 		"Let set on BootfsDatasetsProp but don't load it (local)": {def: "one_pool_one_dataset_one_snapshot_with_bootfsdatasets.yaml", propertyName: libzfs.BootfsDatasetsProp, propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
 		"Let set on BootfsDatasetsProp but don't load it (none)":  {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.BootfsDatasetsProp, propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
+		"Description on snapshot":                                 {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.DescriptionProp, propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
+		"Label on snapshot":                                       {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.LabelProp, propertyValue: "SetProperty-Value", dataset: "rpool@snap1"},
+		"Pinned on snapshot":                                      {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.PinnedProp, propertyValue: "yes", dataset: "rpool@snap1"},
+		"Pinned is inherited by children":                         {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.PinnedProp, propertyValue: "yes", dataset: "rpool/ROOT/ubuntu"},
+		"Excluded is inherited by children":                       {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.ExcludedProp, propertyValue: "yes", dataset: "rpool/ROOT/ubuntu"},
+		"Follow system is inherited by children":                  {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.FollowSystemProp, propertyValue: "yes", dataset: "rpool/ROOT/ubuntu"},
+		"Description is inherited by children":                    {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.DescriptionProp, propertyValue: "SetProperty Value", dataset: "rpool/ROOT/ubuntu"},
 
 		"LastUsed with children":            {def: "one_pool_one_dataset_one_snapshot_with_user_properties.yaml", propertyName: libzfs.LastUsedProp, propertyValue: "42", dataset: "rpool"},
 		"LastUsed is not a number":          {def: "one_pool_one_dataset_one_snapshot_with_user_properties.yaml", propertyName: libzfs.LastUsedProp, propertyValue: "not a number", dataset: "rpool", wantErr: true, isNoOp: true},
@@ -1238,6 +1575,34 @@ func TestInvalidatedTransactionByCancel(t *testing.T) {
 	assert.Panics(t, trans.CheckValid, "transaction should be invalidated")
 }
 
+func TestTransactionFailsOnParentCancel(t *testing.T) {
+	t.Parallel()
+
+	z, err := zfs.New(context.Background(), zfs.WithLibZFS(testutils.GetMockZFS(t)))
+	if err != nil {
+		t.Fatalf("couldn't create base ZFS object: %v", err)
+	}
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	trans, _ := z.NewTransaction(ctx)
+	var reverted bool
+	trans.RegisterRevert(func() error {
+		reverted = true
+		return nil
+	})
+
+	cancelCtx()
+
+	var errCreate error
+	assert.NotPanics(t, func() { errCreate = trans.Create("rpool", "/", "on") }, "operation on a transaction of a cancelled request shouldn't panic")
+	assert.Error(t, errCreate, "operation on a transaction of a cancelled request should fail")
+	assert.False(t, reverted, "revert shouldn't happen before Done()")
+
+	trans.Done()
+	assert.True(t, reverted, "cancelled request should be reverted on Done()")
+}
+
 // transformToReproducibleDatasetSlice applied transformation to ensure that the comparison is reproducible via
 // DataSlices.
 func transformToReproducibleDatasetSlice(t *testing.T, ta timeAsserter, got []*zfs.Dataset) zfs.DatasetSlice {
@@ -1250,7 +1615,7 @@ func transformToReproducibleDatasetSlice(t *testing.T, ta timeAsserter, got []*z
 	var ds []*zfs.Dataset
 	for i, d := range got {
 		copyDS = append(copyDS, *d)
-		if !d.IsSnapshot {
+		if !d.IsSnapshot && !d.IsBookmark {
 			continue
 		}
 		ds = append(ds, &copyDS[i])
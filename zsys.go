@@ -32,8 +32,10 @@ func NewZsysUnixSocketClient(socket string, level logrus.Level) (*ZsysLogClient,
 }
 
 // RegisterServer registers a ZsysServer after creating the grpc server which it returns.
-func RegisterServer(srv ZsysServerIdleTimeout) *grpc.Server {
-	s := grpc.NewServer(grpc.StreamInterceptor(streamlogger.ServerIdleTimeoutInterceptor), authorizer.WithUnixPeerCreds())
+// opts are appended to the grpc server options, like additional interceptors.
+func RegisterServer(srv ZsysServerIdleTimeout, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.StreamInterceptor(streamlogger.ServerIdleTimeoutInterceptor), authorizer.WithUnixPeerCreds()}, opts...)
+	s := grpc.NewServer(opts...)
 	registerZsysServerIdleWithLogs(s, srv)
 	return s
 }
@@ -9,6 +9,7 @@ package zsys
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -191,8 +192,12 @@ type CreateUserDataRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	User     string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	Homepath string `protobuf:"bytes,2,opt,name=homepath,proto3" json:"homepath,omitempty"`
+	User       string            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Homepath   string            `protobuf:"bytes,2,opt,name=homepath,proto3" json:"homepath,omitempty"`
+	Passphrase string            `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	KeyFile    string            `protobuf:"bytes,4,opt,name=keyFile,proto3" json:"keyFile,omitempty"`
+	Container  string            `protobuf:"bytes,5,opt,name=container,proto3" json:"container,omitempty"`
+	Properties map[string]string `protobuf:"bytes,6,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *CreateUserDataRequest) Reset() {
@@ -241,6 +246,34 @@ func (x *CreateUserDataRequest) GetHomepath() string {
 	return ""
 }
 
+func (x *CreateUserDataRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *CreateUserDataRequest) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *CreateUserDataRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *CreateUserDataRequest) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
 type ChangeHomeOnUserDataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -568,6 +601,8 @@ type SaveSystemStateRequest struct {
 	StateName      string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
 	UpdateBootMenu bool   `protobuf:"varint,2,opt,name=updateBootMenu,proto3" json:"updateBootMenu,omitempty"`
 	Autosave       bool   `protobuf:"varint,3,opt,name=autosave,proto3" json:"autosave,omitempty"`
+	Description    string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Label          string `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
 }
 
 func (x *SaveSystemStateRequest) Reset() {
@@ -623,13 +658,29 @@ func (x *SaveSystemStateRequest) GetAutosave() bool {
 	return false
 }
 
+func (x *SaveSystemStateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SaveSystemStateRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 type SaveUserStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserName  string `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
-	StateName string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UserName    string `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	StateName   string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Label       string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
 }
 
 func (x *SaveUserStateRequest) Reset() {
@@ -678,6 +729,20 @@ func (x *SaveUserStateRequest) GetStateName() string {
 	return ""
 }
 
+func (x *SaveUserStateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SaveUserStateRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 type CreateSaveStateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -893,20 +958,17 @@ func (x *RemoveUserStateRequest) GetDryrun() bool {
 	return false
 }
 
-type DumpStatesResponse struct {
+type RevertSystemStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Reply:
-	//
-	//	*DumpStatesResponse_Log
-	//	*DumpStatesResponse_States
-	Reply isDumpStatesResponse_Reply `protobuf_oneof:"reply"`
+	StateName      string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	RevertUserData bool   `protobuf:"varint,2,opt,name=revertUserData,proto3" json:"revertUserData,omitempty"`
 }
 
-func (x *DumpStatesResponse) Reset() {
-	*x = DumpStatesResponse{}
+func (x *RevertSystemStateRequest) Reset() {
+	*x = RevertSystemStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -914,13 +976,13 @@ func (x *DumpStatesResponse) Reset() {
 	}
 }
 
-func (x *DumpStatesResponse) String() string {
+func (x *RevertSystemStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpStatesResponse) ProtoMessage() {}
+func (*RevertSystemStateRequest) ProtoMessage() {}
 
-func (x *DumpStatesResponse) ProtoReflect() protoreflect.Message {
+func (x *RevertSystemStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -932,58 +994,37 @@ func (x *DumpStatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpStatesResponse.ProtoReflect.Descriptor instead.
-func (*DumpStatesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevertSystemStateRequest.ProtoReflect.Descriptor instead.
+func (*RevertSystemStateRequest) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{14}
 }
 
-func (m *DumpStatesResponse) GetReply() isDumpStatesResponse_Reply {
-	if m != nil {
-		return m.Reply
-	}
-	return nil
-}
-
-func (x *DumpStatesResponse) GetLog() string {
-	if x, ok := x.GetReply().(*DumpStatesResponse_Log); ok {
-		return x.Log
+func (x *RevertSystemStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
 	}
 	return ""
 }
 
-func (x *DumpStatesResponse) GetStates() string {
-	if x, ok := x.GetReply().(*DumpStatesResponse_States); ok {
-		return x.States
+func (x *RevertSystemStateRequest) GetRevertUserData() bool {
+	if x != nil {
+		return x.RevertUserData
 	}
-	return ""
-}
-
-type isDumpStatesResponse_Reply interface {
-	isDumpStatesResponse_Reply()
-}
-
-type DumpStatesResponse_Log struct {
-	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
-}
-
-type DumpStatesResponse_States struct {
-	States string `protobuf:"bytes,2,opt,name=states,proto3,oneof"`
+	return false
 }
 
-func (*DumpStatesResponse_Log) isDumpStatesResponse_Reply() {}
-
-func (*DumpStatesResponse_States) isDumpStatesResponse_Reply() {}
-
-type LoggingLevelRequest struct {
+type AnnotateSystemStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Logginglevel int32 `protobuf:"varint,1,opt,name=logginglevel,proto3" json:"logginglevel,omitempty"`
+	StateName   string  `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Label       *string `protobuf:"bytes,3,opt,name=label,proto3,oneof" json:"label,omitempty"`
 }
 
-func (x *LoggingLevelRequest) Reset() {
-	*x = LoggingLevelRequest{}
+func (x *AnnotateSystemStateRequest) Reset() {
+	*x = AnnotateSystemStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -991,13 +1032,13 @@ func (x *LoggingLevelRequest) Reset() {
 	}
 }
 
-func (x *LoggingLevelRequest) String() string {
+func (x *AnnotateSystemStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoggingLevelRequest) ProtoMessage() {}
+func (*AnnotateSystemStateRequest) ProtoMessage() {}
 
-func (x *LoggingLevelRequest) ProtoReflect() protoreflect.Message {
+func (x *AnnotateSystemStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1009,29 +1050,45 @@ func (x *LoggingLevelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoggingLevelRequest.ProtoReflect.Descriptor instead.
-func (*LoggingLevelRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnnotateSystemStateRequest.ProtoReflect.Descriptor instead.
+func (*AnnotateSystemStateRequest) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *LoggingLevelRequest) GetLogginglevel() int32 {
+func (x *AnnotateSystemStateRequest) GetStateName() string {
 	if x != nil {
-		return x.Logginglevel
+		return x.StateName
 	}
-	return 0
+	return ""
 }
 
-type TraceRequest struct {
+func (x *AnnotateSystemStateRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *AnnotateSystemStateRequest) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+type AnnotateUserStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Duration int32  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	UserName    string  `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	StateName   string  `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Description *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Label       *string `protobuf:"bytes,4,opt,name=label,proto3,oneof" json:"label,omitempty"`
 }
 
-func (x *TraceRequest) Reset() {
-	*x = TraceRequest{}
+func (x *AnnotateUserStateRequest) Reset() {
+	*x = AnnotateUserStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1039,13 +1096,13 @@ func (x *TraceRequest) Reset() {
 	}
 }
 
-func (x *TraceRequest) String() string {
+func (x *AnnotateUserStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TraceRequest) ProtoMessage() {}
+func (*AnnotateUserStateRequest) ProtoMessage() {}
 
-func (x *TraceRequest) ProtoReflect() protoreflect.Message {
+func (x *AnnotateUserStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1057,39 +1114,50 @@ func (x *TraceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TraceRequest.ProtoReflect.Descriptor instead.
-func (*TraceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnnotateUserStateRequest.ProtoReflect.Descriptor instead.
+func (*AnnotateUserStateRequest) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *TraceRequest) GetType() string {
+func (x *AnnotateUserStateRequest) GetUserName() string {
 	if x != nil {
-		return x.Type
+		return x.UserName
 	}
 	return ""
 }
 
-func (x *TraceRequest) GetDuration() int32 {
+func (x *AnnotateUserStateRequest) GetStateName() string {
 	if x != nil {
-		return x.Duration
+		return x.StateName
 	}
-	return 0
+	return ""
 }
 
-type TraceResponse struct {
+func (x *AnnotateUserStateRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *AnnotateUserStateRequest) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+type PinSystemStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Reply:
-	//
-	//	*TraceResponse_Log
-	//	*TraceResponse_Trace
-	Reply isTraceResponse_Reply `protobuf_oneof:"reply"`
+	StateName string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Pin       bool   `protobuf:"varint,2,opt,name=pin,proto3" json:"pin,omitempty"`
 }
 
-func (x *TraceResponse) Reset() {
-	*x = TraceResponse{}
+func (x *PinSystemStateRequest) Reset() {
+	*x = PinSystemStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1097,13 +1165,13 @@ func (x *TraceResponse) Reset() {
 	}
 }
 
-func (x *TraceResponse) String() string {
+func (x *PinSystemStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TraceResponse) ProtoMessage() {}
+func (*PinSystemStateRequest) ProtoMessage() {}
 
-func (x *TraceResponse) ProtoReflect() protoreflect.Message {
+func (x *PinSystemStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1115,58 +1183,37 @@ func (x *TraceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TraceResponse.ProtoReflect.Descriptor instead.
-func (*TraceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PinSystemStateRequest.ProtoReflect.Descriptor instead.
+func (*PinSystemStateRequest) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{17}
 }
 
-func (m *TraceResponse) GetReply() isTraceResponse_Reply {
-	if m != nil {
-		return m.Reply
-	}
-	return nil
-}
-
-func (x *TraceResponse) GetLog() string {
-	if x, ok := x.GetReply().(*TraceResponse_Log); ok {
-		return x.Log
+func (x *PinSystemStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
 	}
 	return ""
 }
 
-func (x *TraceResponse) GetTrace() []byte {
-	if x, ok := x.GetReply().(*TraceResponse_Trace); ok {
-		return x.Trace
+func (x *PinSystemStateRequest) GetPin() bool {
+	if x != nil {
+		return x.Pin
 	}
-	return nil
-}
-
-type isTraceResponse_Reply interface {
-	isTraceResponse_Reply()
-}
-
-type TraceResponse_Log struct {
-	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
-}
-
-type TraceResponse_Trace struct {
-	Trace []byte `protobuf:"bytes,2,opt,name=trace,proto3,oneof"`
+	return false
 }
 
-func (*TraceResponse_Log) isTraceResponse_Reply() {}
-
-func (*TraceResponse_Trace) isTraceResponse_Reply() {}
-
-type GCRequest struct {
+type PinUserStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	All bool `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	UserName  string `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	StateName string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Pin       bool   `protobuf:"varint,3,opt,name=pin,proto3" json:"pin,omitempty"`
 }
 
-func (x *GCRequest) Reset() {
-	*x = GCRequest{}
+func (x *PinUserStateRequest) Reset() {
+	*x = PinUserStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1174,13 +1221,13 @@ func (x *GCRequest) Reset() {
 	}
 }
 
-func (x *GCRequest) String() string {
+func (x *PinUserStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GCRequest) ProtoMessage() {}
+func (*PinUserStateRequest) ProtoMessage() {}
 
-func (x *GCRequest) ProtoReflect() protoreflect.Message {
+func (x *PinUserStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1192,29 +1239,44 @@ func (x *GCRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GCRequest.ProtoReflect.Descriptor instead.
-func (*GCRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PinUserStateRequest.ProtoReflect.Descriptor instead.
+func (*PinUserStateRequest) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GCRequest) GetAll() bool {
+func (x *PinUserStateRequest) GetUserName() string {
 	if x != nil {
-		return x.All
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *PinUserStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *PinUserStateRequest) GetPin() bool {
+	if x != nil {
+		return x.Pin
 	}
 	return false
 }
 
-type MachineShowRequest struct {
+type StateDiffRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MachineId string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
-	Full      bool   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	From     string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To       string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	UserData bool   `protobuf:"varint,3,opt,name=userData,proto3" json:"userData,omitempty"`
 }
 
-func (x *MachineShowRequest) Reset() {
-	*x = MachineShowRequest{}
+func (x *StateDiffRequest) Reset() {
+	*x = StateDiffRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1222,13 +1284,13 @@ func (x *MachineShowRequest) Reset() {
 	}
 }
 
-func (x *MachineShowRequest) String() string {
+func (x *StateDiffRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachineShowRequest) ProtoMessage() {}
+func (*StateDiffRequest) ProtoMessage() {}
 
-func (x *MachineShowRequest) ProtoReflect() protoreflect.Message {
+func (x *StateDiffRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1240,39 +1302,46 @@ func (x *MachineShowRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachineShowRequest.ProtoReflect.Descriptor instead.
-func (*MachineShowRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StateDiffRequest.ProtoReflect.Descriptor instead.
+func (*StateDiffRequest) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *MachineShowRequest) GetMachineId() string {
+func (x *StateDiffRequest) GetFrom() string {
 	if x != nil {
-		return x.MachineId
+		return x.From
 	}
 	return ""
 }
 
-func (x *MachineShowRequest) GetFull() bool {
+func (x *StateDiffRequest) GetTo() string {
 	if x != nil {
-		return x.Full
+		return x.To
+	}
+	return ""
+}
+
+func (x *StateDiffRequest) GetUserData() bool {
+	if x != nil {
+		return x.UserData
 	}
 	return false
 }
 
-type MachineShowResponse struct {
+type StateDiffResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Reply:
 	//
-	//	*MachineShowResponse_Log
-	//	*MachineShowResponse_MachineInfo
-	Reply isMachineShowResponse_Reply `protobuf_oneof:"reply"`
+	//	*StateDiffResponse_Log
+	//	*StateDiffResponse_Change
+	Reply isStateDiffResponse_Reply `protobuf_oneof:"reply"`
 }
 
-func (x *MachineShowResponse) Reset() {
-	*x = MachineShowResponse{}
+func (x *StateDiffResponse) Reset() {
+	*x = StateDiffResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1280,13 +1349,13 @@ func (x *MachineShowResponse) Reset() {
 	}
 }
 
-func (x *MachineShowResponse) String() string {
+func (x *StateDiffResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachineShowResponse) ProtoMessage() {}
+func (*StateDiffResponse) ProtoMessage() {}
 
-func (x *MachineShowResponse) ProtoReflect() protoreflect.Message {
+func (x *StateDiffResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1298,62 +1367,62 @@ func (x *MachineShowResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachineShowResponse.ProtoReflect.Descriptor instead.
-func (*MachineShowResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StateDiffResponse.ProtoReflect.Descriptor instead.
+func (*StateDiffResponse) Descriptor() ([]byte, []int) {
 	return file_zsys_proto_rawDescGZIP(), []int{20}
 }
 
-func (m *MachineShowResponse) GetReply() isMachineShowResponse_Reply {
+func (m *StateDiffResponse) GetReply() isStateDiffResponse_Reply {
 	if m != nil {
 		return m.Reply
 	}
 	return nil
 }
 
-func (x *MachineShowResponse) GetLog() string {
-	if x, ok := x.GetReply().(*MachineShowResponse_Log); ok {
+func (x *StateDiffResponse) GetLog() string {
+	if x, ok := x.GetReply().(*StateDiffResponse_Log); ok {
 		return x.Log
 	}
 	return ""
 }
 
-func (x *MachineShowResponse) GetMachineInfo() string {
-	if x, ok := x.GetReply().(*MachineShowResponse_MachineInfo); ok {
-		return x.MachineInfo
+func (x *StateDiffResponse) GetChange() *FileChange {
+	if x, ok := x.GetReply().(*StateDiffResponse_Change); ok {
+		return x.Change
 	}
-	return ""
+	return nil
 }
 
-type isMachineShowResponse_Reply interface {
-	isMachineShowResponse_Reply()
+type isStateDiffResponse_Reply interface {
+	isStateDiffResponse_Reply()
 }
 
-type MachineShowResponse_Log struct {
+type StateDiffResponse_Log struct {
 	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
 }
 
-type MachineShowResponse_MachineInfo struct {
-	MachineInfo string `protobuf:"bytes,2,opt,name=machineInfo,proto3,oneof"`
+type StateDiffResponse_Change struct {
+	Change *FileChange `protobuf:"bytes,2,opt,name=change,proto3,oneof"`
 }
 
-func (*MachineShowResponse_Log) isMachineShowResponse_Reply() {}
+func (*StateDiffResponse_Log) isStateDiffResponse_Reply() {}
 
-func (*MachineShowResponse_MachineInfo) isMachineShowResponse_Reply() {}
+func (*StateDiffResponse_Change) isStateDiffResponse_Reply() {}
 
-type MachineListResponse struct {
+type FileChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Reply:
-	//
-	//	*MachineListResponse_Log
-	//	*MachineListResponse_MachineList
-	Reply isMachineListResponse_Reply `protobuf_oneof:"reply"`
+	FromDataset string `protobuf:"bytes,1,opt,name=fromDataset,proto3" json:"fromDataset,omitempty"`
+	ToDataset   string `protobuf:"bytes,2,opt,name=toDataset,proto3" json:"toDataset,omitempty"`
+	Change      string `protobuf:"bytes,3,opt,name=change,proto3" json:"change,omitempty"`
+	Path        string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	NewPath     string `protobuf:"bytes,5,opt,name=newPath,proto3" json:"newPath,omitempty"`
 }
 
-func (x *MachineListResponse) Reset() {
-	*x = MachineListResponse{}
+func (x *FileChange) Reset() {
+	*x = FileChange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_zsys_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1361,357 +1430,4146 @@ func (x *MachineListResponse) Reset() {
 	}
 }
 
-func (x *MachineListResponse) String() string {
+func (x *FileChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachineListResponse) ProtoMessage() {}
+func (*FileChange) ProtoMessage() {}
 
-func (x *MachineListResponse) ProtoReflect() protoreflect.Message {
+func (x *FileChange) ProtoReflect() protoreflect.Message {
 	mi := &file_zsys_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MachineListResponse.ProtoReflect.Descriptor instead.
-func (*MachineListResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{21}
-}
-
-func (m *MachineListResponse) GetReply() isMachineListResponse_Reply {
-	if m != nil {
-		return m.Reply
-	}
-	return nil
-}
-
-func (x *MachineListResponse) GetLog() string {
-	if x, ok := x.GetReply().(*MachineListResponse_Log); ok {
-		return x.Log
-	}
-	return ""
-}
-
-func (x *MachineListResponse) GetMachineList() string {
-	if x, ok := x.GetReply().(*MachineListResponse_MachineList); ok {
-		return x.MachineList
-	}
-	return ""
-}
-
-type isMachineListResponse_Reply interface {
-	isMachineListResponse_Reply()
-}
-
-type MachineListResponse_Log struct {
-	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
-}
-
-type MachineListResponse_MachineList struct {
-	MachineList string `protobuf:"bytes,2,opt,name=machineList,proto3,oneof"`
-}
-
-func (*MachineListResponse_Log) isMachineListResponse_Reply() {}
-
-func (*MachineListResponse_MachineList) isMachineListResponse_Reply() {}
-
-var File_zsys_proto protoreflect.FileDescriptor
-
-var file_zsys_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x7a, 0x73,
-	0x79, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0b, 0x4c,
-	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f,
-	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x22, 0x4a, 0x0a, 0x0f,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
-	0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42,
-	0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x47, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x74,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x74,
-	0x68, 0x22, 0x4b, 0x0a, 0x1b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f,
-	0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x6f, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x6f, 0x6d, 0x65, 0x22, 0x4b,
-	0x0a, 0x15, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x22, 0x4e, 0x0a, 0x13, 0x50,
-	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x4d, 0x0a, 0x12, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x2b, 0x0a, 0x15, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x6f, 0x22, 0x7a, 0x0a, 0x16, 0x53, 0x61, 0x76, 0x65, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x26, 0x0a, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e,
-	0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
-	0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73,
-	0x61, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73,
-	0x61, 0x76, 0x65, 0x22, 0x50, 0x0a, 0x14, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x56, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1e, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x66, 0x0a,
-	0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
-	0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f,
-	0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x4b, 0x0a, 0x12, 0x44, 0x75, 0x6d, 0x70,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
-	0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c,
-	0x6f, 0x67, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05,
-	0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x39, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67,
-	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
-	0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c,
-	0x22, 0x3e, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x44, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x42, 0x07, 0x0a,
-	0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1d, 0x0a, 0x09, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x03, 0x61, 0x6c, 0x6c, 0x22, 0x46, 0x0a, 0x12, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
-	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c,
-	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22, 0x56, 0x0a,
-	0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x22, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68,
-	0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x07, 0x0a, 0x05,
-	0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x56, 0x0a, 0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03,
-	0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67,
-	0x12, 0x22, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x32, 0xb2, 0x0a,
-	0x0a, 0x04, 0x5a, 0x73, 0x79, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x14, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x21, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x44,
-	0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1b, 0x2e,
-	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55,
-	0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
-	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x37, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73,
-	0x79, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x42, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e,
-	0x75, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
-	0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x73,
-	0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73, 0x79,
-	0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0d, 0x53, 0x61, 0x76,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x7a, 0x73, 0x79,
-	0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
-	0x01, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x44, 0x75, 0x6d, 0x70, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2e,
-	0x0a, 0x0a, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0b, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e,
-	0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76,
-	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2b,
-	0x0a, 0x07, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x05, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x12, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
-	0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x2a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x06, 0x52,
-	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x02, 0x47, 0x43, 0x12, 0x0f, 0x2e,
-	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68,
-	0x6f, 0x77, 0x12, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x0b, 0x4d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x30, 0x01, 0x42, 0x18, 0x5a, 0x16, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x75, 0x62, 0x75, 0x6e, 0x74, 0x75, 0x2f, 0x7a, 0x73, 0x79, 0x73, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_zsys_proto_rawDescOnce sync.Once
-	file_zsys_proto_rawDescData = file_zsys_proto_rawDesc
-)
-
-func file_zsys_proto_rawDescGZIP() []byte {
-	file_zsys_proto_rawDescOnce.Do(func() {
-		file_zsys_proto_rawDescData = protoimpl.X.CompressGZIP(file_zsys_proto_rawDescData)
-	})
-	return file_zsys_proto_rawDescData
-}
-
-var file_zsys_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
-var file_zsys_proto_goTypes = []interface{}{
-	(*Empty)(nil),                       // 0: zsys.Empty
-	(*LogResponse)(nil),                 // 1: zsys.LogResponse
-	(*VersionResponse)(nil),             // 2: zsys.VersionResponse
-	(*CreateUserDataRequest)(nil),       // 3: zsys.CreateUserDataRequest
-	(*ChangeHomeOnUserDataRequest)(nil), // 4: zsys.ChangeHomeOnUserDataRequest
-	(*DissociateUserRequest)(nil),       // 5: zsys.DissociateUserRequest
-	(*PrepareBootResponse)(nil),         // 6: zsys.PrepareBootResponse
-	(*CommitBootResponse)(nil),          // 7: zsys.CommitBootResponse
-	(*UpdateBootMenuRequest)(nil),       // 8: zsys.UpdateBootMenuRequest
-	(*SaveSystemStateRequest)(nil),      // 9: zsys.SaveSystemStateRequest
-	(*SaveUserStateRequest)(nil),        // 10: zsys.SaveUserStateRequest
-	(*CreateSaveStateResponse)(nil),     // 11: zsys.CreateSaveStateResponse
-	(*RemoveSystemStateRequest)(nil),    // 12: zsys.RemoveSystemStateRequest
-	(*RemoveUserStateRequest)(nil),      // 13: zsys.RemoveUserStateRequest
-	(*DumpStatesResponse)(nil),          // 14: zsys.DumpStatesResponse
-	(*LoggingLevelRequest)(nil),         // 15: zsys.LoggingLevelRequest
-	(*TraceRequest)(nil),                // 16: zsys.TraceRequest
-	(*TraceResponse)(nil),               // 17: zsys.TraceResponse
-	(*GCRequest)(nil),                   // 18: zsys.GCRequest
-	(*MachineShowRequest)(nil),          // 19: zsys.MachineShowRequest
-	(*MachineShowResponse)(nil),         // 20: zsys.MachineShowResponse
-	(*MachineListResponse)(nil),         // 21: zsys.MachineListResponse
-}
-var file_zsys_proto_depIdxs = []int32{
-	0,  // 0: zsys.Zsys.Version:input_type -> zsys.Empty
-	3,  // 1: zsys.Zsys.CreateUserData:input_type -> zsys.CreateUserDataRequest
-	4,  // 2: zsys.Zsys.ChangeHomeOnUserData:input_type -> zsys.ChangeHomeOnUserDataRequest
-	5,  // 3: zsys.Zsys.DissociateUser:input_type -> zsys.DissociateUserRequest
-	0,  // 4: zsys.Zsys.PrepareBoot:input_type -> zsys.Empty
-	0,  // 5: zsys.Zsys.CommitBoot:input_type -> zsys.Empty
-	8,  // 6: zsys.Zsys.UpdateBootMenu:input_type -> zsys.UpdateBootMenuRequest
-	0,  // 7: zsys.Zsys.UpdateLastUsed:input_type -> zsys.Empty
-	9,  // 8: zsys.Zsys.SaveSystemState:input_type -> zsys.SaveSystemStateRequest
-	10, // 9: zsys.Zsys.SaveUserState:input_type -> zsys.SaveUserStateRequest
-	12, // 10: zsys.Zsys.RemoveSystemState:input_type -> zsys.RemoveSystemStateRequest
-	13, // 11: zsys.Zsys.RemoveUserState:input_type -> zsys.RemoveUserStateRequest
-	0,  // 12: zsys.Zsys.DumpStates:input_type -> zsys.Empty
-	0,  // 13: zsys.Zsys.DaemonStop:input_type -> zsys.Empty
-	15, // 14: zsys.Zsys.LoggingLevel:input_type -> zsys.LoggingLevelRequest
-	0,  // 15: zsys.Zsys.Refresh:input_type -> zsys.Empty
-	16, // 16: zsys.Zsys.Trace:input_type -> zsys.TraceRequest
-	0,  // 17: zsys.Zsys.Status:input_type -> zsys.Empty
-	0,  // 18: zsys.Zsys.Reload:input_type -> zsys.Empty
-	18, // 19: zsys.Zsys.GC:input_type -> zsys.GCRequest
-	19, // 20: zsys.Zsys.MachineShow:input_type -> zsys.MachineShowRequest
-	0,  // 21: zsys.Zsys.MachineList:input_type -> zsys.Empty
-	2,  // 22: zsys.Zsys.Version:output_type -> zsys.VersionResponse
-	1,  // 23: zsys.Zsys.CreateUserData:output_type -> zsys.LogResponse
-	1,  // 24: zsys.Zsys.ChangeHomeOnUserData:output_type -> zsys.LogResponse
-	1,  // 25: zsys.Zsys.DissociateUser:output_type -> zsys.LogResponse
-	6,  // 26: zsys.Zsys.PrepareBoot:output_type -> zsys.PrepareBootResponse
-	7,  // 27: zsys.Zsys.CommitBoot:output_type -> zsys.CommitBootResponse
-	1,  // 28: zsys.Zsys.UpdateBootMenu:output_type -> zsys.LogResponse
-	1,  // 29: zsys.Zsys.UpdateLastUsed:output_type -> zsys.LogResponse
-	11, // 30: zsys.Zsys.SaveSystemState:output_type -> zsys.CreateSaveStateResponse
-	11, // 31: zsys.Zsys.SaveUserState:output_type -> zsys.CreateSaveStateResponse
-	1,  // 32: zsys.Zsys.RemoveSystemState:output_type -> zsys.LogResponse
-	1,  // 33: zsys.Zsys.RemoveUserState:output_type -> zsys.LogResponse
-	14, // 34: zsys.Zsys.DumpStates:output_type -> zsys.DumpStatesResponse
-	1,  // 35: zsys.Zsys.DaemonStop:output_type -> zsys.LogResponse
-	1,  // 36: zsys.Zsys.LoggingLevel:output_type -> zsys.LogResponse
-	1,  // 37: zsys.Zsys.Refresh:output_type -> zsys.LogResponse
-	17, // 38: zsys.Zsys.Trace:output_type -> zsys.TraceResponse
-	1,  // 39: zsys.Zsys.Status:output_type -> zsys.LogResponse
-	1,  // 40: zsys.Zsys.Reload:output_type -> zsys.LogResponse
-	1,  // 41: zsys.Zsys.GC:output_type -> zsys.LogResponse
-	20, // 42: zsys.Zsys.MachineShow:output_type -> zsys.MachineShowResponse
-	21, // 43: zsys.Zsys.MachineList:output_type -> zsys.MachineListResponse
-	22, // [22:44] is the sub-list for method output_type
-	0,  // [0:22] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
-}
-
-func init() { file_zsys_proto_init() }
-func file_zsys_proto_init() {
-	if File_zsys_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_zsys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChange.ProtoReflect.Descriptor instead.
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FileChange) GetFromDataset() string {
+	if x != nil {
+		return x.FromDataset
+	}
+	return ""
+}
+
+func (x *FileChange) GetToDataset() string {
+	if x != nil {
+		return x.ToDataset
+	}
+	return ""
+}
+
+func (x *FileChange) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *FileChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChange) GetNewPath() string {
+	if x != nil {
+		return x.NewPath
+	}
+	return ""
+}
+
+type ExportSystemStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StateName       string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	IncrementalFrom string `protobuf:"bytes,2,opt,name=incrementalFrom,proto3" json:"incrementalFrom,omitempty"`
+	UserData        bool   `protobuf:"varint,3,opt,name=userData,proto3" json:"userData,omitempty"`
+}
+
+func (x *ExportSystemStateRequest) Reset() {
+	*x = ExportSystemStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportSystemStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSystemStateRequest) ProtoMessage() {}
+
+func (x *ExportSystemStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSystemStateRequest.ProtoReflect.Descriptor instead.
+func (*ExportSystemStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportSystemStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *ExportSystemStateRequest) GetIncrementalFrom() string {
+	if x != nil {
+		return x.IncrementalFrom
+	}
+	return ""
+}
+
+func (x *ExportSystemStateRequest) GetUserData() bool {
+	if x != nil {
+		return x.UserData
+	}
+	return false
+}
+
+type ExportSystemStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*ExportSystemStateResponse_Log
+	//	*ExportSystemStateResponse_Stream
+	Reply isExportSystemStateResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *ExportSystemStateResponse) Reset() {
+	*x = ExportSystemStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportSystemStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSystemStateResponse) ProtoMessage() {}
+
+func (x *ExportSystemStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSystemStateResponse.ProtoReflect.Descriptor instead.
+func (*ExportSystemStateResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{23}
+}
+
+func (m *ExportSystemStateResponse) GetReply() isExportSystemStateResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *ExportSystemStateResponse) GetLog() string {
+	if x, ok := x.GetReply().(*ExportSystemStateResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *ExportSystemStateResponse) GetStream() []byte {
+	if x, ok := x.GetReply().(*ExportSystemStateResponse_Stream); ok {
+		return x.Stream
+	}
+	return nil
+}
+
+type isExportSystemStateResponse_Reply interface {
+	isExportSystemStateResponse_Reply()
+}
+
+type ExportSystemStateResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type ExportSystemStateResponse_Stream struct {
+	Stream []byte `protobuf:"bytes,2,opt,name=stream,proto3,oneof"`
+}
+
+func (*ExportSystemStateResponse_Log) isExportSystemStateResponse_Reply() {}
+
+func (*ExportSystemStateResponse_Stream) isExportSystemStateResponse_Reply() {}
+
+type ImportSystemStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pool   string `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	Stream []byte `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+}
+
+func (x *ImportSystemStateRequest) Reset() {
+	*x = ImportSystemStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportSystemStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSystemStateRequest) ProtoMessage() {}
+
+func (x *ImportSystemStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSystemStateRequest.ProtoReflect.Descriptor instead.
+func (*ImportSystemStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportSystemStateRequest) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *ImportSystemStateRequest) GetStream() []byte {
+	if x != nil {
+		return x.Stream
+	}
+	return nil
+}
+
+type DumpStatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*DumpStatesResponse_Log
+	//	*DumpStatesResponse_States
+	//	*DumpStatesResponse_Machines
+	Reply isDumpStatesResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *DumpStatesResponse) Reset() {
+	*x = DumpStatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpStatesResponse) ProtoMessage() {}
+
+func (x *DumpStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpStatesResponse.ProtoReflect.Descriptor instead.
+func (*DumpStatesResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{25}
+}
+
+func (m *DumpStatesResponse) GetReply() isDumpStatesResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *DumpStatesResponse) GetLog() string {
+	if x, ok := x.GetReply().(*DumpStatesResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *DumpStatesResponse) GetStates() string {
+	if x, ok := x.GetReply().(*DumpStatesResponse_States); ok {
+		return x.States
+	}
+	return ""
+}
+
+func (x *DumpStatesResponse) GetMachines() *Machines {
+	if x, ok := x.GetReply().(*DumpStatesResponse_Machines); ok {
+		return x.Machines
+	}
+	return nil
+}
+
+type isDumpStatesResponse_Reply interface {
+	isDumpStatesResponse_Reply()
+}
+
+type DumpStatesResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type DumpStatesResponse_States struct {
+	States string `protobuf:"bytes,2,opt,name=states,proto3,oneof"`
+}
+
+type DumpStatesResponse_Machines struct {
+	Machines *Machines `protobuf:"bytes,3,opt,name=machines,proto3,oneof"`
+}
+
+func (*DumpStatesResponse_Log) isDumpStatesResponse_Reply() {}
+
+func (*DumpStatesResponse_States) isDumpStatesResponse_Reply() {}
+
+func (*DumpStatesResponse_Machines) isDumpStatesResponse_Reply() {}
+
+type LoggingLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Logginglevel int32 `protobuf:"varint,1,opt,name=logginglevel,proto3" json:"logginglevel,omitempty"`
+}
+
+func (x *LoggingLevelRequest) Reset() {
+	*x = LoggingLevelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoggingLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggingLevelRequest) ProtoMessage() {}
+
+func (x *LoggingLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggingLevelRequest.ProtoReflect.Descriptor instead.
+func (*LoggingLevelRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LoggingLevelRequest) GetLogginglevel() int32 {
+	if x != nil {
+		return x.Logginglevel
+	}
+	return 0
+}
+
+type TraceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Duration int32  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *TraceRequest) Reset() {
+	*x = TraceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRequest) ProtoMessage() {}
+
+func (x *TraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRequest.ProtoReflect.Descriptor instead.
+func (*TraceRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TraceRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TraceRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type TraceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*TraceResponse_Log
+	//	*TraceResponse_Trace
+	Reply isTraceResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *TraceResponse) Reset() {
+	*x = TraceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceResponse) ProtoMessage() {}
+
+func (x *TraceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceResponse.ProtoReflect.Descriptor instead.
+func (*TraceResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{28}
+}
+
+func (m *TraceResponse) GetReply() isTraceResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *TraceResponse) GetLog() string {
+	if x, ok := x.GetReply().(*TraceResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *TraceResponse) GetTrace() []byte {
+	if x, ok := x.GetReply().(*TraceResponse_Trace); ok {
+		return x.Trace
+	}
+	return nil
+}
+
+type isTraceResponse_Reply interface {
+	isTraceResponse_Reply()
+}
+
+type TraceResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type TraceResponse_Trace struct {
+	Trace []byte `protobuf:"bytes,2,opt,name=trace,proto3,oneof"`
+}
+
+func (*TraceResponse_Log) isTraceResponse_Reply() {}
+
+func (*TraceResponse_Trace) isTraceResponse_Reply() {}
+
+type GCRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	All     bool `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	Dryrun  bool `protobuf:"varint,2,opt,name=dryrun,proto3" json:"dryrun,omitempty"`
+	Explain bool `protobuf:"varint,3,opt,name=explain,proto3" json:"explain,omitempty"`
+}
+
+func (x *GCRequest) Reset() {
+	*x = GCRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCRequest) ProtoMessage() {}
+
+func (x *GCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCRequest.ProtoReflect.Descriptor instead.
+func (*GCRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GCRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *GCRequest) GetDryrun() bool {
+	if x != nil {
+		return x.Dryrun
+	}
+	return false
+}
+
+func (x *GCRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+type MachineShowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
+	Full      bool   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+}
+
+func (x *MachineShowRequest) Reset() {
+	*x = MachineShowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineShowRequest) ProtoMessage() {}
+
+func (x *MachineShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineShowRequest.ProtoReflect.Descriptor instead.
+func (*MachineShowRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MachineShowRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *MachineShowRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+type MachineShowResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*MachineShowResponse_Log
+	//	*MachineShowResponse_Machine
+	Reply isMachineShowResponse_Reply `protobuf_oneof:"reply"`
+	// machineInfo is the preformatted text description of machine, for older clients.
+	MachineInfo string `protobuf:"bytes,2,opt,name=machineInfo,proto3" json:"machineInfo,omitempty"`
+}
+
+func (x *MachineShowResponse) Reset() {
+	*x = MachineShowResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineShowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineShowResponse) ProtoMessage() {}
+
+func (x *MachineShowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineShowResponse.ProtoReflect.Descriptor instead.
+func (*MachineShowResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{31}
+}
+
+func (m *MachineShowResponse) GetReply() isMachineShowResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *MachineShowResponse) GetLog() string {
+	if x, ok := x.GetReply().(*MachineShowResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *MachineShowResponse) GetMachine() *Machine {
+	if x, ok := x.GetReply().(*MachineShowResponse_Machine); ok {
+		return x.Machine
+	}
+	return nil
+}
+
+func (x *MachineShowResponse) GetMachineInfo() string {
+	if x != nil {
+		return x.MachineInfo
+	}
+	return ""
+}
+
+type isMachineShowResponse_Reply interface {
+	isMachineShowResponse_Reply()
+}
+
+type MachineShowResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type MachineShowResponse_Machine struct {
+	Machine *Machine `protobuf:"bytes,3,opt,name=machine,proto3,oneof"`
+}
+
+func (*MachineShowResponse_Log) isMachineShowResponse_Reply() {}
+
+func (*MachineShowResponse_Machine) isMachineShowResponse_Reply() {}
+
+type MachineListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*MachineListResponse_Log
+	//	*MachineListResponse_Machines
+	Reply isMachineListResponse_Reply `protobuf_oneof:"reply"`
+	// machineList is the preformatted text list of machines, for older clients.
+	MachineList string `protobuf:"bytes,2,opt,name=machineList,proto3" json:"machineList,omitempty"`
+}
+
+func (x *MachineListResponse) Reset() {
+	*x = MachineListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineListResponse) ProtoMessage() {}
+
+func (x *MachineListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineListResponse.ProtoReflect.Descriptor instead.
+func (*MachineListResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{32}
+}
+
+func (m *MachineListResponse) GetReply() isMachineListResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *MachineListResponse) GetLog() string {
+	if x, ok := x.GetReply().(*MachineListResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *MachineListResponse) GetMachines() *Machines {
+	if x, ok := x.GetReply().(*MachineListResponse_Machines); ok {
+		return x.Machines
+	}
+	return nil
+}
+
+func (x *MachineListResponse) GetMachineList() string {
+	if x != nil {
+		return x.MachineList
+	}
+	return ""
+}
+
+type isMachineListResponse_Reply interface {
+	isMachineListResponse_Reply()
+}
+
+type MachineListResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type MachineListResponse_Machines struct {
+	Machines *Machines `protobuf:"bytes,3,opt,name=machines,proto3,oneof"`
+}
+
+func (*MachineListResponse_Log) isMachineListResponse_Reply() {}
+
+func (*MachineListResponse_Machines) isMachineListResponse_Reply() {}
+
+type Machines struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Machines []*Machine `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"`
+}
+
+func (x *Machines) Reset() {
+	*x = Machines{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Machines) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Machines) ProtoMessage() {}
+
+func (x *Machines) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Machines.ProtoReflect.Descriptor instead.
+func (*Machines) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Machines) GetMachines() []*Machine {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+type MachineCloneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StateName    string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	WithUserData bool   `protobuf:"varint,2,opt,name=withUserData,proto3" json:"withUserData,omitempty"`
+}
+
+func (x *MachineCloneRequest) Reset() {
+	*x = MachineCloneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineCloneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineCloneRequest) ProtoMessage() {}
+
+func (x *MachineCloneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineCloneRequest.ProtoReflect.Descriptor instead.
+func (*MachineCloneRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MachineCloneRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *MachineCloneRequest) GetWithUserData() bool {
+	if x != nil {
+		return x.WithUserData
+	}
+	return false
+}
+
+type MachineCloneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*MachineCloneResponse_Log
+	//	*MachineCloneResponse_MachineId
+	Reply isMachineCloneResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *MachineCloneResponse) Reset() {
+	*x = MachineCloneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineCloneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineCloneResponse) ProtoMessage() {}
+
+func (x *MachineCloneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineCloneResponse.ProtoReflect.Descriptor instead.
+func (*MachineCloneResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{35}
+}
+
+func (m *MachineCloneResponse) GetReply() isMachineCloneResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *MachineCloneResponse) GetLog() string {
+	if x, ok := x.GetReply().(*MachineCloneResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *MachineCloneResponse) GetMachineId() string {
+	if x, ok := x.GetReply().(*MachineCloneResponse_MachineId); ok {
+		return x.MachineId
+	}
+	return ""
+}
+
+type isMachineCloneResponse_Reply interface {
+	isMachineCloneResponse_Reply()
+}
+
+type MachineCloneResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type MachineCloneResponse_MachineId struct {
+	MachineId string `protobuf:"bytes,2,opt,name=machineId,proto3,oneof"`
+}
+
+func (*MachineCloneResponse_Log) isMachineCloneResponse_Reply() {}
+
+func (*MachineCloneResponse_MachineId) isMachineCloneResponse_Reply() {}
+
+type MachineRemoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
+	Force     bool   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	Dryrun    bool   `protobuf:"varint,3,opt,name=dryrun,proto3" json:"dryrun,omitempty"`
+}
+
+func (x *MachineRemoveRequest) Reset() {
+	*x = MachineRemoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineRemoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineRemoveRequest) ProtoMessage() {}
+
+func (x *MachineRemoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineRemoveRequest.ProtoReflect.Descriptor instead.
+func (*MachineRemoveRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *MachineRemoveRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *MachineRemoveRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *MachineRemoveRequest) GetDryrun() bool {
+	if x != nil {
+		return x.Dryrun
+	}
+	return false
+}
+
+type DatasetExcludeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *DatasetExcludeRequest) Reset() {
+	*x = DatasetExcludeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetExcludeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetExcludeRequest) ProtoMessage() {}
+
+func (x *DatasetExcludeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetExcludeRequest.ProtoReflect.Descriptor instead.
+func (*DatasetExcludeRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DatasetExcludeRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type DatasetExcludeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*DatasetExcludeResponse_Log
+	//	*DatasetExcludeResponse_Dataset
+	Reply isDatasetExcludeResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *DatasetExcludeResponse) Reset() {
+	*x = DatasetExcludeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetExcludeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetExcludeResponse) ProtoMessage() {}
+
+func (x *DatasetExcludeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetExcludeResponse.ProtoReflect.Descriptor instead.
+func (*DatasetExcludeResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{38}
+}
+
+func (m *DatasetExcludeResponse) GetReply() isDatasetExcludeResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *DatasetExcludeResponse) GetLog() string {
+	if x, ok := x.GetReply().(*DatasetExcludeResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *DatasetExcludeResponse) GetDataset() string {
+	if x, ok := x.GetReply().(*DatasetExcludeResponse_Dataset); ok {
+		return x.Dataset
+	}
+	return ""
+}
+
+type isDatasetExcludeResponse_Reply interface {
+	isDatasetExcludeResponse_Reply()
+}
+
+type DatasetExcludeResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type DatasetExcludeResponse_Dataset struct {
+	Dataset string `protobuf:"bytes,2,opt,name=dataset,proto3,oneof"`
+}
+
+func (*DatasetExcludeResponse_Log) isDatasetExcludeResponse_Reply() {}
+
+func (*DatasetExcludeResponse_Dataset) isDatasetExcludeResponse_Reply() {}
+
+type DatasetListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*DatasetListResponse_Log
+	//	*DatasetListResponse_Datasets
+	Reply isDatasetListResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *DatasetListResponse) Reset() {
+	*x = DatasetListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetListResponse) ProtoMessage() {}
+
+func (x *DatasetListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetListResponse.ProtoReflect.Descriptor instead.
+func (*DatasetListResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{39}
+}
+
+func (m *DatasetListResponse) GetReply() isDatasetListResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *DatasetListResponse) GetLog() string {
+	if x, ok := x.GetReply().(*DatasetListResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *DatasetListResponse) GetDatasets() *Datasets {
+	if x, ok := x.GetReply().(*DatasetListResponse_Datasets); ok {
+		return x.Datasets
+	}
+	return nil
+}
+
+type isDatasetListResponse_Reply interface {
+	isDatasetListResponse_Reply()
+}
+
+type DatasetListResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type DatasetListResponse_Datasets struct {
+	Datasets *Datasets `protobuf:"bytes,2,opt,name=datasets,proto3,oneof"`
+}
+
+func (*DatasetListResponse_Log) isDatasetListResponse_Reply() {}
+
+func (*DatasetListResponse_Datasets) isDatasetListResponse_Reply() {}
+
+type Datasets struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Datasets []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
+}
+
+func (x *Datasets) Reset() {
+	*x = Datasets{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Datasets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Datasets) ProtoMessage() {}
+
+func (x *Datasets) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Datasets.ProtoReflect.Descriptor instead.
+func (*Datasets) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Datasets) GetDatasets() []*Dataset {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+type DatasetPersistentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path         string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FollowSystem bool   `protobuf:"varint,2,opt,name=followSystem,proto3" json:"followSystem,omitempty"`
+}
+
+func (x *DatasetPersistentRequest) Reset() {
+	*x = DatasetPersistentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetPersistentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetPersistentRequest) ProtoMessage() {}
+
+func (x *DatasetPersistentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetPersistentRequest.ProtoReflect.Descriptor instead.
+func (*DatasetPersistentRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DatasetPersistentRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DatasetPersistentRequest) GetFollowSystem() bool {
+	if x != nil {
+		return x.FollowSystem
+	}
+	return false
+}
+
+type DatasetPersistentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*DatasetPersistentResponse_Log
+	//	*DatasetPersistentResponse_Dataset
+	Reply isDatasetPersistentResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *DatasetPersistentResponse) Reset() {
+	*x = DatasetPersistentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetPersistentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetPersistentResponse) ProtoMessage() {}
+
+func (x *DatasetPersistentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetPersistentResponse.ProtoReflect.Descriptor instead.
+func (*DatasetPersistentResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{42}
+}
+
+func (m *DatasetPersistentResponse) GetReply() isDatasetPersistentResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *DatasetPersistentResponse) GetLog() string {
+	if x, ok := x.GetReply().(*DatasetPersistentResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *DatasetPersistentResponse) GetDataset() string {
+	if x, ok := x.GetReply().(*DatasetPersistentResponse_Dataset); ok {
+		return x.Dataset
+	}
+	return ""
+}
+
+type isDatasetPersistentResponse_Reply interface {
+	isDatasetPersistentResponse_Reply()
+}
+
+type DatasetPersistentResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type DatasetPersistentResponse_Dataset struct {
+	Dataset string `protobuf:"bytes,2,opt,name=dataset,proto3,oneof"`
+}
+
+func (*DatasetPersistentResponse_Log) isDatasetPersistentResponse_Reply() {}
+
+func (*DatasetPersistentResponse_Dataset) isDatasetPersistentResponse_Reply() {}
+
+type WatchEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*WatchEventsResponse_Log
+	//	*WatchEventsResponse_Event
+	Reply isWatchEventsResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *WatchEventsResponse) Reset() {
+	*x = WatchEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsResponse) ProtoMessage() {}
+
+func (x *WatchEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsResponse.ProtoReflect.Descriptor instead.
+func (*WatchEventsResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{43}
+}
+
+func (m *WatchEventsResponse) GetReply() isWatchEventsResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetLog() string {
+	if x, ok := x.GetReply().(*WatchEventsResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *WatchEventsResponse) GetEvent() *Event {
+	if x, ok := x.GetReply().(*WatchEventsResponse_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+type isWatchEventsResponse_Reply interface {
+	isWatchEventsResponse_Reply()
+}
+
+type WatchEventsResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type WatchEventsResponse_Event struct {
+	Event *Event `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+func (*WatchEventsResponse_Log) isWatchEventsResponse_Reply() {}
+
+func (*WatchEventsResponse_Event) isWatchEventsResponse_Reply() {}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are assignable to Event:
+	//
+	//	*Event_StateSaved
+	//	*Event_StateRemoved
+	//	*Event_GcStarted
+	//	*Event_GcFinished
+	//	*Event_BootCommitted
+	//	*Event_UserDataCreated
+	//	*Event_UserDataDissociated
+	//	*Event_RefreshCompleted
+	//	*Event_LowSpace
+	Event isEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (m *Event) GetEvent() isEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *Event) GetStateSaved() *StateEvent {
+	if x, ok := x.GetEvent().(*Event_StateSaved); ok {
+		return x.StateSaved
+	}
+	return nil
+}
+
+func (x *Event) GetStateRemoved() *StateEvent {
+	if x, ok := x.GetEvent().(*Event_StateRemoved); ok {
+		return x.StateRemoved
+	}
+	return nil
+}
+
+func (x *Event) GetGcStarted() *GCEvent {
+	if x, ok := x.GetEvent().(*Event_GcStarted); ok {
+		return x.GcStarted
+	}
+	return nil
+}
+
+func (x *Event) GetGcFinished() *GCEvent {
+	if x, ok := x.GetEvent().(*Event_GcFinished); ok {
+		return x.GcFinished
+	}
+	return nil
+}
+
+func (x *Event) GetBootCommitted() *BootCommittedEvent {
+	if x, ok := x.GetEvent().(*Event_BootCommitted); ok {
+		return x.BootCommitted
+	}
+	return nil
+}
+
+func (x *Event) GetUserDataCreated() *UserDataEvent {
+	if x, ok := x.GetEvent().(*Event_UserDataCreated); ok {
+		return x.UserDataCreated
+	}
+	return nil
+}
+
+func (x *Event) GetUserDataDissociated() *UserDataEvent {
+	if x, ok := x.GetEvent().(*Event_UserDataDissociated); ok {
+		return x.UserDataDissociated
+	}
+	return nil
+}
+
+func (x *Event) GetRefreshCompleted() *Empty {
+	if x, ok := x.GetEvent().(*Event_RefreshCompleted); ok {
+		return x.RefreshCompleted
+	}
+	return nil
+}
+
+func (x *Event) GetLowSpace() *LowSpaceEvent {
+	if x, ok := x.GetEvent().(*Event_LowSpace); ok {
+		return x.LowSpace
+	}
+	return nil
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_StateSaved struct {
+	StateSaved *StateEvent `protobuf:"bytes,2,opt,name=stateSaved,proto3,oneof"`
+}
+
+type Event_StateRemoved struct {
+	StateRemoved *StateEvent `protobuf:"bytes,3,opt,name=stateRemoved,proto3,oneof"`
+}
+
+type Event_GcStarted struct {
+	GcStarted *GCEvent `protobuf:"bytes,4,opt,name=gcStarted,proto3,oneof"`
+}
+
+type Event_GcFinished struct {
+	GcFinished *GCEvent `protobuf:"bytes,5,opt,name=gcFinished,proto3,oneof"`
+}
+
+type Event_BootCommitted struct {
+	BootCommitted *BootCommittedEvent `protobuf:"bytes,6,opt,name=bootCommitted,proto3,oneof"`
+}
+
+type Event_UserDataCreated struct {
+	UserDataCreated *UserDataEvent `protobuf:"bytes,7,opt,name=userDataCreated,proto3,oneof"`
+}
+
+type Event_UserDataDissociated struct {
+	UserDataDissociated *UserDataEvent `protobuf:"bytes,8,opt,name=userDataDissociated,proto3,oneof"`
+}
+
+type Event_RefreshCompleted struct {
+	RefreshCompleted *Empty `protobuf:"bytes,9,opt,name=refreshCompleted,proto3,oneof"`
+}
+
+type Event_LowSpace struct {
+	LowSpace *LowSpaceEvent `protobuf:"bytes,10,opt,name=lowSpace,proto3,oneof"`
+}
+
+func (*Event_StateSaved) isEvent_Event() {}
+
+func (*Event_StateRemoved) isEvent_Event() {}
+
+func (*Event_GcStarted) isEvent_Event() {}
+
+func (*Event_GcFinished) isEvent_Event() {}
+
+func (*Event_BootCommitted) isEvent_Event() {}
+
+func (*Event_UserDataCreated) isEvent_Event() {}
+
+func (*Event_UserDataDissociated) isEvent_Event() {}
+
+func (*Event_RefreshCompleted) isEvent_Event() {}
+
+func (*Event_LowSpace) isEvent_Event() {}
+
+type StateEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StateName string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	User      string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+}
+
+func (x *StateEvent) Reset() {
+	*x = StateEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateEvent) ProtoMessage() {}
+
+func (x *StateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateEvent.ProtoReflect.Descriptor instead.
+func (*StateEvent) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *StateEvent) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *StateEvent) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type GCEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SystemStates  uint32 `protobuf:"varint,1,opt,name=systemStates,proto3" json:"systemStates,omitempty"`
+	UserStates    uint32 `protobuf:"varint,2,opt,name=userStates,proto3" json:"userStates,omitempty"`
+	RemovedStates uint32 `protobuf:"varint,3,opt,name=removedStates,proto3" json:"removedStates,omitempty"`
+}
+
+func (x *GCEvent) Reset() {
+	*x = GCEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCEvent) ProtoMessage() {}
+
+func (x *GCEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCEvent.ProtoReflect.Descriptor instead.
+func (*GCEvent) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GCEvent) GetSystemStates() uint32 {
+	if x != nil {
+		return x.SystemStates
+	}
+	return 0
+}
+
+func (x *GCEvent) GetUserStates() uint32 {
+	if x != nil {
+		return x.UserStates
+	}
+	return 0
+}
+
+func (x *GCEvent) GetRemovedStates() uint32 {
+	if x != nil {
+		return x.RemovedStates
+	}
+	return 0
+}
+
+type BootCommittedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
+	Changed   bool   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+}
+
+func (x *BootCommittedEvent) Reset() {
+	*x = BootCommittedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootCommittedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootCommittedEvent) ProtoMessage() {}
+
+func (x *BootCommittedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootCommittedEvent.ProtoReflect.Descriptor instead.
+func (*BootCommittedEvent) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BootCommittedEvent) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *BootCommittedEvent) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type UserDataEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Home string `protobuf:"bytes,2,opt,name=home,proto3" json:"home,omitempty"`
+}
+
+func (x *UserDataEvent) Reset() {
+	*x = UserDataEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserDataEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataEvent) ProtoMessage() {}
+
+func (x *UserDataEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDataEvent.ProtoReflect.Descriptor instead.
+func (*UserDataEvent) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *UserDataEvent) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *UserDataEvent) GetHome() string {
+	if x != nil {
+		return x.Home
+	}
+	return ""
+}
+
+type LowSpaceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pool string `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	Free uint32 `protobuf:"varint,2,opt,name=free,proto3" json:"free,omitempty"`
+	Min  uint32 `protobuf:"varint,3,opt,name=min,proto3" json:"min,omitempty"`
+}
+
+func (x *LowSpaceEvent) Reset() {
+	*x = LowSpaceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LowSpaceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LowSpaceEvent) ProtoMessage() {}
+
+func (x *LowSpaceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LowSpaceEvent.ProtoReflect.Descriptor instead.
+func (*LowSpaceEvent) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *LowSpaceEvent) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *LowSpaceEvent) GetFree() uint32 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+func (x *LowSpaceEvent) GetMin() uint32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Since     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Operation string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Match     string                 `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	Limit     uint32                 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *HistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *HistoryRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type HistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*HistoryResponse_Log
+	//	*HistoryResponse_Entry
+	Reply isHistoryResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *HistoryResponse) Reset() {
+	*x = HistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryResponse) ProtoMessage() {}
+
+func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryResponse.ProtoReflect.Descriptor instead.
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{51}
+}
+
+func (m *HistoryResponse) GetReply() isHistoryResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *HistoryResponse) GetLog() string {
+	if x, ok := x.GetReply().(*HistoryResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *HistoryResponse) GetEntry() *JournalEntry {
+	if x, ok := x.GetReply().(*HistoryResponse_Entry); ok {
+		return x.Entry
+	}
+	return nil
+}
+
+type isHistoryResponse_Reply interface {
+	isHistoryResponse_Reply()
+}
+
+type HistoryResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type HistoryResponse_Entry struct {
+	Entry *JournalEntry `protobuf:"bytes,2,opt,name=entry,proto3,oneof"`
+}
+
+func (*HistoryResponse_Log) isHistoryResponse_Reply() {}
+
+func (*HistoryResponse_Entry) isHistoryResponse_Reply() {}
+
+type JournalEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	RequestId  string                 `protobuf:"bytes,2,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	Uid        uint32                 `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Pid        int32                  `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	Operation  string                 `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	Parameters map[string]string      `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Error      string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Datasets   []string               `protobuf:"bytes,8,rep,name=datasets,proto3" json:"datasets,omitempty"`
+}
+
+func (x *JournalEntry) Reset() {
+	*x = JournalEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JournalEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalEntry) ProtoMessage() {}
+
+func (x *JournalEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalEntry.ProtoReflect.Descriptor instead.
+func (*JournalEntry) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *JournalEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *JournalEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetDatasets() []string {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+type Machine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                     string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsZsys                 bool         `protobuf:"varint,2,opt,name=isZsys,proto3" json:"isZsys,omitempty"`
+	IsCurrent              bool         `protobuf:"varint,3,opt,name=isCurrent,proto3" json:"isCurrent,omitempty"`
+	State                  *State       `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	History                []*State     `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	UserHistory            []*UserState `protobuf:"bytes,6,rep,name=userHistory,proto3" json:"userHistory,omitempty"`
+	PersistentDatasets     []*Dataset   `protobuf:"bytes,7,rep,name=persistentDatasets,proto3" json:"persistentDatasets,omitempty"`
+	NextBoot               string       `protobuf:"bytes,8,opt,name=nextBoot,proto3" json:"nextBoot,omitempty"`
+	NextBootRevertUserData bool         `protobuf:"varint,9,opt,name=nextBootRevertUserData,proto3" json:"nextBootRevertUserData,omitempty"`
+	Bookmarks              []*Dataset   `protobuf:"bytes,10,rep,name=bookmarks,proto3" json:"bookmarks,omitempty"`
+}
+
+func (x *Machine) Reset() {
+	*x = Machine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Machine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Machine) ProtoMessage() {}
+
+func (x *Machine) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Machine.ProtoReflect.Descriptor instead.
+func (*Machine) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Machine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Machine) GetIsZsys() bool {
+	if x != nil {
+		return x.IsZsys
+	}
+	return false
+}
+
+func (x *Machine) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+func (x *Machine) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Machine) GetHistory() []*State {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Machine) GetUserHistory() []*UserState {
+	if x != nil {
+		return x.UserHistory
+	}
+	return nil
+}
+
+func (x *Machine) GetPersistentDatasets() []*Dataset {
+	if x != nil {
+		return x.PersistentDatasets
+	}
+	return nil
+}
+
+func (x *Machine) GetNextBoot() string {
+	if x != nil {
+		return x.NextBoot
+	}
+	return ""
+}
+
+func (x *Machine) GetNextBootRevertUserData() bool {
+	if x != nil {
+		return x.NextBootRevertUserData
+	}
+	return false
+}
+
+func (x *Machine) GetBookmarks() []*Dataset {
+	if x != nil {
+		return x.Bookmarks
+	}
+	return nil
+}
+
+type State struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LastUsed         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	LastBootedKernel string                 `protobuf:"bytes,3,opt,name=lastBootedKernel,proto3" json:"lastBootedKernel,omitempty"`
+	SystemDatasets   []*Dataset             `protobuf:"bytes,4,rep,name=systemDatasets,proto3" json:"systemDatasets,omitempty"`
+	Users            []*UserState           `protobuf:"bytes,5,rep,name=users,proto3" json:"users,omitempty"`
+	Description      string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Label            string                 `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	Pinned           bool                   `protobuf:"varint,8,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Used             uint64                 `protobuf:"varint,9,opt,name=used,proto3" json:"used,omitempty"`
+	Referenced       uint64                 `protobuf:"varint,10,opt,name=referenced,proto3" json:"referenced,omitempty"`
+	Written          uint64                 `protobuf:"varint,11,opt,name=written,proto3" json:"written,omitempty"`
+	Encrypted        bool                   `protobuf:"varint,12,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	Locked           bool                   `protobuf:"varint,13,opt,name=locked,proto3" json:"locked,omitempty"`
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *State) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *State) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+func (x *State) GetLastBootedKernel() string {
+	if x != nil {
+		return x.LastBootedKernel
+	}
+	return ""
+}
+
+func (x *State) GetSystemDatasets() []*Dataset {
+	if x != nil {
+		return x.SystemDatasets
+	}
+	return nil
+}
+
+func (x *State) GetUsers() []*UserState {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *State) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *State) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *State) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *State) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *State) GetReferenced() uint64 {
+	if x != nil {
+		return x.Referenced
+	}
+	return 0
+}
+
+func (x *State) GetWritten() uint64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *State) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *State) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+type UserState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User        string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Id          string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	LastUsed    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	Datasets    []*Dataset             `protobuf:"bytes,4,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Label       string                 `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	Pinned      bool                   `protobuf:"varint,7,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Used        uint64                 `protobuf:"varint,8,opt,name=used,proto3" json:"used,omitempty"`
+	Referenced  uint64                 `protobuf:"varint,9,opt,name=referenced,proto3" json:"referenced,omitempty"`
+	Written     uint64                 `protobuf:"varint,10,opt,name=written,proto3" json:"written,omitempty"`
+	Encrypted   bool                   `protobuf:"varint,11,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	Locked      bool                   `protobuf:"varint,12,opt,name=locked,proto3" json:"locked,omitempty"`
+}
+
+func (x *UserState) Reset() {
+	*x = UserState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserState) ProtoMessage() {}
+
+func (x *UserState) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserState.ProtoReflect.Descriptor instead.
+func (*UserState) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UserState) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *UserState) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserState) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+func (x *UserState) GetDatasets() []*Dataset {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+func (x *UserState) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UserState) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *UserState) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *UserState) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *UserState) GetReferenced() uint64 {
+	if x != nil {
+		return x.Referenced
+	}
+	return 0
+}
+
+func (x *UserState) GetWritten() uint64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *UserState) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *UserState) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+type Dataset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsSnapshot       bool                   `protobuf:"varint,2,opt,name=isSnapshot,proto3" json:"isSnapshot,omitempty"`
+	Mountpoint       string                 `protobuf:"bytes,3,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	CanMount         string                 `protobuf:"bytes,4,opt,name=canMount,proto3" json:"canMount,omitempty"`
+	Mounted          bool                   `protobuf:"varint,5,opt,name=mounted,proto3" json:"mounted,omitempty"`
+	BootFS           bool                   `protobuf:"varint,6,opt,name=bootFS,proto3" json:"bootFS,omitempty"`
+	LastUsed         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	LastBootedKernel string                 `protobuf:"bytes,8,opt,name=lastBootedKernel,proto3" json:"lastBootedKernel,omitempty"`
+	BootfsDatasets   []string               `protobuf:"bytes,9,rep,name=bootfsDatasets,proto3" json:"bootfsDatasets,omitempty"`
+	Origin           string                 `protobuf:"bytes,10,opt,name=origin,proto3" json:"origin,omitempty"`
+	Used             uint64                 `protobuf:"varint,11,opt,name=used,proto3" json:"used,omitempty"`
+	Referenced       uint64                 `protobuf:"varint,12,opt,name=referenced,proto3" json:"referenced,omitempty"`
+	Written          uint64                 `protobuf:"varint,13,opt,name=written,proto3" json:"written,omitempty"`
+	IsBookmark       bool                   `protobuf:"varint,14,opt,name=isBookmark,proto3" json:"isBookmark,omitempty"`
+	EncryptionRoot   string                 `protobuf:"bytes,15,opt,name=encryptionRoot,proto3" json:"encryptionRoot,omitempty"`
+	KeyStatus        string                 `protobuf:"bytes,16,opt,name=keyStatus,proto3" json:"keyStatus,omitempty"`
+	Excluded         bool                   `protobuf:"varint,17,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	FollowSystem     bool                   `protobuf:"varint,18,opt,name=followSystem,proto3" json:"followSystem,omitempty"`
+}
+
+func (x *Dataset) Reset() {
+	*x = Dataset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dataset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dataset) ProtoMessage() {}
+
+func (x *Dataset) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dataset.ProtoReflect.Descriptor instead.
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Dataset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dataset) GetIsSnapshot() bool {
+	if x != nil {
+		return x.IsSnapshot
+	}
+	return false
+}
+
+func (x *Dataset) GetMountpoint() string {
+	if x != nil {
+		return x.Mountpoint
+	}
+	return ""
+}
+
+func (x *Dataset) GetCanMount() string {
+	if x != nil {
+		return x.CanMount
+	}
+	return ""
+}
+
+func (x *Dataset) GetMounted() bool {
+	if x != nil {
+		return x.Mounted
+	}
+	return false
+}
+
+func (x *Dataset) GetBootFS() bool {
+	if x != nil {
+		return x.BootFS
+	}
+	return false
+}
+
+func (x *Dataset) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+func (x *Dataset) GetLastBootedKernel() string {
+	if x != nil {
+		return x.LastBootedKernel
+	}
+	return ""
+}
+
+func (x *Dataset) GetBootfsDatasets() []string {
+	if x != nil {
+		return x.BootfsDatasets
+	}
+	return nil
+}
+
+func (x *Dataset) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Dataset) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *Dataset) GetReferenced() uint64 {
+	if x != nil {
+		return x.Referenced
+	}
+	return 0
+}
+
+func (x *Dataset) GetWritten() uint64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *Dataset) GetIsBookmark() bool {
+	if x != nil {
+		return x.IsBookmark
+	}
+	return false
+}
+
+func (x *Dataset) GetEncryptionRoot() string {
+	if x != nil {
+		return x.EncryptionRoot
+	}
+	return ""
+}
+
+func (x *Dataset) GetKeyStatus() string {
+	if x != nil {
+		return x.KeyStatus
+	}
+	return ""
+}
+
+func (x *Dataset) GetExcluded() bool {
+	if x != nil {
+		return x.Excluded
+	}
+	return false
+}
+
+func (x *Dataset) GetFollowSystem() bool {
+	if x != nil {
+		return x.FollowSystem
+	}
+	return false
+}
+
+type MetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//
+	//	*MetricsResponse_Log
+	//	*MetricsResponse_Metrics
+	Reply isMetricsResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *MetricsResponse) Reset() {
+	*x = MetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsResponse) ProtoMessage() {}
+
+func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
+func (*MetricsResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{57}
+}
+
+func (m *MetricsResponse) GetReply() isMetricsResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *MetricsResponse) GetLog() string {
+	if x, ok := x.GetReply().(*MetricsResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *MetricsResponse) GetMetrics() string {
+	if x, ok := x.GetReply().(*MetricsResponse_Metrics); ok {
+		return x.Metrics
+	}
+	return ""
+}
+
+type isMetricsResponse_Reply interface {
+	isMetricsResponse_Reply()
+}
+
+type MetricsResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type MetricsResponse_Metrics struct {
+	Metrics string `protobuf:"bytes,2,opt,name=metrics,proto3,oneof"`
+}
+
+func (*MetricsResponse_Log) isMetricsResponse_Reply() {}
+
+func (*MetricsResponse_Metrics) isMetricsResponse_Reply() {}
+
+var File_zsys_proto protoreflect.FileDescriptor
+
+var file_zsys_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x7a, 0x73,
+	0x79, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0b,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x22, 0x4a, 0x0a,
+	0x0f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xab, 0x02, 0x0a, 0x15, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x70, 0x68, 0x72,
+	0x61, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x4b, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4b, 0x0a, 0x1b, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65,
+	0x77, 0x48, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77,
+	0x48, 0x6f, 0x6d, 0x65, 0x22, 0x4b, 0x0a, 0x15, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d,
+	0x65, 0x22, 0x4e, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x4d, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x2b, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65,
+	0x6e, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x6f, 0x22, 0xb2, 0x01,
+	0x0a, 0x16, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x61, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x61, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x22, 0x88, 0x01, 0x0a, 0x14, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x56, 0x0a,
+	0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1e, 0x0a, 0x09,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05,
+	0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x66, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x80, 0x01,
+	0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72,
+	0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e,
+	0x22, 0x60, 0x0a, 0x18, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65,
+	0x76, 0x65, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x96, 0x01, 0x0a, 0x1a, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x88, 0x01,
+	0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0xb0, 0x01, 0x0a, 0x18,
+	0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x47,
+	0x0a, 0x15, 0x50, 0x69, 0x6e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x03, 0x70, 0x69, 0x6e, 0x22, 0x61, 0x0a, 0x13, 0x50, 0x69, 0x6e, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x70, 0x69, 0x6e, 0x22, 0x52, 0x0a, 0x10, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5c,
+	0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x92, 0x01, 0x0a,
+	0x0a, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66,
+	0x72, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x6f, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x6f, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x61,
+	0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x74,
+	0x68, 0x22, 0x7e, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x69,
+	0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x22, 0x52, 0x0a, 0x19, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c,
+	0x6f, 0x67, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x07, 0x0a, 0x05,
+	0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x4c, 0x0a, 0x18, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4a, 0x04, 0x08,
+	0x01, 0x10, 0x02, 0x22, 0x79, 0x0a, 0x12, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x18, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x39,
+	0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6c, 0x6f, 0x67,
+	0x67, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x3e, 0x0a, 0x0c, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x0d, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x16,
+	0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
+	0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x4f, 0x0a, 0x09, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x22, 0x46, 0x0a, 0x12, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22, 0x7f, 0x0a, 0x13, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
+	0x6c, 0x6f, 0x67, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x82, 0x01, 0x0a, 0x13, 0x4d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x2c, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x35,
+	0x0a, 0x08, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x08, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x08, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x77, 0x69,
+	0x74, 0x68, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x22, 0x53,
+	0x0a, 0x14, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1e, 0x0a, 0x09, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x62, 0x0a, 0x14, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x2b, 0x0a, 0x15, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x22, 0x51, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x45,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c,
+	0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x42, 0x07,
+	0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x60, 0x0a, 0x13, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c,
+	0x6f, 0x67, 0x12, 0x2c, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x73, 0x48, 0x00, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73,
+	0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x35, 0x0a, 0x08, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73,
+	0x22, 0x52, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x22, 0x0a, 0x0c, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x22, 0x54, 0x0a, 0x19, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x50,
+	0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x57, 0x0a, 0x13, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0xc6, 0x04, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x32, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65,
+	0x64, 0x12, 0x36, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x09, 0x67, 0x63, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x67,
+	0x63, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x0a, 0x67, 0x63, 0x46, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x67,
+	0x63, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0d, 0x62, 0x6f, 0x6f,
+	0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x62, 0x6f,
+	0x6f, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0f, 0x75,
+	0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x73, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x13,
+	0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x13, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63,
+	0x69, 0x61, 0x74, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x10,
+	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x12, 0x31, 0x0a, 0x08, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x77, 0x53, 0x70, 0x61,
+	0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x77, 0x53, 0x70,
+	0x61, 0x63, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x3e, 0x0a, 0x0a,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x73, 0x0a, 0x07,
+	0x47, 0x43, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x75,
+	0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x75, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x22, 0x4c, 0x0a, 0x12, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74,
+	0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22,
+	0x37, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x22, 0x49, 0x0a, 0x0d, 0x4c, 0x6f, 0x77, 0x53,
+	0x70, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6f,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x72, 0x65,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x6d, 0x69, 0x6e, 0x22, 0x8c, 0x01, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x5a, 0x0a, 0x0f, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x05,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xd3,
+	0x02, 0x0a, 0x0c, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x42, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4a, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x8c, 0x03, 0x0a, 0x07, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x5a, 0x73, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x69, 0x73, 0x5a, 0x73, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x31, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x12, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x12,
+	0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x36,
+	0x0a, 0x16, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16,
+	0x6e, 0x65, 0x78, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x0a, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61,
+	0x72, 0x6b, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61,
+	0x72, 0x6b, 0x73, 0x22, 0xad, 0x03, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x36, 0x0a,
+	0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73,
+	0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6f, 0x6f,
+	0x74, 0x65, 0x64, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x12, 0x35, 0x0a, 0x0e, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x0e, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75,
+	0x73, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x22, 0xe6, 0x02, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x29, 0x0a,
+	0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x77,
+	0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x22, 0xc3, 0x04, 0x0a,
+	0x07, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x69, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6f, 0x6f, 0x74, 0x46, 0x53, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x62, 0x6f, 0x6f, 0x74, 0x46, 0x53, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73,
+	0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x65, 0x64,
+	0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x61,
+	0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x26,
+	0x0a, 0x0e, 0x62, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x73,
+	0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x73, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x69, 0x73, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x26, 0x0a, 0x0e,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x22, 0x4a, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x32, 0xae,
+	0x14, 0x0a, 0x04, 0x5a, 0x73, 0x79, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x15, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x14,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x21, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e,
+	0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1b,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x12, 0x37, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x12,
+	0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x12, 0x42, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65,
+	0x6e, 0x75, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61,
+	0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0d, 0x53, 0x61,
+	0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x11, 0x52, 0x65, 0x76, 0x65,
+	0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x4c, 0x0a, 0x13, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x12, 0x48, 0x0a, 0x11, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x41, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x50, 0x69,
+	0x6e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x50, 0x69, 0x6e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e,
+	0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x50, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e,
+	0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x12, 0x16, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x56,
+	0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x11, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x33,
+	0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2e, 0x0a, 0x0a, 0x44, 0x61,
+	0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0c, 0x4c, 0x6f,
+	0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2b, 0x0a, 0x07, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x12, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x06, 0x52, 0x65, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x02, 0x47, 0x43, 0x12, 0x0f, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x44, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x12, 0x18,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x47,
+	0x0a, 0x0c, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x12, 0x19,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x6f,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x40, 0x0a, 0x0d, 0x4d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x1a, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x1b, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x5c, 0x0a, 0x17, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x5a, 0x0a, 0x15, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x4d, 0x61, 0x6b, 0x65, 0x50, 0x65,
+	0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x0b, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x07, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x14, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2f,
+	0x0a, 0x07, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42,
+	0x18, 0x5a, 0x16, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x62,
+	0x75, 0x6e, 0x74, 0x75, 0x2f, 0x7a, 0x73, 0x79, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_zsys_proto_rawDescOnce sync.Once
+	file_zsys_proto_rawDescData = file_zsys_proto_rawDesc
+)
+
+func file_zsys_proto_rawDescGZIP() []byte {
+	file_zsys_proto_rawDescOnce.Do(func() {
+		file_zsys_proto_rawDescData = protoimpl.X.CompressGZIP(file_zsys_proto_rawDescData)
+	})
+	return file_zsys_proto_rawDescData
+}
+
+var file_zsys_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_zsys_proto_goTypes = []interface{}{
+	(*Empty)(nil),                       // 0: zsys.Empty
+	(*LogResponse)(nil),                 // 1: zsys.LogResponse
+	(*VersionResponse)(nil),             // 2: zsys.VersionResponse
+	(*CreateUserDataRequest)(nil),       // 3: zsys.CreateUserDataRequest
+	(*ChangeHomeOnUserDataRequest)(nil), // 4: zsys.ChangeHomeOnUserDataRequest
+	(*DissociateUserRequest)(nil),       // 5: zsys.DissociateUserRequest
+	(*PrepareBootResponse)(nil),         // 6: zsys.PrepareBootResponse
+	(*CommitBootResponse)(nil),          // 7: zsys.CommitBootResponse
+	(*UpdateBootMenuRequest)(nil),       // 8: zsys.UpdateBootMenuRequest
+	(*SaveSystemStateRequest)(nil),      // 9: zsys.SaveSystemStateRequest
+	(*SaveUserStateRequest)(nil),        // 10: zsys.SaveUserStateRequest
+	(*CreateSaveStateResponse)(nil),     // 11: zsys.CreateSaveStateResponse
+	(*RemoveSystemStateRequest)(nil),    // 12: zsys.RemoveSystemStateRequest
+	(*RemoveUserStateRequest)(nil),      // 13: zsys.RemoveUserStateRequest
+	(*RevertSystemStateRequest)(nil),    // 14: zsys.RevertSystemStateRequest
+	(*AnnotateSystemStateRequest)(nil),  // 15: zsys.AnnotateSystemStateRequest
+	(*AnnotateUserStateRequest)(nil),    // 16: zsys.AnnotateUserStateRequest
+	(*PinSystemStateRequest)(nil),       // 17: zsys.PinSystemStateRequest
+	(*PinUserStateRequest)(nil),         // 18: zsys.PinUserStateRequest
+	(*StateDiffRequest)(nil),            // 19: zsys.StateDiffRequest
+	(*StateDiffResponse)(nil),           // 20: zsys.StateDiffResponse
+	(*FileChange)(nil),                  // 21: zsys.FileChange
+	(*ExportSystemStateRequest)(nil),    // 22: zsys.ExportSystemStateRequest
+	(*ExportSystemStateResponse)(nil),   // 23: zsys.ExportSystemStateResponse
+	(*ImportSystemStateRequest)(nil),    // 24: zsys.ImportSystemStateRequest
+	(*DumpStatesResponse)(nil),          // 25: zsys.DumpStatesResponse
+	(*LoggingLevelRequest)(nil),         // 26: zsys.LoggingLevelRequest
+	(*TraceRequest)(nil),                // 27: zsys.TraceRequest
+	(*TraceResponse)(nil),               // 28: zsys.TraceResponse
+	(*GCRequest)(nil),                   // 29: zsys.GCRequest
+	(*MachineShowRequest)(nil),          // 30: zsys.MachineShowRequest
+	(*MachineShowResponse)(nil),         // 31: zsys.MachineShowResponse
+	(*MachineListResponse)(nil),         // 32: zsys.MachineListResponse
+	(*Machines)(nil),                    // 33: zsys.Machines
+	(*MachineCloneRequest)(nil),         // 34: zsys.MachineCloneRequest
+	(*MachineCloneResponse)(nil),        // 35: zsys.MachineCloneResponse
+	(*MachineRemoveRequest)(nil),        // 36: zsys.MachineRemoveRequest
+	(*DatasetExcludeRequest)(nil),       // 37: zsys.DatasetExcludeRequest
+	(*DatasetExcludeResponse)(nil),      // 38: zsys.DatasetExcludeResponse
+	(*DatasetListResponse)(nil),         // 39: zsys.DatasetListResponse
+	(*Datasets)(nil),                    // 40: zsys.Datasets
+	(*DatasetPersistentRequest)(nil),    // 41: zsys.DatasetPersistentRequest
+	(*DatasetPersistentResponse)(nil),   // 42: zsys.DatasetPersistentResponse
+	(*WatchEventsResponse)(nil),         // 43: zsys.WatchEventsResponse
+	(*Event)(nil),                       // 44: zsys.Event
+	(*StateEvent)(nil),                  // 45: zsys.StateEvent
+	(*GCEvent)(nil),                     // 46: zsys.GCEvent
+	(*BootCommittedEvent)(nil),          // 47: zsys.BootCommittedEvent
+	(*UserDataEvent)(nil),               // 48: zsys.UserDataEvent
+	(*LowSpaceEvent)(nil),               // 49: zsys.LowSpaceEvent
+	(*HistoryRequest)(nil),              // 50: zsys.HistoryRequest
+	(*HistoryResponse)(nil),             // 51: zsys.HistoryResponse
+	(*JournalEntry)(nil),                // 52: zsys.JournalEntry
+	(*Machine)(nil),                     // 53: zsys.Machine
+	(*State)(nil),                       // 54: zsys.State
+	(*UserState)(nil),                   // 55: zsys.UserState
+	(*Dataset)(nil),                     // 56: zsys.Dataset
+	(*MetricsResponse)(nil),             // 57: zsys.MetricsResponse
+	nil,                                 // 58: zsys.CreateUserDataRequest.PropertiesEntry
+	nil,                                 // 59: zsys.JournalEntry.ParametersEntry
+	(*timestamppb.Timestamp)(nil),       // 60: google.protobuf.Timestamp
+}
+var file_zsys_proto_depIdxs = []int32{
+	58, // 0: zsys.CreateUserDataRequest.properties:type_name -> zsys.CreateUserDataRequest.PropertiesEntry
+	21, // 1: zsys.StateDiffResponse.change:type_name -> zsys.FileChange
+	33, // 2: zsys.DumpStatesResponse.machines:type_name -> zsys.Machines
+	53, // 3: zsys.MachineShowResponse.machine:type_name -> zsys.Machine
+	33, // 4: zsys.MachineListResponse.machines:type_name -> zsys.Machines
+	53, // 5: zsys.Machines.machines:type_name -> zsys.Machine
+	40, // 6: zsys.DatasetListResponse.datasets:type_name -> zsys.Datasets
+	56, // 7: zsys.Datasets.datasets:type_name -> zsys.Dataset
+	44, // 8: zsys.WatchEventsResponse.event:type_name -> zsys.Event
+	60, // 9: zsys.Event.time:type_name -> google.protobuf.Timestamp
+	45, // 10: zsys.Event.stateSaved:type_name -> zsys.StateEvent
+	45, // 11: zsys.Event.stateRemoved:type_name -> zsys.StateEvent
+	46, // 12: zsys.Event.gcStarted:type_name -> zsys.GCEvent
+	46, // 13: zsys.Event.gcFinished:type_name -> zsys.GCEvent
+	47, // 14: zsys.Event.bootCommitted:type_name -> zsys.BootCommittedEvent
+	48, // 15: zsys.Event.userDataCreated:type_name -> zsys.UserDataEvent
+	48, // 16: zsys.Event.userDataDissociated:type_name -> zsys.UserDataEvent
+	0,  // 17: zsys.Event.refreshCompleted:type_name -> zsys.Empty
+	49, // 18: zsys.Event.lowSpace:type_name -> zsys.LowSpaceEvent
+	60, // 19: zsys.HistoryRequest.since:type_name -> google.protobuf.Timestamp
+	52, // 20: zsys.HistoryResponse.entry:type_name -> zsys.JournalEntry
+	60, // 21: zsys.JournalEntry.time:type_name -> google.protobuf.Timestamp
+	59, // 22: zsys.JournalEntry.parameters:type_name -> zsys.JournalEntry.ParametersEntry
+	54, // 23: zsys.Machine.state:type_name -> zsys.State
+	54, // 24: zsys.Machine.history:type_name -> zsys.State
+	55, // 25: zsys.Machine.userHistory:type_name -> zsys.UserState
+	56, // 26: zsys.Machine.persistentDatasets:type_name -> zsys.Dataset
+	56, // 27: zsys.Machine.bookmarks:type_name -> zsys.Dataset
+	60, // 28: zsys.State.lastUsed:type_name -> google.protobuf.Timestamp
+	56, // 29: zsys.State.systemDatasets:type_name -> zsys.Dataset
+	55, // 30: zsys.State.users:type_name -> zsys.UserState
+	60, // 31: zsys.UserState.lastUsed:type_name -> google.protobuf.Timestamp
+	56, // 32: zsys.UserState.datasets:type_name -> zsys.Dataset
+	60, // 33: zsys.Dataset.lastUsed:type_name -> google.protobuf.Timestamp
+	0,  // 34: zsys.Zsys.Version:input_type -> zsys.Empty
+	3,  // 35: zsys.Zsys.CreateUserData:input_type -> zsys.CreateUserDataRequest
+	4,  // 36: zsys.Zsys.ChangeHomeOnUserData:input_type -> zsys.ChangeHomeOnUserDataRequest
+	5,  // 37: zsys.Zsys.DissociateUser:input_type -> zsys.DissociateUserRequest
+	0,  // 38: zsys.Zsys.PrepareBoot:input_type -> zsys.Empty
+	0,  // 39: zsys.Zsys.CommitBoot:input_type -> zsys.Empty
+	8,  // 40: zsys.Zsys.UpdateBootMenu:input_type -> zsys.UpdateBootMenuRequest
+	0,  // 41: zsys.Zsys.UpdateLastUsed:input_type -> zsys.Empty
+	9,  // 42: zsys.Zsys.SaveSystemState:input_type -> zsys.SaveSystemStateRequest
+	10, // 43: zsys.Zsys.SaveUserState:input_type -> zsys.SaveUserStateRequest
+	12, // 44: zsys.Zsys.RemoveSystemState:input_type -> zsys.RemoveSystemStateRequest
+	13, // 45: zsys.Zsys.RemoveUserState:input_type -> zsys.RemoveUserStateRequest
+	14, // 46: zsys.Zsys.RevertSystemState:input_type -> zsys.RevertSystemStateRequest
+	15, // 47: zsys.Zsys.AnnotateSystemState:input_type -> zsys.AnnotateSystemStateRequest
+	16, // 48: zsys.Zsys.AnnotateUserState:input_type -> zsys.AnnotateUserStateRequest
+	17, // 49: zsys.Zsys.PinSystemState:input_type -> zsys.PinSystemStateRequest
+	18, // 50: zsys.Zsys.PinUserState:input_type -> zsys.PinUserStateRequest
+	19, // 51: zsys.Zsys.StateDiff:input_type -> zsys.StateDiffRequest
+	22, // 52: zsys.Zsys.ExportSystemState:input_type -> zsys.ExportSystemStateRequest
+	24, // 53: zsys.Zsys.ImportSystemState:input_type -> zsys.ImportSystemStateRequest
+	0,  // 54: zsys.Zsys.ReplicateStates:input_type -> zsys.Empty
+	0,  // 55: zsys.Zsys.DumpStates:input_type -> zsys.Empty
+	0,  // 56: zsys.Zsys.DaemonStop:input_type -> zsys.Empty
+	26, // 57: zsys.Zsys.LoggingLevel:input_type -> zsys.LoggingLevelRequest
+	0,  // 58: zsys.Zsys.Refresh:input_type -> zsys.Empty
+	27, // 59: zsys.Zsys.Trace:input_type -> zsys.TraceRequest
+	0,  // 60: zsys.Zsys.Status:input_type -> zsys.Empty
+	0,  // 61: zsys.Zsys.Reload:input_type -> zsys.Empty
+	29, // 62: zsys.Zsys.GC:input_type -> zsys.GCRequest
+	30, // 63: zsys.Zsys.MachineShow:input_type -> zsys.MachineShowRequest
+	0,  // 64: zsys.Zsys.MachineList:input_type -> zsys.Empty
+	34, // 65: zsys.Zsys.MachineClone:input_type -> zsys.MachineCloneRequest
+	36, // 66: zsys.Zsys.MachineRemove:input_type -> zsys.MachineRemoveRequest
+	37, // 67: zsys.Zsys.DatasetExclude:input_type -> zsys.DatasetExcludeRequest
+	0,  // 68: zsys.Zsys.DatasetList:input_type -> zsys.Empty
+	41, // 69: zsys.Zsys.DatasetCreatePersistent:input_type -> zsys.DatasetPersistentRequest
+	41, // 70: zsys.Zsys.DatasetMakePersistent:input_type -> zsys.DatasetPersistentRequest
+	0,  // 71: zsys.Zsys.WatchEvents:input_type -> zsys.Empty
+	50, // 72: zsys.Zsys.History:input_type -> zsys.HistoryRequest
+	0,  // 73: zsys.Zsys.Metrics:input_type -> zsys.Empty
+	2,  // 74: zsys.Zsys.Version:output_type -> zsys.VersionResponse
+	1,  // 75: zsys.Zsys.CreateUserData:output_type -> zsys.LogResponse
+	1,  // 76: zsys.Zsys.ChangeHomeOnUserData:output_type -> zsys.LogResponse
+	1,  // 77: zsys.Zsys.DissociateUser:output_type -> zsys.LogResponse
+	6,  // 78: zsys.Zsys.PrepareBoot:output_type -> zsys.PrepareBootResponse
+	7,  // 79: zsys.Zsys.CommitBoot:output_type -> zsys.CommitBootResponse
+	1,  // 80: zsys.Zsys.UpdateBootMenu:output_type -> zsys.LogResponse
+	1,  // 81: zsys.Zsys.UpdateLastUsed:output_type -> zsys.LogResponse
+	11, // 82: zsys.Zsys.SaveSystemState:output_type -> zsys.CreateSaveStateResponse
+	11, // 83: zsys.Zsys.SaveUserState:output_type -> zsys.CreateSaveStateResponse
+	1,  // 84: zsys.Zsys.RemoveSystemState:output_type -> zsys.LogResponse
+	1,  // 85: zsys.Zsys.RemoveUserState:output_type -> zsys.LogResponse
+	1,  // 86: zsys.Zsys.RevertSystemState:output_type -> zsys.LogResponse
+	1,  // 87: zsys.Zsys.AnnotateSystemState:output_type -> zsys.LogResponse
+	1,  // 88: zsys.Zsys.AnnotateUserState:output_type -> zsys.LogResponse
+	1,  // 89: zsys.Zsys.PinSystemState:output_type -> zsys.LogResponse
+	1,  // 90: zsys.Zsys.PinUserState:output_type -> zsys.LogResponse
+	20, // 91: zsys.Zsys.StateDiff:output_type -> zsys.StateDiffResponse
+	23, // 92: zsys.Zsys.ExportSystemState:output_type -> zsys.ExportSystemStateResponse
+	11, // 93: zsys.Zsys.ImportSystemState:output_type -> zsys.CreateSaveStateResponse
+	1,  // 94: zsys.Zsys.ReplicateStates:output_type -> zsys.LogResponse
+	25, // 95: zsys.Zsys.DumpStates:output_type -> zsys.DumpStatesResponse
+	1,  // 96: zsys.Zsys.DaemonStop:output_type -> zsys.LogResponse
+	1,  // 97: zsys.Zsys.LoggingLevel:output_type -> zsys.LogResponse
+	1,  // 98: zsys.Zsys.Refresh:output_type -> zsys.LogResponse
+	28, // 99: zsys.Zsys.Trace:output_type -> zsys.TraceResponse
+	1,  // 100: zsys.Zsys.Status:output_type -> zsys.LogResponse
+	1,  // 101: zsys.Zsys.Reload:output_type -> zsys.LogResponse
+	1,  // 102: zsys.Zsys.GC:output_type -> zsys.LogResponse
+	31, // 103: zsys.Zsys.MachineShow:output_type -> zsys.MachineShowResponse
+	32, // 104: zsys.Zsys.MachineList:output_type -> zsys.MachineListResponse
+	35, // 105: zsys.Zsys.MachineClone:output_type -> zsys.MachineCloneResponse
+	1,  // 106: zsys.Zsys.MachineRemove:output_type -> zsys.LogResponse
+	38, // 107: zsys.Zsys.DatasetExclude:output_type -> zsys.DatasetExcludeResponse
+	39, // 108: zsys.Zsys.DatasetList:output_type -> zsys.DatasetListResponse
+	42, // 109: zsys.Zsys.DatasetCreatePersistent:output_type -> zsys.DatasetPersistentResponse
+	42, // 110: zsys.Zsys.DatasetMakePersistent:output_type -> zsys.DatasetPersistentResponse
+	43, // 111: zsys.Zsys.WatchEvents:output_type -> zsys.WatchEventsResponse
+	51, // 112: zsys.Zsys.History:output_type -> zsys.HistoryResponse
+	57, // 113: zsys.Zsys.Metrics:output_type -> zsys.MetricsResponse
+	74, // [74:114] is the sub-list for method output_type
+	34, // [34:74] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_zsys_proto_init() }
+func file_zsys_proto_init() {
+	if File_zsys_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_zsys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateUserDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeHomeOnUserDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DissociateUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareBootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitBootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateBootMenuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSaveStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevertSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnnotateSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnnotateUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PinSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PinUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateDiffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateDiffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSystemStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpStatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoggingLevelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1722,8 +5580,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogResponse); i {
+		file_zsys_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1734,8 +5592,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionResponse); i {
+		file_zsys_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineShowRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1746,8 +5604,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateUserDataRequest); i {
+		file_zsys_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineShowResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1758,8 +5616,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeHomeOnUserDataRequest); i {
+		file_zsys_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1770,8 +5628,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DissociateUserRequest); i {
+		file_zsys_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Machines); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1782,8 +5640,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrepareBootResponse); i {
+		file_zsys_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineCloneRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1794,8 +5652,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitBootResponse); i {
+		file_zsys_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineCloneResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1806,8 +5664,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateBootMenuRequest); i {
+		file_zsys_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineRemoveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1818,8 +5676,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SaveSystemStateRequest); i {
+		file_zsys_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetExcludeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1830,8 +5688,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SaveUserStateRequest); i {
+		file_zsys_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetExcludeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1842,8 +5700,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSaveStateResponse); i {
+		file_zsys_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1854,8 +5712,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveSystemStateRequest); i {
+		file_zsys_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Datasets); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1866,8 +5724,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveUserStateRequest); i {
+		file_zsys_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetPersistentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1878,8 +5736,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DumpStatesResponse); i {
+		file_zsys_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetPersistentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1890,8 +5748,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoggingLevelRequest); i {
+		file_zsys_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEventsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1902,8 +5760,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TraceRequest); i {
+		file_zsys_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1914,8 +5772,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TraceResponse); i {
+		file_zsys_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1926,8 +5784,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GCRequest); i {
+		file_zsys_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1938,8 +5796,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachineShowRequest); i {
+		file_zsys_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BootCommittedEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1950,8 +5808,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachineShowResponse); i {
+		file_zsys_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserDataEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1962,8 +5820,104 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachineListResponse); i {
+		file_zsys_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LowSpaceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JournalEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Machine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*State); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dataset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1991,21 +5945,71 @@ func file_zsys_proto_init() {
 		(*CreateSaveStateResponse_Log)(nil),
 		(*CreateSaveStateResponse_StateName)(nil),
 	}
-	file_zsys_proto_msgTypes[14].OneofWrappers = []interface{}{
+	file_zsys_proto_msgTypes[15].OneofWrappers = []interface{}{}
+	file_zsys_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	file_zsys_proto_msgTypes[20].OneofWrappers = []interface{}{
+		(*StateDiffResponse_Log)(nil),
+		(*StateDiffResponse_Change)(nil),
+	}
+	file_zsys_proto_msgTypes[23].OneofWrappers = []interface{}{
+		(*ExportSystemStateResponse_Log)(nil),
+		(*ExportSystemStateResponse_Stream)(nil),
+	}
+	file_zsys_proto_msgTypes[25].OneofWrappers = []interface{}{
 		(*DumpStatesResponse_Log)(nil),
 		(*DumpStatesResponse_States)(nil),
+		(*DumpStatesResponse_Machines)(nil),
 	}
-	file_zsys_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_zsys_proto_msgTypes[28].OneofWrappers = []interface{}{
 		(*TraceResponse_Log)(nil),
 		(*TraceResponse_Trace)(nil),
 	}
-	file_zsys_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_zsys_proto_msgTypes[31].OneofWrappers = []interface{}{
 		(*MachineShowResponse_Log)(nil),
-		(*MachineShowResponse_MachineInfo)(nil),
+		(*MachineShowResponse_Machine)(nil),
 	}
-	file_zsys_proto_msgTypes[21].OneofWrappers = []interface{}{
+	file_zsys_proto_msgTypes[32].OneofWrappers = []interface{}{
 		(*MachineListResponse_Log)(nil),
-		(*MachineListResponse_MachineList)(nil),
+		(*MachineListResponse_Machines)(nil),
+	}
+	file_zsys_proto_msgTypes[35].OneofWrappers = []interface{}{
+		(*MachineCloneResponse_Log)(nil),
+		(*MachineCloneResponse_MachineId)(nil),
+	}
+	file_zsys_proto_msgTypes[38].OneofWrappers = []interface{}{
+		(*DatasetExcludeResponse_Log)(nil),
+		(*DatasetExcludeResponse_Dataset)(nil),
+	}
+	file_zsys_proto_msgTypes[39].OneofWrappers = []interface{}{
+		(*DatasetListResponse_Log)(nil),
+		(*DatasetListResponse_Datasets)(nil),
+	}
+	file_zsys_proto_msgTypes[42].OneofWrappers = []interface{}{
+		(*DatasetPersistentResponse_Log)(nil),
+		(*DatasetPersistentResponse_Dataset)(nil),
+	}
+	file_zsys_proto_msgTypes[43].OneofWrappers = []interface{}{
+		(*WatchEventsResponse_Log)(nil),
+		(*WatchEventsResponse_Event)(nil),
+	}
+	file_zsys_proto_msgTypes[44].OneofWrappers = []interface{}{
+		(*Event_StateSaved)(nil),
+		(*Event_StateRemoved)(nil),
+		(*Event_GcStarted)(nil),
+		(*Event_GcFinished)(nil),
+		(*Event_BootCommitted)(nil),
+		(*Event_UserDataCreated)(nil),
+		(*Event_UserDataDissociated)(nil),
+		(*Event_RefreshCompleted)(nil),
+		(*Event_LowSpace)(nil),
+	}
+	file_zsys_proto_msgTypes[51].OneofWrappers = []interface{}{
+		(*HistoryResponse_Log)(nil),
+		(*HistoryResponse_Entry)(nil),
+	}
+	file_zsys_proto_msgTypes[57].OneofWrappers = []interface{}{
+		(*MetricsResponse_Log)(nil),
+		(*MetricsResponse_Metrics)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2013,7 +6017,7 @@ func file_zsys_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_zsys_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   60,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -83,10 +83,12 @@ func (z *ZsysLogServer) Version(req *Empty, stream Zsys_VersionServer) error {
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.Version(req, &zsysVersionLogStream{
+	err = z.ZsysServerIdleTimeout.Version(req, &zsysVersionLogStream{
 		Zsys_VersionServer: stream,
 		ctx:                ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "Version", err)
+	return err
 }
 
 /*
@@ -113,10 +115,12 @@ func (z *ZsysLogServer) CreateUserData(req *CreateUserDataRequest, stream Zsys_C
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.CreateUserData(req, &zsysCreateUserDataLogStream{
+	err = z.ZsysServerIdleTimeout.CreateUserData(req, &zsysCreateUserDataLogStream{
 		Zsys_CreateUserDataServer: stream,
 		ctx:                       ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "CreateUserData", err)
+	return err
 }
 
 /*
@@ -143,10 +147,12 @@ func (z *ZsysLogServer) ChangeHomeOnUserData(req *ChangeHomeOnUserDataRequest, s
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.ChangeHomeOnUserData(req, &zsysChangeHomeOnUserDataLogStream{
+	err = z.ZsysServerIdleTimeout.ChangeHomeOnUserData(req, &zsysChangeHomeOnUserDataLogStream{
 		Zsys_ChangeHomeOnUserDataServer: stream,
 		ctx:                             ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "ChangeHomeOnUserData", err)
+	return err
 }
 
 /*
@@ -173,10 +179,12 @@ func (z *ZsysLogServer) DissociateUser(req *DissociateUserRequest, stream Zsys_D
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.DissociateUser(req, &zsysDissociateUserLogStream{
+	err = z.ZsysServerIdleTimeout.DissociateUser(req, &zsysDissociateUserLogStream{
 		Zsys_DissociateUserServer: stream,
 		ctx:                       ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DissociateUser", err)
+	return err
 }
 
 /*
@@ -203,10 +211,12 @@ func (z *ZsysLogServer) PrepareBoot(req *Empty, stream Zsys_PrepareBootServer) e
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.PrepareBoot(req, &zsysPrepareBootLogStream{
+	err = z.ZsysServerIdleTimeout.PrepareBoot(req, &zsysPrepareBootLogStream{
 		Zsys_PrepareBootServer: stream,
 		ctx:                    ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "PrepareBoot", err)
+	return err
 }
 
 /*
@@ -233,10 +243,12 @@ func (z *ZsysLogServer) CommitBoot(req *Empty, stream Zsys_CommitBootServer) err
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.CommitBoot(req, &zsysCommitBootLogStream{
+	err = z.ZsysServerIdleTimeout.CommitBoot(req, &zsysCommitBootLogStream{
 		Zsys_CommitBootServer: stream,
 		ctx:                   ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "CommitBoot", err)
+	return err
 }
 
 /*
@@ -263,10 +275,12 @@ func (z *ZsysLogServer) UpdateBootMenu(req *UpdateBootMenuRequest, stream Zsys_U
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.UpdateBootMenu(req, &zsysUpdateBootMenuLogStream{
+	err = z.ZsysServerIdleTimeout.UpdateBootMenu(req, &zsysUpdateBootMenuLogStream{
 		Zsys_UpdateBootMenuServer: stream,
 		ctx:                       ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "UpdateBootMenu", err)
+	return err
 }
 
 /*
@@ -293,10 +307,12 @@ func (z *ZsysLogServer) UpdateLastUsed(req *Empty, stream Zsys_UpdateLastUsedSer
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.UpdateLastUsed(req, &zsysUpdateLastUsedLogStream{
+	err = z.ZsysServerIdleTimeout.UpdateLastUsed(req, &zsysUpdateLastUsedLogStream{
 		Zsys_UpdateLastUsedServer: stream,
 		ctx:                       ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "UpdateLastUsed", err)
+	return err
 }
 
 /*
@@ -323,10 +339,12 @@ func (z *ZsysLogServer) SaveSystemState(req *SaveSystemStateRequest, stream Zsys
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.SaveSystemState(req, &zsysSaveSystemStateLogStream{
+	err = z.ZsysServerIdleTimeout.SaveSystemState(req, &zsysSaveSystemStateLogStream{
 		Zsys_SaveSystemStateServer: stream,
 		ctx:                        ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "SaveSystemState", err)
+	return err
 }
 
 /*
@@ -353,10 +371,12 @@ func (z *ZsysLogServer) SaveUserState(req *SaveUserStateRequest, stream Zsys_Sav
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.SaveUserState(req, &zsysSaveUserStateLogStream{
+	err = z.ZsysServerIdleTimeout.SaveUserState(req, &zsysSaveUserStateLogStream{
 		Zsys_SaveUserStateServer: stream,
 		ctx:                      ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "SaveUserState", err)
+	return err
 }
 
 /*
@@ -383,10 +403,12 @@ func (z *ZsysLogServer) RemoveSystemState(req *RemoveSystemStateRequest, stream
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.RemoveSystemState(req, &zsysRemoveSystemStateLogStream{
+	err = z.ZsysServerIdleTimeout.RemoveSystemState(req, &zsysRemoveSystemStateLogStream{
 		Zsys_RemoveSystemStateServer: stream,
 		ctx:                          ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "RemoveSystemState", err)
+	return err
 }
 
 /*
@@ -413,10 +435,300 @@ func (z *ZsysLogServer) RemoveUserState(req *RemoveUserStateRequest, stream Zsys
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.RemoveUserState(req, &zsysRemoveUserStateLogStream{
+	err = z.ZsysServerIdleTimeout.RemoveUserState(req, &zsysRemoveUserStateLogStream{
 		Zsys_RemoveUserStateServer: stream,
 		ctx:                        ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "RemoveUserState", err)
+	return err
+}
+
+/*
+ * Zsys.RevertSystemState()
+ */
+
+// zsysRevertSystemStateLogStream is a Zsys_RevertSystemStateServer augmented by its own Context containing the log streamer
+type zsysRevertSystemStateLogStream struct {
+	Zsys_RevertSystemStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRevertSystemStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// RevertSystemState overrides ZsysServer RevertSystemState, installing a logger first
+func (z *ZsysLogServer) RevertSystemState(req *RevertSystemStateRequest, stream Zsys_RevertSystemStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "RevertSystemState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.RevertSystemState(req, &zsysRevertSystemStateLogStream{
+		Zsys_RevertSystemStateServer: stream,
+		ctx:                          ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "RevertSystemState", err)
+	return err
+}
+
+/*
+ * Zsys.AnnotateSystemState()
+ */
+
+// zsysAnnotateSystemStateLogStream is a Zsys_AnnotateSystemStateServer augmented by its own Context containing the log streamer
+type zsysAnnotateSystemStateLogStream struct {
+	Zsys_AnnotateSystemStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysAnnotateSystemStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// AnnotateSystemState overrides ZsysServer AnnotateSystemState, installing a logger first
+func (z *ZsysLogServer) AnnotateSystemState(req *AnnotateSystemStateRequest, stream Zsys_AnnotateSystemStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "AnnotateSystemState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.AnnotateSystemState(req, &zsysAnnotateSystemStateLogStream{
+		Zsys_AnnotateSystemStateServer: stream,
+		ctx:                            ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "AnnotateSystemState", err)
+	return err
+}
+
+/*
+ * Zsys.AnnotateUserState()
+ */
+
+// zsysAnnotateUserStateLogStream is a Zsys_AnnotateUserStateServer augmented by its own Context containing the log streamer
+type zsysAnnotateUserStateLogStream struct {
+	Zsys_AnnotateUserStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysAnnotateUserStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// AnnotateUserState overrides ZsysServer AnnotateUserState, installing a logger first
+func (z *ZsysLogServer) AnnotateUserState(req *AnnotateUserStateRequest, stream Zsys_AnnotateUserStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "AnnotateUserState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.AnnotateUserState(req, &zsysAnnotateUserStateLogStream{
+		Zsys_AnnotateUserStateServer: stream,
+		ctx:                          ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "AnnotateUserState", err)
+	return err
+}
+
+/*
+ * Zsys.PinSystemState()
+ */
+
+// zsysPinSystemStateLogStream is a Zsys_PinSystemStateServer augmented by its own Context containing the log streamer
+type zsysPinSystemStateLogStream struct {
+	Zsys_PinSystemStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysPinSystemStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// PinSystemState overrides ZsysServer PinSystemState, installing a logger first
+func (z *ZsysLogServer) PinSystemState(req *PinSystemStateRequest, stream Zsys_PinSystemStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "PinSystemState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.PinSystemState(req, &zsysPinSystemStateLogStream{
+		Zsys_PinSystemStateServer: stream,
+		ctx:                       ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "PinSystemState", err)
+	return err
+}
+
+/*
+ * Zsys.PinUserState()
+ */
+
+// zsysPinUserStateLogStream is a Zsys_PinUserStateServer augmented by its own Context containing the log streamer
+type zsysPinUserStateLogStream struct {
+	Zsys_PinUserStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysPinUserStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// PinUserState overrides ZsysServer PinUserState, installing a logger first
+func (z *ZsysLogServer) PinUserState(req *PinUserStateRequest, stream Zsys_PinUserStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "PinUserState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.PinUserState(req, &zsysPinUserStateLogStream{
+		Zsys_PinUserStateServer: stream,
+		ctx:                     ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "PinUserState", err)
+	return err
+}
+
+/*
+ * Zsys.StateDiff()
+ */
+
+// zsysStateDiffLogStream is a Zsys_StateDiffServer augmented by its own Context containing the log streamer
+type zsysStateDiffLogStream struct {
+	Zsys_StateDiffServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysStateDiffLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// StateDiff overrides ZsysServer StateDiff, installing a logger first
+func (z *ZsysLogServer) StateDiff(req *StateDiffRequest, stream Zsys_StateDiffServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "StateDiff")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.StateDiff(req, &zsysStateDiffLogStream{
+		Zsys_StateDiffServer: stream,
+		ctx:                  ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "StateDiff", err)
+	return err
+}
+
+/*
+ * Zsys.ExportSystemState()
+ */
+
+// zsysExportSystemStateLogStream is a Zsys_ExportSystemStateServer augmented by its own Context containing the log streamer
+type zsysExportSystemStateLogStream struct {
+	Zsys_ExportSystemStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysExportSystemStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// ExportSystemState overrides ZsysServer ExportSystemState, installing a logger first
+func (z *ZsysLogServer) ExportSystemState(req *ExportSystemStateRequest, stream Zsys_ExportSystemStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "ExportSystemState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.ExportSystemState(req, &zsysExportSystemStateLogStream{
+		Zsys_ExportSystemStateServer: stream,
+		ctx:                          ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "ExportSystemState", err)
+	return err
+}
+
+/*
+ * Zsys.ImportSystemState()
+ */
+
+// zsysImportSystemStateLogStream is a Zsys_ImportSystemStateServer augmented by its own Context containing the log streamer
+type zsysImportSystemStateLogStream struct {
+	Zsys_ImportSystemStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysImportSystemStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// ImportSystemState overrides ZsysServer ImportSystemState, installing a logger first
+func (z *ZsysLogServer) ImportSystemState(stream Zsys_ImportSystemStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "ImportSystemState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.ImportSystemState(&zsysImportSystemStateLogStream{
+		Zsys_ImportSystemStateServer: stream,
+		ctx:                          ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "ImportSystemState", err)
+	return err
+}
+
+/*
+ * Zsys.ReplicateStates()
+ */
+
+// zsysReplicateStatesLogStream is a Zsys_ReplicateStatesServer augmented by its own Context containing the log streamer
+type zsysReplicateStatesLogStream struct {
+	Zsys_ReplicateStatesServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysReplicateStatesLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// ReplicateStates overrides ZsysServer ReplicateStates, installing a logger first
+func (z *ZsysLogServer) ReplicateStates(req *Empty, stream Zsys_ReplicateStatesServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "ReplicateStates")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.ReplicateStates(req, &zsysReplicateStatesLogStream{
+		Zsys_ReplicateStatesServer: stream,
+		ctx:                        ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "ReplicateStates", err)
+	return err
 }
 
 /*
@@ -443,10 +755,12 @@ func (z *ZsysLogServer) DumpStates(req *Empty, stream Zsys_DumpStatesServer) err
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.DumpStates(req, &zsysDumpStatesLogStream{
+	err = z.ZsysServerIdleTimeout.DumpStates(req, &zsysDumpStatesLogStream{
 		Zsys_DumpStatesServer: stream,
 		ctx:                   ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DumpStates", err)
+	return err
 }
 
 /*
@@ -473,10 +787,12 @@ func (z *ZsysLogServer) DaemonStop(req *Empty, stream Zsys_DaemonStopServer) err
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.DaemonStop(req, &zsysDaemonStopLogStream{
+	err = z.ZsysServerIdleTimeout.DaemonStop(req, &zsysDaemonStopLogStream{
 		Zsys_DaemonStopServer: stream,
 		ctx:                   ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DaemonStop", err)
+	return err
 }
 
 /*
@@ -503,220 +819,524 @@ func (z *ZsysLogServer) LoggingLevel(req *LoggingLevelRequest, stream Zsys_Loggi
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.LoggingLevel(req, &zsysLoggingLevelLogStream{
-		Zsys_LoggingLevelServer: stream,
-		ctx:                     ctx,
+	err = z.ZsysServerIdleTimeout.LoggingLevel(req, &zsysLoggingLevelLogStream{
+		Zsys_LoggingLevelServer: stream,
+		ctx:                     ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "LoggingLevel", err)
+	return err
+}
+
+/*
+ * Zsys.Refresh()
+ */
+
+// zsysRefreshLogStream is a Zsys_RefreshServer augmented by its own Context containing the log streamer
+type zsysRefreshLogStream struct {
+	Zsys_RefreshServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRefreshLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// Refresh overrides ZsysServer Refresh, installing a logger first
+func (z *ZsysLogServer) Refresh(req *Empty, stream Zsys_RefreshServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Refresh")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.Refresh(req, &zsysRefreshLogStream{
+		Zsys_RefreshServer: stream,
+		ctx:                ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "Refresh", err)
+	return err
+}
+
+/*
+ * Zsys.Trace()
+ */
+
+// zsysTraceLogStream is a Zsys_TraceServer augmented by its own Context containing the log streamer
+type zsysTraceLogStream struct {
+	Zsys_TraceServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysTraceLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// Trace overrides ZsysServer Trace, installing a logger first
+func (z *ZsysLogServer) Trace(req *TraceRequest, stream Zsys_TraceServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Trace")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.Trace(req, &zsysTraceLogStream{
+		Zsys_TraceServer: stream,
+		ctx:              ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "Trace", err)
+	return err
+}
+
+/*
+ * Zsys.Status()
+ */
+
+// zsysStatusLogStream is a Zsys_StatusServer augmented by its own Context containing the log streamer
+type zsysStatusLogStream struct {
+	Zsys_StatusServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysStatusLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// Status overrides ZsysServer Status, installing a logger first
+func (z *ZsysLogServer) Status(req *Empty, stream Zsys_StatusServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Status")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.Status(req, &zsysStatusLogStream{
+		Zsys_StatusServer: stream,
+		ctx:               ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "Status", err)
+	return err
+}
+
+/*
+ * Zsys.Reload()
+ */
+
+// zsysReloadLogStream is a Zsys_ReloadServer augmented by its own Context containing the log streamer
+type zsysReloadLogStream struct {
+	Zsys_ReloadServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysReloadLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// Reload overrides ZsysServer Reload, installing a logger first
+func (z *ZsysLogServer) Reload(req *Empty, stream Zsys_ReloadServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Reload")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.Reload(req, &zsysReloadLogStream{
+		Zsys_ReloadServer: stream,
+		ctx:               ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "Reload", err)
+	return err
+}
+
+/*
+ * Zsys.GC()
+ */
+
+// zsysGCLogStream is a Zsys_GCServer augmented by its own Context containing the log streamer
+type zsysGCLogStream struct {
+	Zsys_GCServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysGCLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// GC overrides ZsysServer GC, installing a logger first
+func (z *ZsysLogServer) GC(req *GCRequest, stream Zsys_GCServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "GC")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.GC(req, &zsysGCLogStream{
+		Zsys_GCServer: stream,
+		ctx:           ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "GC", err)
+	return err
+}
+
+/*
+ * Zsys.MachineShow()
+ */
+
+// zsysMachineShowLogStream is a Zsys_MachineShowServer augmented by its own Context containing the log streamer
+type zsysMachineShowLogStream struct {
+	Zsys_MachineShowServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysMachineShowLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// MachineShow overrides ZsysServer MachineShow, installing a logger first
+func (z *ZsysLogServer) MachineShow(req *MachineShowRequest, stream Zsys_MachineShowServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineShow")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.MachineShow(req, &zsysMachineShowLogStream{
+		Zsys_MachineShowServer: stream,
+		ctx:                    ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "MachineShow", err)
+	return err
+}
+
+/*
+ * Zsys.MachineList()
+ */
+
+// zsysMachineListLogStream is a Zsys_MachineListServer augmented by its own Context containing the log streamer
+type zsysMachineListLogStream struct {
+	Zsys_MachineListServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysMachineListLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// MachineList overrides ZsysServer MachineList, installing a logger first
+func (z *ZsysLogServer) MachineList(req *Empty, stream Zsys_MachineListServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineList")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.MachineList(req, &zsysMachineListLogStream{
+		Zsys_MachineListServer: stream,
+		ctx:                    ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "MachineList", err)
+	return err
+}
+
+/*
+ * Zsys.MachineClone()
+ */
+
+// zsysMachineCloneLogStream is a Zsys_MachineCloneServer augmented by its own Context containing the log streamer
+type zsysMachineCloneLogStream struct {
+	Zsys_MachineCloneServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysMachineCloneLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// MachineClone overrides ZsysServer MachineClone, installing a logger first
+func (z *ZsysLogServer) MachineClone(req *MachineCloneRequest, stream Zsys_MachineCloneServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineClone")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.MachineClone(req, &zsysMachineCloneLogStream{
+		Zsys_MachineCloneServer: stream,
+		ctx:                     ctx,
+	})
+	streamlogger.LogRequestEnd(ctx, "MachineClone", err)
+	return err
+}
+
+/*
+ * Zsys.MachineRemove()
+ */
+
+// zsysMachineRemoveLogStream is a Zsys_MachineRemoveServer augmented by its own Context containing the log streamer
+type zsysMachineRemoveLogStream struct {
+	Zsys_MachineRemoveServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysMachineRemoveLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// MachineRemove overrides ZsysServer MachineRemove, installing a logger first
+func (z *ZsysLogServer) MachineRemove(req *MachineRemoveRequest, stream Zsys_MachineRemoveServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineRemove")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	err = z.ZsysServerIdleTimeout.MachineRemove(req, &zsysMachineRemoveLogStream{
+		Zsys_MachineRemoveServer: stream,
+		ctx:                      ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "MachineRemove", err)
+	return err
 }
 
 /*
- * Zsys.Refresh()
+ * Zsys.DatasetExclude()
  */
 
-// zsysRefreshLogStream is a Zsys_RefreshServer augmented by its own Context containing the log streamer
-type zsysRefreshLogStream struct {
-	Zsys_RefreshServer
+// zsysDatasetExcludeLogStream is a Zsys_DatasetExcludeServer augmented by its own Context containing the log streamer
+type zsysDatasetExcludeLogStream struct {
+	Zsys_DatasetExcludeServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysRefreshLogStream) Context() context.Context {
+func (s *zsysDatasetExcludeLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// Refresh overrides ZsysServer Refresh, installing a logger first
-func (z *ZsysLogServer) Refresh(req *Empty, stream Zsys_RefreshServer) error {
+// DatasetExclude overrides ZsysServer DatasetExclude, installing a logger first
+func (z *ZsysLogServer) DatasetExclude(req *DatasetExcludeRequest, stream Zsys_DatasetExcludeServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Refresh")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "DatasetExclude")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.Refresh(req, &zsysRefreshLogStream{
-		Zsys_RefreshServer: stream,
-		ctx:                ctx,
+	err = z.ZsysServerIdleTimeout.DatasetExclude(req, &zsysDatasetExcludeLogStream{
+		Zsys_DatasetExcludeServer: stream,
+		ctx:                       ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DatasetExclude", err)
+	return err
 }
 
 /*
- * Zsys.Trace()
+ * Zsys.DatasetList()
  */
 
-// zsysTraceLogStream is a Zsys_TraceServer augmented by its own Context containing the log streamer
-type zsysTraceLogStream struct {
-	Zsys_TraceServer
+// zsysDatasetListLogStream is a Zsys_DatasetListServer augmented by its own Context containing the log streamer
+type zsysDatasetListLogStream struct {
+	Zsys_DatasetListServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysTraceLogStream) Context() context.Context {
+func (s *zsysDatasetListLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// Trace overrides ZsysServer Trace, installing a logger first
-func (z *ZsysLogServer) Trace(req *TraceRequest, stream Zsys_TraceServer) error {
+// DatasetList overrides ZsysServer DatasetList, installing a logger first
+func (z *ZsysLogServer) DatasetList(req *Empty, stream Zsys_DatasetListServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Trace")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "DatasetList")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.Trace(req, &zsysTraceLogStream{
-		Zsys_TraceServer: stream,
-		ctx:              ctx,
+	err = z.ZsysServerIdleTimeout.DatasetList(req, &zsysDatasetListLogStream{
+		Zsys_DatasetListServer: stream,
+		ctx:                    ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DatasetList", err)
+	return err
 }
 
 /*
- * Zsys.Status()
+ * Zsys.DatasetCreatePersistent()
  */
 
-// zsysStatusLogStream is a Zsys_StatusServer augmented by its own Context containing the log streamer
-type zsysStatusLogStream struct {
-	Zsys_StatusServer
+// zsysDatasetCreatePersistentLogStream is a Zsys_DatasetCreatePersistentServer augmented by its own Context containing the log streamer
+type zsysDatasetCreatePersistentLogStream struct {
+	Zsys_DatasetCreatePersistentServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysStatusLogStream) Context() context.Context {
+func (s *zsysDatasetCreatePersistentLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// Status overrides ZsysServer Status, installing a logger first
-func (z *ZsysLogServer) Status(req *Empty, stream Zsys_StatusServer) error {
+// DatasetCreatePersistent overrides ZsysServer DatasetCreatePersistent, installing a logger first
+func (z *ZsysLogServer) DatasetCreatePersistent(req *DatasetPersistentRequest, stream Zsys_DatasetCreatePersistentServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Status")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "DatasetCreatePersistent")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.Status(req, &zsysStatusLogStream{
-		Zsys_StatusServer: stream,
-		ctx:               ctx,
+	err = z.ZsysServerIdleTimeout.DatasetCreatePersistent(req, &zsysDatasetCreatePersistentLogStream{
+		Zsys_DatasetCreatePersistentServer: stream,
+		ctx:                                ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DatasetCreatePersistent", err)
+	return err
 }
 
 /*
- * Zsys.Reload()
+ * Zsys.DatasetMakePersistent()
  */
 
-// zsysReloadLogStream is a Zsys_ReloadServer augmented by its own Context containing the log streamer
-type zsysReloadLogStream struct {
-	Zsys_ReloadServer
+// zsysDatasetMakePersistentLogStream is a Zsys_DatasetMakePersistentServer augmented by its own Context containing the log streamer
+type zsysDatasetMakePersistentLogStream struct {
+	Zsys_DatasetMakePersistentServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysReloadLogStream) Context() context.Context {
+func (s *zsysDatasetMakePersistentLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// Reload overrides ZsysServer Reload, installing a logger first
-func (z *ZsysLogServer) Reload(req *Empty, stream Zsys_ReloadServer) error {
+// DatasetMakePersistent overrides ZsysServer DatasetMakePersistent, installing a logger first
+func (z *ZsysLogServer) DatasetMakePersistent(req *DatasetPersistentRequest, stream Zsys_DatasetMakePersistentServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Reload")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "DatasetMakePersistent")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.Reload(req, &zsysReloadLogStream{
-		Zsys_ReloadServer: stream,
-		ctx:               ctx,
+	err = z.ZsysServerIdleTimeout.DatasetMakePersistent(req, &zsysDatasetMakePersistentLogStream{
+		Zsys_DatasetMakePersistentServer: stream,
+		ctx:                              ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "DatasetMakePersistent", err)
+	return err
 }
 
 /*
- * Zsys.GC()
+ * Zsys.WatchEvents()
  */
 
-// zsysGCLogStream is a Zsys_GCServer augmented by its own Context containing the log streamer
-type zsysGCLogStream struct {
-	Zsys_GCServer
+// zsysWatchEventsLogStream is a Zsys_WatchEventsServer augmented by its own Context containing the log streamer
+type zsysWatchEventsLogStream struct {
+	Zsys_WatchEventsServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysGCLogStream) Context() context.Context {
+func (s *zsysWatchEventsLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// GC overrides ZsysServer GC, installing a logger first
-func (z *ZsysLogServer) GC(req *GCRequest, stream Zsys_GCServer) error {
+// WatchEvents overrides ZsysServer WatchEvents, installing a logger first
+func (z *ZsysLogServer) WatchEvents(req *Empty, stream Zsys_WatchEventsServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "GC")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "WatchEvents")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.GC(req, &zsysGCLogStream{
-		Zsys_GCServer: stream,
-		ctx:           ctx,
+	err = z.ZsysServerIdleTimeout.WatchEvents(req, &zsysWatchEventsLogStream{
+		Zsys_WatchEventsServer: stream,
+		ctx:                    ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "WatchEvents", err)
+	return err
 }
 
 /*
- * Zsys.MachineShow()
+ * Zsys.History()
  */
 
-// zsysMachineShowLogStream is a Zsys_MachineShowServer augmented by its own Context containing the log streamer
-type zsysMachineShowLogStream struct {
-	Zsys_MachineShowServer
+// zsysHistoryLogStream is a Zsys_HistoryServer augmented by its own Context containing the log streamer
+type zsysHistoryLogStream struct {
+	Zsys_HistoryServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysMachineShowLogStream) Context() context.Context {
+func (s *zsysHistoryLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// MachineShow overrides ZsysServer MachineShow, installing a logger first
-func (z *ZsysLogServer) MachineShow(req *MachineShowRequest, stream Zsys_MachineShowServer) error {
+// History overrides ZsysServer History, installing a logger first
+func (z *ZsysLogServer) History(req *HistoryRequest, stream Zsys_HistoryServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineShow")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "History")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.MachineShow(req, &zsysMachineShowLogStream{
-		Zsys_MachineShowServer: stream,
-		ctx:                    ctx,
+	err = z.ZsysServerIdleTimeout.History(req, &zsysHistoryLogStream{
+		Zsys_HistoryServer: stream,
+		ctx:                ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "History", err)
+	return err
 }
 
 /*
- * Zsys.MachineList()
+ * Zsys.Metrics()
  */
 
-// zsysMachineListLogStream is a Zsys_MachineListServer augmented by its own Context containing the log streamer
-type zsysMachineListLogStream struct {
-	Zsys_MachineListServer
+// zsysMetricsLogStream is a Zsys_MetricsServer augmented by its own Context containing the log streamer
+type zsysMetricsLogStream struct {
+	Zsys_MetricsServer
 	ctx context.Context
 }
 
 // Context access the log streamer context
-func (s *zsysMachineListLogStream) Context() context.Context {
+func (s *zsysMetricsLogStream) Context() context.Context {
 	return s.ctx
 }
 
-// MachineList overrides ZsysServer MachineList, installing a logger first
-func (z *ZsysLogServer) MachineList(req *Empty, stream Zsys_MachineListServer) error {
+// Metrics overrides ZsysServer Metrics, installing a logger first
+func (z *ZsysLogServer) Metrics(req *Empty, stream Zsys_MetricsServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
-	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineList")
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Metrics")
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
 	}
 
 	// wrap the context to access the context with logger
-	return z.ZsysServerIdleTimeout.MachineList(req, &zsysMachineListLogStream{
-		Zsys_MachineListServer: stream,
-		ctx:                    ctx,
+	err = z.ZsysServerIdleTimeout.Metrics(req, &zsysMetricsLogStream{
+		Zsys_MetricsServer: stream,
+		ctx:                ctx,
 	})
+	streamlogger.LogRequestEnd(ctx, "Metrics", err)
+	return err
 }
 
 /*
@@ -879,6 +1499,123 @@ func (s *zsysRemoveUserStateServer) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Write promote zsysRevertSystemStateServer to an io.Writer
+func (s *zsysRevertSystemStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysAnnotateSystemStateServer to an io.Writer
+func (s *zsysAnnotateSystemStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysAnnotateUserStateServer to an io.Writer
+func (s *zsysAnnotateUserStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysPinSystemStateServer to an io.Writer
+func (s *zsysPinSystemStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysPinUserStateServer to an io.Writer
+func (s *zsysPinUserStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysStateDiffServer to an io.Writer
+func (s *zsysStateDiffServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&StateDiffResponse{
+			Reply: &StateDiffResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysExportSystemStateServer to an io.Writer
+func (s *zsysExportSystemStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&ExportSystemStateResponse{
+			Reply: &ExportSystemStateResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysImportSystemStateServer to an io.Writer
+func (s *zsysImportSystemStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&CreateSaveStateResponse{
+			Reply: &CreateSaveStateResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysReplicateStatesServer to an io.Writer
+func (s *zsysReplicateStatesServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 // Write promote zsysDumpStatesServer to an io.Writer
 func (s *zsysDumpStatesServer) Write(p []byte) (n int, err error) {
 	err = s.Send(
@@ -1008,3 +1745,120 @@ func (s *zsysMachineListServer) Write(p []byte) (n int, err error) {
 
 	return len(p), nil
 }
+
+// Write promote zsysMachineCloneServer to an io.Writer
+func (s *zsysMachineCloneServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&MachineCloneResponse{
+			Reply: &MachineCloneResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysMachineRemoveServer to an io.Writer
+func (s *zsysMachineRemoveServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysDatasetExcludeServer to an io.Writer
+func (s *zsysDatasetExcludeServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&DatasetExcludeResponse{
+			Reply: &DatasetExcludeResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysDatasetListServer to an io.Writer
+func (s *zsysDatasetListServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&DatasetListResponse{
+			Reply: &DatasetListResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysDatasetCreatePersistentServer to an io.Writer
+func (s *zsysDatasetCreatePersistentServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&DatasetPersistentResponse{
+			Reply: &DatasetPersistentResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysDatasetMakePersistentServer to an io.Writer
+func (s *zsysDatasetMakePersistentServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&DatasetPersistentResponse{
+			Reply: &DatasetPersistentResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysWatchEventsServer to an io.Writer
+func (s *zsysWatchEventsServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&WatchEventsResponse{
+			Reply: &WatchEventsResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysHistoryServer to an io.Writer
+func (s *zsysHistoryServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&HistoryResponse{
+			Reply: &HistoryResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysMetricsServer to an io.Writer
+func (s *zsysMetricsServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&MetricsResponse{
+			Reply: &MetricsResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
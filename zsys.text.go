@@ -0,0 +1,217 @@
+package zsys
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/units"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Text returns detailed machine informations, formatted for display.
+func (m *Machine) Text(full bool) (string, error) {
+	var out bytes.Buffer
+	w := tabwriter.NewWriter(&out, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, i18n.G("Name:\t%s\n"), m.GetId())
+	fmt.Fprintf(w, i18n.G("ZSys:\t%t\n"), m.GetIsZsys())
+	if m.GetNextBoot() != "" {
+		if m.GetNextBootRevertUserData() {
+			fmt.Fprintf(w, i18n.G("Next boot:\t%s (reverting user data)\n"), m.GetNextBoot())
+		} else {
+			fmt.Fprintf(w, i18n.G("Next boot:\t%s\n"), m.GetNextBoot())
+		}
+	}
+
+	// Main machine state
+	stateToWriter(w, m.GetState(), false, full)
+
+	if full {
+		if len(m.GetPersistentDatasets()) == 0 {
+			fmt.Fprintf(w, i18n.G("Persistent Datasets: None\n"))
+		} else {
+			fmt.Fprintf(w, i18n.G("Persistent Datasets:\n"))
+			for _, n := range m.GetPersistentDatasets() {
+				fmt.Fprintf(w, i18n.G(" - %s\n"), n.GetName())
+			}
+		}
+		if len(m.GetBookmarks()) > 0 {
+			fmt.Fprintf(w, i18n.G("Bookmarks:\n"))
+			for _, b := range m.GetBookmarks() {
+				fmt.Fprintf(w, i18n.G(" - %s (%s)\n"), b.GetName(), FormatTime(b.GetLastUsed()))
+			}
+		}
+	}
+
+	// History, already sorted from most recent
+	if len(m.GetHistory()) > 0 {
+		fmt.Fprintf(w, i18n.G("History:\t\n"))
+	}
+	for _, s := range m.GetHistory() {
+		stateToWriter(w, s, true, full)
+	}
+
+	// Users
+	userHistory := make(map[string][]*UserState)
+	nStates := make(map[string]int)
+	for _, us := range m.GetState().GetUsers() {
+		nStates[us.GetUser()]++
+	}
+	for _, us := range m.GetUserHistory() {
+		userHistory[us.GetUser()] = append(userHistory[us.GetUser()], us)
+		nStates[us.GetUser()]++
+	}
+	var users []string
+	for u := range nStates {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+
+	fmt.Fprintf(w, i18n.G("Users:\n"))
+
+	for _, user := range users {
+		fmt.Fprintf(w, i18n.G("  - Name:\t%s\n"), user)
+
+		if nStates[user] > 1 {
+			fmt.Fprintf(w, i18n.G("    History:\t\n"))
+		}
+
+		for _, s := range userHistory[user] {
+			if full {
+				var ud []string
+				for _, d := range s.GetDatasets() {
+					ud = append(ud, d.GetName())
+				}
+				fmt.Fprintf(w, i18n.G("     - %s (%s, %s used)%s%s: %s\n"), s.GetId(), FormatTime(s.GetLastUsed()), units.HumanSize(s.GetUsed()),
+					lockedMark(s.GetLocked()), annotations(s.GetPinned(), s.GetLabel(), s.GetDescription()), strings.Join(ud, ", "))
+				continue
+			}
+			fmt.Fprintf(w, i18n.G("     - %s (%s)%s%s\n"), s.GetId(), FormatTime(s.GetLastUsed()), lockedMark(s.GetLocked()), annotations(s.GetPinned(), s.GetLabel(), s.GetDescription()))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// stateToWriter forwards system state information to a writer
+func stateToWriter(w io.Writer, s *State, isHistory, full bool) {
+	var prefix string
+	if isHistory {
+		fmt.Fprintf(w, i18n.G("  - Name:\t%s\n"), s.GetId())
+	}
+	lu := FormatTime(s.GetLastUsed())
+
+	if isHistory {
+		prefix = "    "
+	}
+	if !isHistory {
+		for _, d := range s.GetSystemDatasets() {
+			if d.GetName() == s.GetId() && d.GetMounted() {
+				lu = i18n.G("current")
+			}
+		}
+		fmt.Fprintf(w, i18n.G("%sLast Used:\t%s\n"), prefix, lu)
+	} else {
+		fmt.Fprintf(w, i18n.G("%sCreated on:\t%s\n"), prefix, lu)
+	}
+	if s.GetPinned() {
+		fmt.Fprintf(w, i18n.G("%sPinned:\tyes\n"), prefix)
+	}
+	if s.GetLabel() != "" {
+		fmt.Fprintf(w, i18n.G("%sLabel:\t%s\n"), prefix, s.GetLabel())
+	}
+	if s.GetDescription() != "" {
+		fmt.Fprintf(w, i18n.G("%sDescription:\t%s\n"), prefix, s.GetDescription())
+	}
+	if s.GetEncrypted() {
+		encrypted := i18n.G("yes")
+		if s.GetLocked() {
+			encrypted = i18n.G("yes (locked)")
+		}
+		fmt.Fprintf(w, i18n.G("%sEncrypted:\t%s\n"), prefix, encrypted)
+	}
+
+	if full {
+		fmt.Fprintf(w, i18n.G("%sLast Booted Kernel:\t%s\n"), prefix, s.GetLastBootedKernel())
+		fmt.Fprintf(w, i18n.G("%sUsed:\t%s\n"), prefix, units.HumanSize(s.GetUsed()))
+		fmt.Fprintf(w, i18n.G("%sReferenced:\t%s\n"), prefix, units.HumanSize(s.GetReferenced()))
+		fmt.Fprintf(w, i18n.G("%sWritten:\t%s\n"), prefix, units.HumanSize(s.GetWritten()))
+		fmt.Fprintf(w, i18n.G("%sSystem Datasets:\n"), prefix)
+
+		for _, d := range s.GetSystemDatasets() {
+			fmt.Fprintf(w, i18n.G("%s\t- %s\n"), prefix, d.GetName())
+		}
+
+		if len(s.GetUsers()) > 0 {
+			fmt.Fprintf(w, i18n.G("%sUser Datasets:\n"), prefix)
+			for _, us := range s.GetUsers() {
+				fmt.Fprintf(w, i18n.G("%s\tUser: %s\n"), prefix, us.GetUser())
+				for _, d := range us.GetDatasets() {
+					fmt.Fprintf(w, i18n.G("%s\t- %s\n"), prefix, d.GetName())
+				}
+			}
+		}
+	}
+}
+
+// Text returns all the machines and a summary, formatted for display, current machine being first.
+func (ms *Machines) Text() (string, error) {
+	var out bytes.Buffer
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprint(w, i18n.G("ID\tZSys\tLast Used\n"))
+	fmt.Fprint(w, i18n.G("--\t----\t---------\n"))
+
+	for _, m := range ms.GetMachines() {
+		lu := FormatTime(m.GetState().GetLastUsed())
+		if m.GetIsCurrent() {
+			lu = i18n.G("current")
+		}
+		fmt.Fprintf(w, i18n.G("%s\t%t\t%s\n"), m.GetId(), m.GetIsZsys(), lu)
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// FormatTime returns a local time representation of t for display.
+func FormatTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return time.Time{}.Format("2006-01-02 15:04:05")
+	}
+	return t.AsTime().Local().Format("2006-01-02 15:04:05")
+}
+
+// lockedMark returns a compact mark for a state which encryption key isn't loaded, prefixed with a space if not empty.
+func lockedMark(locked bool) string {
+	if !locked {
+		return ""
+	}
+	return i18n.G(" (locked)")
+}
+
+// annotations returns a compact representation of a state pin, label and description, prefixed with a space if not empty.
+func annotations(pinned bool, label, description string) string {
+	var r string
+	if pinned {
+		r += i18n.G(" (pinned)")
+	}
+	if label != "" {
+		r += fmt.Sprintf(" [%s]", label)
+	}
+	if description != "" {
+		r += fmt.Sprintf(" %q", description)
+	}
+	return r
+}
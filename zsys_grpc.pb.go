@@ -19,28 +19,46 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Zsys_Version_FullMethodName              = "/zsys.Zsys/Version"
-	Zsys_CreateUserData_FullMethodName       = "/zsys.Zsys/CreateUserData"
-	Zsys_ChangeHomeOnUserData_FullMethodName = "/zsys.Zsys/ChangeHomeOnUserData"
-	Zsys_DissociateUser_FullMethodName       = "/zsys.Zsys/DissociateUser"
-	Zsys_PrepareBoot_FullMethodName          = "/zsys.Zsys/PrepareBoot"
-	Zsys_CommitBoot_FullMethodName           = "/zsys.Zsys/CommitBoot"
-	Zsys_UpdateBootMenu_FullMethodName       = "/zsys.Zsys/UpdateBootMenu"
-	Zsys_UpdateLastUsed_FullMethodName       = "/zsys.Zsys/UpdateLastUsed"
-	Zsys_SaveSystemState_FullMethodName      = "/zsys.Zsys/SaveSystemState"
-	Zsys_SaveUserState_FullMethodName        = "/zsys.Zsys/SaveUserState"
-	Zsys_RemoveSystemState_FullMethodName    = "/zsys.Zsys/RemoveSystemState"
-	Zsys_RemoveUserState_FullMethodName      = "/zsys.Zsys/RemoveUserState"
-	Zsys_DumpStates_FullMethodName           = "/zsys.Zsys/DumpStates"
-	Zsys_DaemonStop_FullMethodName           = "/zsys.Zsys/DaemonStop"
-	Zsys_LoggingLevel_FullMethodName         = "/zsys.Zsys/LoggingLevel"
-	Zsys_Refresh_FullMethodName              = "/zsys.Zsys/Refresh"
-	Zsys_Trace_FullMethodName                = "/zsys.Zsys/Trace"
-	Zsys_Status_FullMethodName               = "/zsys.Zsys/Status"
-	Zsys_Reload_FullMethodName               = "/zsys.Zsys/Reload"
-	Zsys_GC_FullMethodName                   = "/zsys.Zsys/GC"
-	Zsys_MachineShow_FullMethodName          = "/zsys.Zsys/MachineShow"
-	Zsys_MachineList_FullMethodName          = "/zsys.Zsys/MachineList"
+	Zsys_Version_FullMethodName                 = "/zsys.Zsys/Version"
+	Zsys_CreateUserData_FullMethodName          = "/zsys.Zsys/CreateUserData"
+	Zsys_ChangeHomeOnUserData_FullMethodName    = "/zsys.Zsys/ChangeHomeOnUserData"
+	Zsys_DissociateUser_FullMethodName          = "/zsys.Zsys/DissociateUser"
+	Zsys_PrepareBoot_FullMethodName             = "/zsys.Zsys/PrepareBoot"
+	Zsys_CommitBoot_FullMethodName              = "/zsys.Zsys/CommitBoot"
+	Zsys_UpdateBootMenu_FullMethodName          = "/zsys.Zsys/UpdateBootMenu"
+	Zsys_UpdateLastUsed_FullMethodName          = "/zsys.Zsys/UpdateLastUsed"
+	Zsys_SaveSystemState_FullMethodName         = "/zsys.Zsys/SaveSystemState"
+	Zsys_SaveUserState_FullMethodName           = "/zsys.Zsys/SaveUserState"
+	Zsys_RemoveSystemState_FullMethodName       = "/zsys.Zsys/RemoveSystemState"
+	Zsys_RemoveUserState_FullMethodName         = "/zsys.Zsys/RemoveUserState"
+	Zsys_RevertSystemState_FullMethodName       = "/zsys.Zsys/RevertSystemState"
+	Zsys_AnnotateSystemState_FullMethodName     = "/zsys.Zsys/AnnotateSystemState"
+	Zsys_AnnotateUserState_FullMethodName       = "/zsys.Zsys/AnnotateUserState"
+	Zsys_PinSystemState_FullMethodName          = "/zsys.Zsys/PinSystemState"
+	Zsys_PinUserState_FullMethodName            = "/zsys.Zsys/PinUserState"
+	Zsys_StateDiff_FullMethodName               = "/zsys.Zsys/StateDiff"
+	Zsys_ExportSystemState_FullMethodName       = "/zsys.Zsys/ExportSystemState"
+	Zsys_ImportSystemState_FullMethodName       = "/zsys.Zsys/ImportSystemState"
+	Zsys_ReplicateStates_FullMethodName         = "/zsys.Zsys/ReplicateStates"
+	Zsys_DumpStates_FullMethodName              = "/zsys.Zsys/DumpStates"
+	Zsys_DaemonStop_FullMethodName              = "/zsys.Zsys/DaemonStop"
+	Zsys_LoggingLevel_FullMethodName            = "/zsys.Zsys/LoggingLevel"
+	Zsys_Refresh_FullMethodName                 = "/zsys.Zsys/Refresh"
+	Zsys_Trace_FullMethodName                   = "/zsys.Zsys/Trace"
+	Zsys_Status_FullMethodName                  = "/zsys.Zsys/Status"
+	Zsys_Reload_FullMethodName                  = "/zsys.Zsys/Reload"
+	Zsys_GC_FullMethodName                      = "/zsys.Zsys/GC"
+	Zsys_MachineShow_FullMethodName             = "/zsys.Zsys/MachineShow"
+	Zsys_MachineList_FullMethodName             = "/zsys.Zsys/MachineList"
+	Zsys_MachineClone_FullMethodName            = "/zsys.Zsys/MachineClone"
+	Zsys_MachineRemove_FullMethodName           = "/zsys.Zsys/MachineRemove"
+	Zsys_DatasetExclude_FullMethodName          = "/zsys.Zsys/DatasetExclude"
+	Zsys_DatasetList_FullMethodName             = "/zsys.Zsys/DatasetList"
+	Zsys_DatasetCreatePersistent_FullMethodName = "/zsys.Zsys/DatasetCreatePersistent"
+	Zsys_DatasetMakePersistent_FullMethodName   = "/zsys.Zsys/DatasetMakePersistent"
+	Zsys_WatchEvents_FullMethodName             = "/zsys.Zsys/WatchEvents"
+	Zsys_History_FullMethodName                 = "/zsys.Zsys/History"
+	Zsys_Metrics_FullMethodName                 = "/zsys.Zsys/Metrics"
 )
 
 // ZsysClient is the client API for Zsys service.
@@ -59,6 +77,15 @@ type ZsysClient interface {
 	SaveUserState(ctx context.Context, in *SaveUserStateRequest, opts ...grpc.CallOption) (Zsys_SaveUserStateClient, error)
 	RemoveSystemState(ctx context.Context, in *RemoveSystemStateRequest, opts ...grpc.CallOption) (Zsys_RemoveSystemStateClient, error)
 	RemoveUserState(ctx context.Context, in *RemoveUserStateRequest, opts ...grpc.CallOption) (Zsys_RemoveUserStateClient, error)
+	RevertSystemState(ctx context.Context, in *RevertSystemStateRequest, opts ...grpc.CallOption) (Zsys_RevertSystemStateClient, error)
+	AnnotateSystemState(ctx context.Context, in *AnnotateSystemStateRequest, opts ...grpc.CallOption) (Zsys_AnnotateSystemStateClient, error)
+	AnnotateUserState(ctx context.Context, in *AnnotateUserStateRequest, opts ...grpc.CallOption) (Zsys_AnnotateUserStateClient, error)
+	PinSystemState(ctx context.Context, in *PinSystemStateRequest, opts ...grpc.CallOption) (Zsys_PinSystemStateClient, error)
+	PinUserState(ctx context.Context, in *PinUserStateRequest, opts ...grpc.CallOption) (Zsys_PinUserStateClient, error)
+	StateDiff(ctx context.Context, in *StateDiffRequest, opts ...grpc.CallOption) (Zsys_StateDiffClient, error)
+	ExportSystemState(ctx context.Context, in *ExportSystemStateRequest, opts ...grpc.CallOption) (Zsys_ExportSystemStateClient, error)
+	ImportSystemState(ctx context.Context, opts ...grpc.CallOption) (Zsys_ImportSystemStateClient, error)
+	ReplicateStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReplicateStatesClient, error)
 	DumpStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DumpStatesClient, error)
 	DaemonStop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DaemonStopClient, error)
 	LoggingLevel(ctx context.Context, in *LoggingLevelRequest, opts ...grpc.CallOption) (Zsys_LoggingLevelClient, error)
@@ -69,6 +96,15 @@ type ZsysClient interface {
 	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error)
 	MachineShow(ctx context.Context, in *MachineShowRequest, opts ...grpc.CallOption) (Zsys_MachineShowClient, error)
 	MachineList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MachineListClient, error)
+	MachineClone(ctx context.Context, in *MachineCloneRequest, opts ...grpc.CallOption) (Zsys_MachineCloneClient, error)
+	MachineRemove(ctx context.Context, in *MachineRemoveRequest, opts ...grpc.CallOption) (Zsys_MachineRemoveClient, error)
+	DatasetExclude(ctx context.Context, in *DatasetExcludeRequest, opts ...grpc.CallOption) (Zsys_DatasetExcludeClient, error)
+	DatasetList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DatasetListClient, error)
+	DatasetCreatePersistent(ctx context.Context, in *DatasetPersistentRequest, opts ...grpc.CallOption) (Zsys_DatasetCreatePersistentClient, error)
+	DatasetMakePersistent(ctx context.Context, in *DatasetPersistentRequest, opts ...grpc.CallOption) (Zsys_DatasetMakePersistentClient, error)
+	WatchEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_WatchEventsClient, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Zsys_HistoryClient, error)
+	Metrics(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MetricsClient, error)
 }
 
 type zsysClient struct {
@@ -463,8 +499,295 @@ func (x *zsysRemoveUserStateClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
+func (c *zsysClient) RevertSystemState(ctx context.Context, in *RevertSystemStateRequest, opts ...grpc.CallOption) (Zsys_RevertSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[12], Zsys_RevertSystemState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysRevertSystemStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_RevertSystemStateClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysRevertSystemStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysRevertSystemStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) AnnotateSystemState(ctx context.Context, in *AnnotateSystemStateRequest, opts ...grpc.CallOption) (Zsys_AnnotateSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[13], Zsys_AnnotateSystemState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysAnnotateSystemStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_AnnotateSystemStateClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysAnnotateSystemStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysAnnotateSystemStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) AnnotateUserState(ctx context.Context, in *AnnotateUserStateRequest, opts ...grpc.CallOption) (Zsys_AnnotateUserStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[14], Zsys_AnnotateUserState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysAnnotateUserStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_AnnotateUserStateClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysAnnotateUserStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysAnnotateUserStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) PinSystemState(ctx context.Context, in *PinSystemStateRequest, opts ...grpc.CallOption) (Zsys_PinSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[15], Zsys_PinSystemState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysPinSystemStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_PinSystemStateClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysPinSystemStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysPinSystemStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) PinUserState(ctx context.Context, in *PinUserStateRequest, opts ...grpc.CallOption) (Zsys_PinUserStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[16], Zsys_PinUserState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysPinUserStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_PinUserStateClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysPinUserStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysPinUserStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) StateDiff(ctx context.Context, in *StateDiffRequest, opts ...grpc.CallOption) (Zsys_StateDiffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[17], Zsys_StateDiff_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysStateDiffClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_StateDiffClient interface {
+	Recv() (*StateDiffResponse, error)
+	grpc.ClientStream
+}
+
+type zsysStateDiffClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysStateDiffClient) Recv() (*StateDiffResponse, error) {
+	m := new(StateDiffResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) ExportSystemState(ctx context.Context, in *ExportSystemStateRequest, opts ...grpc.CallOption) (Zsys_ExportSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[18], Zsys_ExportSystemState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysExportSystemStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_ExportSystemStateClient interface {
+	Recv() (*ExportSystemStateResponse, error)
+	grpc.ClientStream
+}
+
+type zsysExportSystemStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysExportSystemStateClient) Recv() (*ExportSystemStateResponse, error) {
+	m := new(ExportSystemStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) ImportSystemState(ctx context.Context, opts ...grpc.CallOption) (Zsys_ImportSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[19], Zsys_ImportSystemState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysImportSystemStateClient{stream}
+	return x, nil
+}
+
+type Zsys_ImportSystemStateClient interface {
+	Send(*ImportSystemStateRequest) error
+	Recv() (*CreateSaveStateResponse, error)
+	grpc.ClientStream
+}
+
+type zsysImportSystemStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysImportSystemStateClient) Send(m *ImportSystemStateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *zsysImportSystemStateClient) Recv() (*CreateSaveStateResponse, error) {
+	m := new(CreateSaveStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) ReplicateStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReplicateStatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[20], Zsys_ReplicateStates_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysReplicateStatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_ReplicateStatesClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysReplicateStatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysReplicateStatesClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *zsysClient) DumpStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DumpStatesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[12], Zsys_DumpStates_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[21], Zsys_DumpStates_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -496,7 +819,7 @@ func (x *zsysDumpStatesClient) Recv() (*DumpStatesResponse, error) {
 }
 
 func (c *zsysClient) DaemonStop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DaemonStopClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[13], Zsys_DaemonStop_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[22], Zsys_DaemonStop_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -528,7 +851,7 @@ func (x *zsysDaemonStopClient) Recv() (*LogResponse, error) {
 }
 
 func (c *zsysClient) LoggingLevel(ctx context.Context, in *LoggingLevelRequest, opts ...grpc.CallOption) (Zsys_LoggingLevelClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[14], Zsys_LoggingLevel_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[23], Zsys_LoggingLevel_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -551,7 +874,295 @@ type zsysLoggingLevelClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysLoggingLevelClient) Recv() (*LogResponse, error) {
+func (x *zsysLoggingLevelClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RefreshClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[24], Zsys_Refresh_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysRefreshClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_RefreshClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysRefreshClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysRefreshClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (Zsys_TraceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[25], Zsys_Trace_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysTraceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_TraceClient interface {
+	Recv() (*TraceResponse, error)
+	grpc.ClientStream
+}
+
+type zsysTraceClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysTraceClient) Recv() (*TraceResponse, error) {
+	m := new(TraceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_StatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[26], Zsys_Status_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_StatusClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysStatusClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) Reload(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[27], Zsys_Reload_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysReloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_ReloadClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysReloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysReloadClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[28], Zsys_GC_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysGCClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_GCClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysGCClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysGCClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) MachineShow(ctx context.Context, in *MachineShowRequest, opts ...grpc.CallOption) (Zsys_MachineShowClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[29], Zsys_MachineShow_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysMachineShowClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_MachineShowClient interface {
+	Recv() (*MachineShowResponse, error)
+	grpc.ClientStream
+}
+
+type zsysMachineShowClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysMachineShowClient) Recv() (*MachineShowResponse, error) {
+	m := new(MachineShowResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) MachineList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MachineListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[30], Zsys_MachineList_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysMachineListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_MachineListClient interface {
+	Recv() (*MachineListResponse, error)
+	grpc.ClientStream
+}
+
+type zsysMachineListClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysMachineListClient) Recv() (*MachineListResponse, error) {
+	m := new(MachineListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) MachineClone(ctx context.Context, in *MachineCloneRequest, opts ...grpc.CallOption) (Zsys_MachineCloneClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[31], Zsys_MachineClone_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysMachineCloneClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_MachineCloneClient interface {
+	Recv() (*MachineCloneResponse, error)
+	grpc.ClientStream
+}
+
+type zsysMachineCloneClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysMachineCloneClient) Recv() (*MachineCloneResponse, error) {
+	m := new(MachineCloneResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) MachineRemove(ctx context.Context, in *MachineRemoveRequest, opts ...grpc.CallOption) (Zsys_MachineRemoveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[32], Zsys_MachineRemove_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysMachineRemoveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_MachineRemoveClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysMachineRemoveClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysMachineRemoveClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -559,12 +1170,12 @@ func (x *zsysLoggingLevelClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RefreshClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[15], Zsys_Refresh_FullMethodName, opts...)
+func (c *zsysClient) DatasetExclude(ctx context.Context, in *DatasetExcludeRequest, opts ...grpc.CallOption) (Zsys_DatasetExcludeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[33], Zsys_DatasetExclude_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysRefreshClient{stream}
+	x := &zsysDatasetExcludeClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -574,29 +1185,29 @@ func (c *zsysClient) Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOp
 	return x, nil
 }
 
-type Zsys_RefreshClient interface {
-	Recv() (*LogResponse, error)
+type Zsys_DatasetExcludeClient interface {
+	Recv() (*DatasetExcludeResponse, error)
 	grpc.ClientStream
 }
 
-type zsysRefreshClient struct {
+type zsysDatasetExcludeClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysRefreshClient) Recv() (*LogResponse, error) {
-	m := new(LogResponse)
+func (x *zsysDatasetExcludeClient) Recv() (*DatasetExcludeResponse, error) {
+	m := new(DatasetExcludeResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (Zsys_TraceClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[16], Zsys_Trace_FullMethodName, opts...)
+func (c *zsysClient) DatasetList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DatasetListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[34], Zsys_DatasetList_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysTraceClient{stream}
+	x := &zsysDatasetListClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -606,29 +1217,29 @@ func (c *zsysClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.C
 	return x, nil
 }
 
-type Zsys_TraceClient interface {
-	Recv() (*TraceResponse, error)
+type Zsys_DatasetListClient interface {
+	Recv() (*DatasetListResponse, error)
 	grpc.ClientStream
 }
 
-type zsysTraceClient struct {
+type zsysDatasetListClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysTraceClient) Recv() (*TraceResponse, error) {
-	m := new(TraceResponse)
+func (x *zsysDatasetListClient) Recv() (*DatasetListResponse, error) {
+	m := new(DatasetListResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_StatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[17], Zsys_Status_FullMethodName, opts...)
+func (c *zsysClient) DatasetCreatePersistent(ctx context.Context, in *DatasetPersistentRequest, opts ...grpc.CallOption) (Zsys_DatasetCreatePersistentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[35], Zsys_DatasetCreatePersistent_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysStatusClient{stream}
+	x := &zsysDatasetCreatePersistentClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -638,29 +1249,29 @@ func (c *zsysClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOpt
 	return x, nil
 }
 
-type Zsys_StatusClient interface {
-	Recv() (*LogResponse, error)
+type Zsys_DatasetCreatePersistentClient interface {
+	Recv() (*DatasetPersistentResponse, error)
 	grpc.ClientStream
 }
 
-type zsysStatusClient struct {
+type zsysDatasetCreatePersistentClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysStatusClient) Recv() (*LogResponse, error) {
-	m := new(LogResponse)
+func (x *zsysDatasetCreatePersistentClient) Recv() (*DatasetPersistentResponse, error) {
+	m := new(DatasetPersistentResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) Reload(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReloadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[18], Zsys_Reload_FullMethodName, opts...)
+func (c *zsysClient) DatasetMakePersistent(ctx context.Context, in *DatasetPersistentRequest, opts ...grpc.CallOption) (Zsys_DatasetMakePersistentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[36], Zsys_DatasetMakePersistent_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysReloadClient{stream}
+	x := &zsysDatasetMakePersistentClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -670,29 +1281,29 @@ func (c *zsysClient) Reload(ctx context.Context, in *Empty, opts ...grpc.CallOpt
 	return x, nil
 }
 
-type Zsys_ReloadClient interface {
-	Recv() (*LogResponse, error)
+type Zsys_DatasetMakePersistentClient interface {
+	Recv() (*DatasetPersistentResponse, error)
 	grpc.ClientStream
 }
 
-type zsysReloadClient struct {
+type zsysDatasetMakePersistentClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysReloadClient) Recv() (*LogResponse, error) {
-	m := new(LogResponse)
+func (x *zsysDatasetMakePersistentClient) Recv() (*DatasetPersistentResponse, error) {
+	m := new(DatasetPersistentResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[19], Zsys_GC_FullMethodName, opts...)
+func (c *zsysClient) WatchEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[37], Zsys_WatchEvents_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysGCClient{stream}
+	x := &zsysWatchEventsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -702,29 +1313,29 @@ func (c *zsysClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOpt
 	return x, nil
 }
 
-type Zsys_GCClient interface {
-	Recv() (*LogResponse, error)
+type Zsys_WatchEventsClient interface {
+	Recv() (*WatchEventsResponse, error)
 	grpc.ClientStream
 }
 
-type zsysGCClient struct {
+type zsysWatchEventsClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysGCClient) Recv() (*LogResponse, error) {
-	m := new(LogResponse)
+func (x *zsysWatchEventsClient) Recv() (*WatchEventsResponse, error) {
+	m := new(WatchEventsResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) MachineShow(ctx context.Context, in *MachineShowRequest, opts ...grpc.CallOption) (Zsys_MachineShowClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[20], Zsys_MachineShow_FullMethodName, opts...)
+func (c *zsysClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Zsys_HistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[38], Zsys_History_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysMachineShowClient{stream}
+	x := &zsysHistoryClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -734,29 +1345,29 @@ func (c *zsysClient) MachineShow(ctx context.Context, in *MachineShowRequest, op
 	return x, nil
 }
 
-type Zsys_MachineShowClient interface {
-	Recv() (*MachineShowResponse, error)
+type Zsys_HistoryClient interface {
+	Recv() (*HistoryResponse, error)
 	grpc.ClientStream
 }
 
-type zsysMachineShowClient struct {
+type zsysHistoryClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysMachineShowClient) Recv() (*MachineShowResponse, error) {
-	m := new(MachineShowResponse)
+func (x *zsysHistoryClient) Recv() (*HistoryResponse, error) {
+	m := new(HistoryResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) MachineList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MachineListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[21], Zsys_MachineList_FullMethodName, opts...)
+func (c *zsysClient) Metrics(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MetricsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zsys_ServiceDesc.Streams[39], Zsys_Metrics_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysMachineListClient{stream}
+	x := &zsysMetricsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -766,17 +1377,17 @@ func (c *zsysClient) MachineList(ctx context.Context, in *Empty, opts ...grpc.Ca
 	return x, nil
 }
 
-type Zsys_MachineListClient interface {
-	Recv() (*MachineListResponse, error)
+type Zsys_MetricsClient interface {
+	Recv() (*MetricsResponse, error)
 	grpc.ClientStream
 }
 
-type zsysMachineListClient struct {
+type zsysMetricsClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysMachineListClient) Recv() (*MachineListResponse, error) {
-	m := new(MachineListResponse)
+func (x *zsysMetricsClient) Recv() (*MetricsResponse, error) {
+	m := new(MetricsResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -799,6 +1410,15 @@ type ZsysServer interface {
 	SaveUserState(*SaveUserStateRequest, Zsys_SaveUserStateServer) error
 	RemoveSystemState(*RemoveSystemStateRequest, Zsys_RemoveSystemStateServer) error
 	RemoveUserState(*RemoveUserStateRequest, Zsys_RemoveUserStateServer) error
+	RevertSystemState(*RevertSystemStateRequest, Zsys_RevertSystemStateServer) error
+	AnnotateSystemState(*AnnotateSystemStateRequest, Zsys_AnnotateSystemStateServer) error
+	AnnotateUserState(*AnnotateUserStateRequest, Zsys_AnnotateUserStateServer) error
+	PinSystemState(*PinSystemStateRequest, Zsys_PinSystemStateServer) error
+	PinUserState(*PinUserStateRequest, Zsys_PinUserStateServer) error
+	StateDiff(*StateDiffRequest, Zsys_StateDiffServer) error
+	ExportSystemState(*ExportSystemStateRequest, Zsys_ExportSystemStateServer) error
+	ImportSystemState(Zsys_ImportSystemStateServer) error
+	ReplicateStates(*Empty, Zsys_ReplicateStatesServer) error
 	DumpStates(*Empty, Zsys_DumpStatesServer) error
 	DaemonStop(*Empty, Zsys_DaemonStopServer) error
 	LoggingLevel(*LoggingLevelRequest, Zsys_LoggingLevelServer) error
@@ -809,6 +1429,15 @@ type ZsysServer interface {
 	GC(*GCRequest, Zsys_GCServer) error
 	MachineShow(*MachineShowRequest, Zsys_MachineShowServer) error
 	MachineList(*Empty, Zsys_MachineListServer) error
+	MachineClone(*MachineCloneRequest, Zsys_MachineCloneServer) error
+	MachineRemove(*MachineRemoveRequest, Zsys_MachineRemoveServer) error
+	DatasetExclude(*DatasetExcludeRequest, Zsys_DatasetExcludeServer) error
+	DatasetList(*Empty, Zsys_DatasetListServer) error
+	DatasetCreatePersistent(*DatasetPersistentRequest, Zsys_DatasetCreatePersistentServer) error
+	DatasetMakePersistent(*DatasetPersistentRequest, Zsys_DatasetMakePersistentServer) error
+	WatchEvents(*Empty, Zsys_WatchEventsServer) error
+	History(*HistoryRequest, Zsys_HistoryServer) error
+	Metrics(*Empty, Zsys_MetricsServer) error
 }
 
 // UnimplementedZsysServer should be embedded to have forward compatible implementations.
@@ -851,6 +1480,33 @@ func (UnimplementedZsysServer) RemoveSystemState(*RemoveSystemStateRequest, Zsys
 func (UnimplementedZsysServer) RemoveUserState(*RemoveUserStateRequest, Zsys_RemoveUserStateServer) error {
 	return status.Errorf(codes.Unimplemented, "method RemoveUserState not implemented")
 }
+func (UnimplementedZsysServer) RevertSystemState(*RevertSystemStateRequest, Zsys_RevertSystemStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method RevertSystemState not implemented")
+}
+func (UnimplementedZsysServer) AnnotateSystemState(*AnnotateSystemStateRequest, Zsys_AnnotateSystemStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method AnnotateSystemState not implemented")
+}
+func (UnimplementedZsysServer) AnnotateUserState(*AnnotateUserStateRequest, Zsys_AnnotateUserStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method AnnotateUserState not implemented")
+}
+func (UnimplementedZsysServer) PinSystemState(*PinSystemStateRequest, Zsys_PinSystemStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method PinSystemState not implemented")
+}
+func (UnimplementedZsysServer) PinUserState(*PinUserStateRequest, Zsys_PinUserStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method PinUserState not implemented")
+}
+func (UnimplementedZsysServer) StateDiff(*StateDiffRequest, Zsys_StateDiffServer) error {
+	return status.Errorf(codes.Unimplemented, "method StateDiff not implemented")
+}
+func (UnimplementedZsysServer) ExportSystemState(*ExportSystemStateRequest, Zsys_ExportSystemStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSystemState not implemented")
+}
+func (UnimplementedZsysServer) ImportSystemState(Zsys_ImportSystemStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportSystemState not implemented")
+}
+func (UnimplementedZsysServer) ReplicateStates(*Empty, Zsys_ReplicateStatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReplicateStates not implemented")
+}
 func (UnimplementedZsysServer) DumpStates(*Empty, Zsys_DumpStatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method DumpStates not implemented")
 }
@@ -881,6 +1537,33 @@ func (UnimplementedZsysServer) MachineShow(*MachineShowRequest, Zsys_MachineShow
 func (UnimplementedZsysServer) MachineList(*Empty, Zsys_MachineListServer) error {
 	return status.Errorf(codes.Unimplemented, "method MachineList not implemented")
 }
+func (UnimplementedZsysServer) MachineClone(*MachineCloneRequest, Zsys_MachineCloneServer) error {
+	return status.Errorf(codes.Unimplemented, "method MachineClone not implemented")
+}
+func (UnimplementedZsysServer) MachineRemove(*MachineRemoveRequest, Zsys_MachineRemoveServer) error {
+	return status.Errorf(codes.Unimplemented, "method MachineRemove not implemented")
+}
+func (UnimplementedZsysServer) DatasetExclude(*DatasetExcludeRequest, Zsys_DatasetExcludeServer) error {
+	return status.Errorf(codes.Unimplemented, "method DatasetExclude not implemented")
+}
+func (UnimplementedZsysServer) DatasetList(*Empty, Zsys_DatasetListServer) error {
+	return status.Errorf(codes.Unimplemented, "method DatasetList not implemented")
+}
+func (UnimplementedZsysServer) DatasetCreatePersistent(*DatasetPersistentRequest, Zsys_DatasetCreatePersistentServer) error {
+	return status.Errorf(codes.Unimplemented, "method DatasetCreatePersistent not implemented")
+}
+func (UnimplementedZsysServer) DatasetMakePersistent(*DatasetPersistentRequest, Zsys_DatasetMakePersistentServer) error {
+	return status.Errorf(codes.Unimplemented, "method DatasetMakePersistent not implemented")
+}
+func (UnimplementedZsysServer) WatchEvents(*Empty, Zsys_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedZsysServer) History(*HistoryRequest, Zsys_HistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedZsysServer) Metrics(*Empty, Zsys_MetricsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Metrics not implemented")
+}
 
 // UnsafeZsysServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ZsysServer will
@@ -956,192 +1639,386 @@ func (x *zsysChangeHomeOnUserDataServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_DissociateUser_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DissociateUserRequest)
+func _Zsys_DissociateUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DissociateUserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).DissociateUser(m, &zsysDissociateUserServer{stream})
+}
+
+type Zsys_DissociateUserServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysDissociateUserServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysDissociateUserServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_PrepareBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).PrepareBoot(m, &zsysPrepareBootServer{stream})
+}
+
+type Zsys_PrepareBootServer interface {
+	Send(*PrepareBootResponse) error
+	grpc.ServerStream
+}
+
+type zsysPrepareBootServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysPrepareBootServer) Send(m *PrepareBootResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_CommitBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).CommitBoot(m, &zsysCommitBootServer{stream})
+}
+
+type Zsys_CommitBootServer interface {
+	Send(*CommitBootResponse) error
+	grpc.ServerStream
+}
+
+type zsysCommitBootServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysCommitBootServer) Send(m *CommitBootResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_UpdateBootMenu_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateBootMenuRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).UpdateBootMenu(m, &zsysUpdateBootMenuServer{stream})
+}
+
+type Zsys_UpdateBootMenuServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysUpdateBootMenuServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysUpdateBootMenuServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_UpdateLastUsed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).UpdateLastUsed(m, &zsysUpdateLastUsedServer{stream})
+}
+
+type Zsys_UpdateLastUsedServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysUpdateLastUsedServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysUpdateLastUsedServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_SaveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SaveSystemStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).SaveSystemState(m, &zsysSaveSystemStateServer{stream})
+}
+
+type Zsys_SaveSystemStateServer interface {
+	Send(*CreateSaveStateResponse) error
+	grpc.ServerStream
+}
+
+type zsysSaveSystemStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysSaveSystemStateServer) Send(m *CreateSaveStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_SaveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SaveUserStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).SaveUserState(m, &zsysSaveUserStateServer{stream})
+}
+
+type Zsys_SaveUserStateServer interface {
+	Send(*CreateSaveStateResponse) error
+	grpc.ServerStream
+}
+
+type zsysSaveUserStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysSaveUserStateServer) Send(m *CreateSaveStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_RemoveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RemoveSystemStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).RemoveSystemState(m, &zsysRemoveSystemStateServer{stream})
+}
+
+type Zsys_RemoveSystemStateServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysRemoveSystemStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysRemoveSystemStateServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_RemoveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RemoveUserStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).RemoveUserState(m, &zsysRemoveUserStateServer{stream})
+}
+
+type Zsys_RemoveUserStateServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysRemoveUserStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysRemoveUserStateServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_RevertSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevertSystemStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).DissociateUser(m, &zsysDissociateUserServer{stream})
+	return srv.(ZsysServer).RevertSystemState(m, &zsysRevertSystemStateServer{stream})
 }
 
-type Zsys_DissociateUserServer interface {
+type Zsys_RevertSystemStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysDissociateUserServer struct {
+type zsysRevertSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysDissociateUserServer) Send(m *LogResponse) error {
+func (x *zsysRevertSystemStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_PrepareBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_AnnotateSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AnnotateSystemStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).PrepareBoot(m, &zsysPrepareBootServer{stream})
+	return srv.(ZsysServer).AnnotateSystemState(m, &zsysAnnotateSystemStateServer{stream})
 }
 
-type Zsys_PrepareBootServer interface {
-	Send(*PrepareBootResponse) error
+type Zsys_AnnotateSystemStateServer interface {
+	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysPrepareBootServer struct {
+type zsysAnnotateSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysPrepareBootServer) Send(m *PrepareBootResponse) error {
+func (x *zsysAnnotateSystemStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_CommitBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_AnnotateUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AnnotateUserStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).CommitBoot(m, &zsysCommitBootServer{stream})
+	return srv.(ZsysServer).AnnotateUserState(m, &zsysAnnotateUserStateServer{stream})
 }
 
-type Zsys_CommitBootServer interface {
-	Send(*CommitBootResponse) error
+type Zsys_AnnotateUserStateServer interface {
+	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysCommitBootServer struct {
+type zsysAnnotateUserStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysCommitBootServer) Send(m *CommitBootResponse) error {
+func (x *zsysAnnotateUserStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_UpdateBootMenu_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UpdateBootMenuRequest)
+func _Zsys_PinSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PinSystemStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).UpdateBootMenu(m, &zsysUpdateBootMenuServer{stream})
+	return srv.(ZsysServer).PinSystemState(m, &zsysPinSystemStateServer{stream})
 }
 
-type Zsys_UpdateBootMenuServer interface {
+type Zsys_PinSystemStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysUpdateBootMenuServer struct {
+type zsysPinSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysUpdateBootMenuServer) Send(m *LogResponse) error {
+func (x *zsysPinSystemStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_UpdateLastUsed_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_PinUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PinUserStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).UpdateLastUsed(m, &zsysUpdateLastUsedServer{stream})
+	return srv.(ZsysServer).PinUserState(m, &zsysPinUserStateServer{stream})
 }
 
-type Zsys_UpdateLastUsedServer interface {
+type Zsys_PinUserStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysUpdateLastUsedServer struct {
+type zsysPinUserStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysUpdateLastUsedServer) Send(m *LogResponse) error {
+func (x *zsysPinUserStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_SaveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SaveSystemStateRequest)
+func _Zsys_StateDiff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StateDiffRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).SaveSystemState(m, &zsysSaveSystemStateServer{stream})
+	return srv.(ZsysServer).StateDiff(m, &zsysStateDiffServer{stream})
 }
 
-type Zsys_SaveSystemStateServer interface {
-	Send(*CreateSaveStateResponse) error
+type Zsys_StateDiffServer interface {
+	Send(*StateDiffResponse) error
 	grpc.ServerStream
 }
 
-type zsysSaveSystemStateServer struct {
+type zsysStateDiffServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysSaveSystemStateServer) Send(m *CreateSaveStateResponse) error {
+func (x *zsysStateDiffServer) Send(m *StateDiffResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_SaveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SaveUserStateRequest)
+func _Zsys_ExportSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSystemStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).SaveUserState(m, &zsysSaveUserStateServer{stream})
+	return srv.(ZsysServer).ExportSystemState(m, &zsysExportSystemStateServer{stream})
 }
 
-type Zsys_SaveUserStateServer interface {
-	Send(*CreateSaveStateResponse) error
+type Zsys_ExportSystemStateServer interface {
+	Send(*ExportSystemStateResponse) error
 	grpc.ServerStream
 }
 
-type zsysSaveUserStateServer struct {
+type zsysExportSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysSaveUserStateServer) Send(m *CreateSaveStateResponse) error {
+func (x *zsysExportSystemStateServer) Send(m *ExportSystemStateResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_RemoveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RemoveSystemStateRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(ZsysServer).RemoveSystemState(m, &zsysRemoveSystemStateServer{stream})
+func _Zsys_ImportSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ZsysServer).ImportSystemState(&zsysImportSystemStateServer{stream})
 }
 
-type Zsys_RemoveSystemStateServer interface {
-	Send(*LogResponse) error
+type Zsys_ImportSystemStateServer interface {
+	Send(*CreateSaveStateResponse) error
+	Recv() (*ImportSystemStateRequest, error)
 	grpc.ServerStream
 }
 
-type zsysRemoveSystemStateServer struct {
+type zsysImportSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysRemoveSystemStateServer) Send(m *LogResponse) error {
+func (x *zsysImportSystemStateServer) Send(m *CreateSaveStateResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_RemoveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RemoveUserStateRequest)
+func (x *zsysImportSystemStateServer) Recv() (*ImportSystemStateRequest, error) {
+	m := new(ImportSystemStateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Zsys_ReplicateStates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).RemoveUserState(m, &zsysRemoveUserStateServer{stream})
+	return srv.(ZsysServer).ReplicateStates(m, &zsysReplicateStatesServer{stream})
 }
 
-type Zsys_RemoveUserStateServer interface {
+type Zsys_ReplicateStatesServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysRemoveUserStateServer struct {
+type zsysReplicateStatesServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysRemoveUserStateServer) Send(m *LogResponse) error {
+func (x *zsysReplicateStatesServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -1355,6 +2232,195 @@ func (x *zsysMachineListServer) Send(m *MachineListResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Zsys_MachineClone_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MachineCloneRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).MachineClone(m, &zsysMachineCloneServer{stream})
+}
+
+type Zsys_MachineCloneServer interface {
+	Send(*MachineCloneResponse) error
+	grpc.ServerStream
+}
+
+type zsysMachineCloneServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysMachineCloneServer) Send(m *MachineCloneResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_MachineRemove_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MachineRemoveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).MachineRemove(m, &zsysMachineRemoveServer{stream})
+}
+
+type Zsys_MachineRemoveServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysMachineRemoveServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysMachineRemoveServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_DatasetExclude_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DatasetExcludeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).DatasetExclude(m, &zsysDatasetExcludeServer{stream})
+}
+
+type Zsys_DatasetExcludeServer interface {
+	Send(*DatasetExcludeResponse) error
+	grpc.ServerStream
+}
+
+type zsysDatasetExcludeServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysDatasetExcludeServer) Send(m *DatasetExcludeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_DatasetList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).DatasetList(m, &zsysDatasetListServer{stream})
+}
+
+type Zsys_DatasetListServer interface {
+	Send(*DatasetListResponse) error
+	grpc.ServerStream
+}
+
+type zsysDatasetListServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysDatasetListServer) Send(m *DatasetListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_DatasetCreatePersistent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DatasetPersistentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).DatasetCreatePersistent(m, &zsysDatasetCreatePersistentServer{stream})
+}
+
+type Zsys_DatasetCreatePersistentServer interface {
+	Send(*DatasetPersistentResponse) error
+	grpc.ServerStream
+}
+
+type zsysDatasetCreatePersistentServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysDatasetCreatePersistentServer) Send(m *DatasetPersistentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_DatasetMakePersistent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DatasetPersistentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).DatasetMakePersistent(m, &zsysDatasetMakePersistentServer{stream})
+}
+
+type Zsys_DatasetMakePersistentServer interface {
+	Send(*DatasetPersistentResponse) error
+	grpc.ServerStream
+}
+
+type zsysDatasetMakePersistentServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysDatasetMakePersistentServer) Send(m *DatasetPersistentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).WatchEvents(m, &zsysWatchEventsServer{stream})
+}
+
+type Zsys_WatchEventsServer interface {
+	Send(*WatchEventsResponse) error
+	grpc.ServerStream
+}
+
+type zsysWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysWatchEventsServer) Send(m *WatchEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_History_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).History(m, &zsysHistoryServer{stream})
+}
+
+type Zsys_HistoryServer interface {
+	Send(*HistoryResponse) error
+	grpc.ServerStream
+}
+
+type zsysHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysHistoryServer) Send(m *HistoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_Metrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).Metrics(m, &zsysMetricsServer{stream})
+}
+
+type Zsys_MetricsServer interface {
+	Send(*MetricsResponse) error
+	grpc.ServerStream
+}
+
+type zsysMetricsServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysMetricsServer) Send(m *MetricsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Zsys_ServiceDesc is the grpc.ServiceDesc for Zsys service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1423,6 +2489,52 @@ var Zsys_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Zsys_RemoveUserState_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "RevertSystemState",
+			Handler:       _Zsys_RevertSystemState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AnnotateSystemState",
+			Handler:       _Zsys_AnnotateSystemState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AnnotateUserState",
+			Handler:       _Zsys_AnnotateUserState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PinSystemState",
+			Handler:       _Zsys_PinSystemState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PinUserState",
+			Handler:       _Zsys_PinUserState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StateDiff",
+			Handler:       _Zsys_StateDiff_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportSystemState",
+			Handler:       _Zsys_ExportSystemState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportSystemState",
+			Handler:       _Zsys_ImportSystemState_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReplicateStates",
+			Handler:       _Zsys_ReplicateStates_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "DumpStates",
 			Handler:       _Zsys_DumpStates_Handler,
@@ -1473,6 +2585,51 @@ var Zsys_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Zsys_MachineList_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "MachineClone",
+			Handler:       _Zsys_MachineClone_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MachineRemove",
+			Handler:       _Zsys_MachineRemove_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DatasetExclude",
+			Handler:       _Zsys_DatasetExclude_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DatasetList",
+			Handler:       _Zsys_DatasetList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DatasetCreatePersistent",
+			Handler:       _Zsys_DatasetCreatePersistent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DatasetMakePersistent",
+			Handler:       _Zsys_DatasetMakePersistent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Zsys_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "History",
+			Handler:       _Zsys_History_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Metrics",
+			Handler:       _Zsys_Metrics_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "zsys.proto",
 }